@@ -0,0 +1,8 @@
+package openapi
+
+import _ "embed"
+
+// Spec is the OpenAPI document of the REST gateway, generated from the protos.
+//
+//go:embed proto/v1/credit_application.swagger.json
+var Spec []byte
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func newGetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get <application-id>",
+		Short: "Print an application straight from the database",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, log, err := initCLILogger(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer log.Sync()
+
+			a, err := newApp(ctx, appOptions{})
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+
+			app, err := a.getUC.Execute(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			return printJSON(app)
+		},
+	}
+}
+
+func newListCmd() *cobra.Command {
+	var (
+		statuses []string
+		userID   string
+		page     int
+		pageSize int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List applications straight from the database",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, log, err := initCLILogger(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer log.Sync()
+
+			a, err := newApp(ctx, appOptions{})
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+
+			domainStatuses := make([]domain.ApplicationStatus, 0, len(statuses))
+			for _, status := range statuses {
+				domainStatuses = append(domainStatuses, domain.ApplicationStatus(strings.ToUpper(status)))
+			}
+
+			result, err := a.listUC.Execute(ctx, domainStatuses, page, pageSize, userID)
+			if err != nil {
+				return err
+			}
+			return printJSON(result)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "status filter, repeatable")
+	cmd.Flags().StringVar(&userID, "user", "", "user ID filter")
+	cmd.Flags().IntVar(&page, "page", 1, "page number")
+	cmd.Flags().IntVar(&pageSize, "page-size", 20, "page size")
+
+	return cmd
+}
+
+func newTransitionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "transition <application-id> <status>",
+		Short: "Move an application to a new status and publish the status event",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			appID, err := uuid.Parse(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid application ID: %w", err)
+			}
+
+			ctx, log, err := initCLILogger(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer log.Sync()
+
+			a, err := newApp(ctx, appOptions{withRedis: true, withProducer: true})
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+
+			status := domain.ApplicationStatus(strings.ToUpper(args[1]))
+			if err := a.updateStatusUC.Execute(ctx, appID, status); err != nil {
+				return err
+			}
+
+			app, err := a.getUC.Execute(ctx, appID.String())
+			if err != nil {
+				return err
+			}
+			return printJSON(app)
+		},
+	}
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,614 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Andronzi/credit-origination/config"
+	"github.com/Andronzi/credit-origination/internal/antifraud"
+	"github.com/Andronzi/credit-origination/internal/bureau"
+	"github.com/Andronzi/credit-origination/internal/chaos"
+	"github.com/Andronzi/credit-origination/internal/client"
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/internal/messaging/handlers"
+	"github.com/Andronzi/credit-origination/internal/middleware"
+	"github.com/Andronzi/credit-origination/internal/repository"
+	"github.com/Andronzi/credit-origination/internal/resilience"
+	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/internal/watch"
+	"github.com/Andronzi/credit-origination/pkg/database"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"github.com/Andronzi/credit-origination/pkg/money"
+	"github.com/go-redis/redis/v8"
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+type appOptions struct {
+	// withRedis connects Redis for idempotency, the application cache and
+	// status change notifications to watchers in other replicas.
+	withRedis bool
+	// withProducer connects the Kafka producer; required by every path that
+	// changes an application status.
+	withProducer bool
+}
+
+// app holds the dependencies shared by the serve and admin commands.
+type app struct {
+	log              *zap.Logger
+	db               *gorm.DB
+	redis            *redis.Client
+	producer         *messaging.KafkaProducer
+	injector         *chaos.Injector
+	repo             domain.CreditRepository
+	serverCfg        *config.ServerConfig
+	kafkaCfg         *config.KafkaConfig
+	chaosCfg         *config.ChaosConfig
+	cacheCfg         *config.CacheConfig
+	idempotency      middleware.IdempotencyConfig
+	currencyCfg      *config.CurrencyConfig
+	currencies       *domain.ProductCurrencies
+	watchCfg         *config.WatchConfig
+	bulkCfg          *config.BulkConfig
+	expiryCfg        *config.ExpiryConfig
+	leaderCfg        *config.LeaderConfig
+	antifraudCfg     *config.AntifraudConfig
+	reviewCfg        *config.ReviewConfig
+	affordabilityCfg *config.AffordabilityConfig
+	catalogueCfg     *config.CatalogueConfig
+	consentCfg       *config.ConsentConfig
+	bureauCfg        *config.BureauConfig
+	resilienceCfg    *config.ResilienceConfig
+	dependencies     *resilience.Registry
+	schemaRegistry   *client.SchemaRegistryClient
+	reviews          domain.ReviewRepository
+	offers           domain.OfferRepository
+	consents         domain.ConsentRepository
+	tx               domain.Transactor
+	bureauReports    domain.BureauReportRepository
+	hub              *watch.Hub
+	redisNotifier    *watch.RedisNotifier
+	closers          []func() error
+	getUC            *usecase.GetApplicationUseCase
+	createUC         *usecase.CreateApplicationUseCase
+	listUC           *usecase.ListApplicationUseCase
+	updateUC         *usecase.UpdateApplicationUseCase
+	updateStatusUC   *usecase.UpdateStatusUseCase
+	deleteUC         *usecase.DeleteApplicationUseCase
+	cancelUC         *usecase.CancelApplicationUseCase
+	replayUC         *usecase.ReplayStatusEventsUseCase
+	batchGetUC       *usecase.BatchGetApplicationsUseCase
+	batchCreateUC    *usecase.BatchCreateApplicationsUseCase
+	bulkTransitionUC *usecase.BulkTransitionUseCase
+	exportUC         *usecase.ExportApplicationsUseCase
+	reviewUC         *usecase.ManualReviewUseCase
+	offerUC          *usecase.CounterOfferUseCase
+	consentUC        *usecase.ConsentUseCase
+	expireUC         *usecase.ExpireApplicationsUseCase
+	scoreUC          *usecase.ScoreApplicationUseCase
+	scoring          *client.ScoringClient
+}
+
+func newApp(ctx context.Context, opts appOptions) (*app, error) {
+	a := &app{
+		log:              logger.FromContext(ctx),
+		serverCfg:        config.NewServerConfig(),
+		kafkaCfg:         config.NewKafkaConfig(),
+		chaosCfg:         config.NewChaosConfig(),
+		cacheCfg:         config.NewCacheConfig(),
+		currencyCfg:      config.NewCurrencyConfig(),
+		watchCfg:         config.NewWatchConfig(),
+		bulkCfg:          config.NewBulkConfig(),
+		expiryCfg:        config.NewExpiryConfig(),
+		leaderCfg:        config.NewLeaderConfig(),
+		antifraudCfg:     config.NewAntifraudConfig(),
+		reviewCfg:        config.NewReviewConfig(),
+		affordabilityCfg: config.NewAffordabilityConfig(),
+		catalogueCfg:     config.NewCatalogueConfig(),
+		consentCfg:       config.NewConsentConfig(),
+		bureauCfg:        config.NewBureauConfig(),
+		resilienceCfg:    config.NewResilienceConfig(),
+	}
+
+	if err := a.resilienceCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid resilience configuration: %w", err)
+	}
+	dependencies, err := resilience.NewRegistry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to init dependency metrics: %w", err)
+	}
+	a.dependencies = dependencies
+	a.schemaRegistry = client.NewSchemaRegistryClient(
+		a.kafkaCfg.SchemaRegistryURL,
+		newHTTPClient(a.dependencies, "schema-registry", a.resilienceCfg.SchemaRegistry),
+	)
+
+	a.idempotency, err = initIdempotencyConfig(config.NewIdempotencyConfig())
+	if err != nil {
+		return nil, fmt.Errorf("invalid idempotency configuration: %w", err)
+	}
+
+	currencies, err := initProductCurrencies(a.currencyCfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid currency configuration: %w", err)
+	}
+	a.currencies = currencies
+
+	expiryPolicy, err := initExpiryPolicy(a.expiryCfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expiry configuration: %w", err)
+	}
+
+	fourEyes, err := initFourEyesPolicy(a.reviewCfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid review configuration: %w", err)
+	}
+
+	affordability, err := initAffordabilityPolicy(a.affordabilityCfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid affordability configuration: %w", err)
+	}
+
+	counterOffers, err := initCounterOfferGenerator(a.catalogueCfg, affordability)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product catalogue configuration: %w", err)
+	}
+
+	creditBureau, err := initCreditBureau(a.bureauCfg, a.dependencies)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bureau configuration: %w", err)
+	}
+
+	consentPolicy, err := initConsentPolicy(a.consentCfg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid consent configuration: %w", err)
+	}
+
+	dbCfg := config.NewDatabaseConfig()
+	dbLogLevel, err := database.ParseLogLevel(dbCfg.LogLevel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DB_LOG_LEVEL: %w", err)
+	}
+	db, err := database.ConnectPostgres(database.NewGormLogger(a.log, dbLogLevel, dbCfg.SlowQueryThreshold))
+	if err != nil {
+		return nil, fmt.Errorf("database connection failed: %w", err)
+	}
+	a.db = db
+	a.log.Info("Database connection success", zap.String("origination-service", "main.go"))
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get database instance: %w", err)
+	}
+	a.closers = append(a.closers, sqlDB.Close)
+	if err := database.EnsureSchemaUpToDate(ctx, sqlDB); err != nil {
+		return nil, fmt.Errorf("refusing to start, run `migrate up` first: %w", err)
+	}
+
+	if opts.withRedis {
+		a.redis, err = database.ConnectRedis()
+		if err != nil {
+			return nil, fmt.Errorf("redis connection failed: %w", err)
+		}
+		a.closers = append(a.closers, a.redis.Close)
+		a.log.Info("Redis connection success", zap.String("origination-service", "main.go"))
+	}
+
+	if opts.withProducer {
+		if err := checkSchemaRegistry(a.kafkaCfg.SchemaRegistryURL); err != nil {
+			return nil, err
+		}
+		a.producer, err = initKafkaProducer(a.kafkaCfg, a.schemaRegistry, a.dependencies.Register("kafka-producer", dependencySettings(a.resilienceCfg.KafkaProducer)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to init Kafka producer: %w", err)
+		}
+		a.closers = append(a.closers, a.producer.Close)
+		a.log.Info("Kafka producer connection success", zap.String("origination-service", "main.go"))
+	}
+
+	a.injector, err = initFaultInjector(a.log, a.chaosCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to init fault injector: %w", err)
+	}
+
+	a.repo = chaos.NewFaultyRepository(repository.NewCreditRepo(db), a.injector)
+	if a.redis != nil && a.cacheCfg.Enabled {
+		a.repo = repository.NewCachedCreditRepo(a.repo, a.redis, a.cacheCfg.ApplicationTTL, a.cacheCfg.ListTTL)
+		a.log.Info("Application cache enabled",
+			zap.Duration("application_ttl", a.cacheCfg.ApplicationTTL),
+			zap.Duration("list_ttl", a.cacheCfg.ListTTL),
+		)
+	}
+
+	a.reviews = repository.NewReviewRepo(db)
+	a.offers = repository.NewOfferRepo(db)
+	a.consents = repository.NewConsentRepo(db)
+	a.tx = repository.NewTransactor(db)
+	a.bureauReports = repository.NewBureauReportRepo(db)
+
+	a.scoring = client.NewScoringClient(a.serverCfg.ScoringURL, newHTTPClient(a.dependencies, "scoring", a.resilienceCfg.Scoring))
+
+	a.hub = watch.NewHub(a.watchCfg.BufferSize)
+	var notifier domain.StatusNotifier = a.hub
+	if a.redis != nil {
+		a.redisNotifier = watch.NewRedisNotifier(a.redis, a.watchCfg.Channel, a.hub)
+		notifier = a.redisNotifier
+	}
+
+	screener, err := initScreener(a.antifraudCfg, a.repo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid antifraud configuration: %w", err)
+	}
+
+	a.createUC = usecase.NewCreateApplicationUseCase(a.repo, a.reviews, a.offers, a.scoring, screener, affordability, counterOffers, a.tx, a.producer, notifier)
+	a.listUC = usecase.NewListApplicationUseCase(a.repo)
+	a.getUC = usecase.NewGetApplicationUseCase(a.repo)
+	a.updateUC = usecase.NewUpdateApplicationUseCase(a.repo)
+	a.updateStatusUC = usecase.NewUpdateStatusUseCase(a.repo, a.consents, consentPolicy, a.producer, notifier)
+	a.deleteUC = usecase.NewDeleteApplicationUseCase(a.repo)
+	a.cancelUC = usecase.NewCancelApplicationUseCase(a.repo, a.producer, notifier)
+	a.replayUC = usecase.NewReplayStatusEventsUseCase(a.repo, a.producer)
+	a.exportUC = usecase.NewExportApplicationsUseCase(a.repo)
+
+	limits := usecase.BulkLimits{
+		ChunkSize:        a.bulkCfg.ChunkSize,
+		Concurrency:      a.bulkCfg.Concurrency,
+		MaxBatchSize:     a.bulkCfg.MaxBatchSize,
+		MaxFilterMatches: a.bulkCfg.MaxFilterMatches,
+	}
+	a.batchGetUC = usecase.NewBatchGetApplicationsUseCase(a.repo, limits)
+	a.batchCreateUC = usecase.NewBatchCreateApplicationsUseCase(a.repo, a.reviews, a.offers, screener, affordability, counterOffers, a.tx, a.producer, notifier, limits)
+	a.bulkTransitionUC = usecase.NewBulkTransitionUseCase(a.repo, a.consents, consentPolicy, a.producer, notifier, limits)
+	a.expireUC = usecase.NewExpireApplicationsUseCase(a.repo, a.tx, repository.NewJobFence(db), a.producer, notifier, expiryPolicy, a.expiryCfg.BatchSize)
+	a.offerUC = usecase.NewCounterOfferUseCase(a.repo, a.offers, a.tx, a.producer, notifier)
+	a.consentUC = usecase.NewConsentUseCase(a.repo, a.consents, consentPolicy, a.tx, a.producer, notifier)
+	a.reviewUC = usecase.NewManualReviewUseCase(a.reviews, a.repo, a.tx, a.producer, notifier, fourEyes, a.reviewCfg.SLA)
+	if creditBureau != nil {
+		a.scoreUC = usecase.NewScoreApplicationUseCase(a.repo, a.offers, a.bureauReports, creditBureau, newScorecard(a.bureauCfg), counterOffers, a.tx, a.producer, notifier)
+	}
+
+	return a, nil
+}
+
+func (a *app) Close() {
+	for i := len(a.closers) - 1; i >= 0; i-- {
+		if err := a.closers[i](); err != nil {
+			a.log.Error("Failed to close resource", zap.Error(err))
+		}
+	}
+}
+
+func checkSchemaRegistry(registryURL string) error {
+	addr, err := hostPort(registryURL)
+	if err != nil {
+		return err
+	}
+	conn, err := net.DialTimeout("tcp", addr, 5*time.Second)
+	if err != nil {
+		return fmt.Errorf("schema registry unavailable: %w", err)
+	}
+	return conn.Close()
+}
+
+// TODO: Унифицировать создание
+func initKafkaProducer(cfg *config.KafkaConfig, registry *client.SchemaRegistryClient, dep *resilience.Dependency) (*messaging.KafkaProducer, error) {
+	schema, err := os.ReadFile(cfg.SchemaFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return messaging.NewKafkaProducer(
+		cfg.Brokers,
+		cfg.StatusTopic,
+		cfg.SchemaSubject,
+		string(schema),
+		registry,
+		dep,
+	)
+}
+
+func initProductCurrencies(cfg *config.CurrencyConfig) (*domain.ProductCurrencies, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	fallback, _ := money.ParseCurrency(cfg.Default)
+	products := make(map[string]money.Currency, len(cfg.Products))
+	for product, code := range cfg.Products {
+		products[product], _ = money.ParseCurrency(code)
+	}
+	return domain.NewProductCurrencies(fallback, products), nil
+}
+
+func initIdempotencyConfig(cfg *config.IdempotencyConfig) (middleware.IdempotencyConfig, error) {
+	if err := cfg.Validate(); err != nil {
+		return middleware.IdempotencyConfig{}, err
+	}
+
+	methodTTL := make(map[string]time.Duration, len(cfg.MethodTTLs))
+	for method, ttl := range cfg.MethodTTLs {
+		methodTTL[method], _ = time.ParseDuration(ttl)
+	}
+	return middleware.IdempotencyConfig{
+		DefaultTTL:   cfg.TTL,
+		MethodTTL:    methodTTL,
+		LockTTL:      cfg.LockTTL,
+		WaitTimeout:  cfg.WaitTimeout,
+		PollInterval: cfg.PollInterval,
+	}, nil
+}
+
+func initTokenVerifier(cfg *config.AuthConfig) (*middleware.TokenVerifier, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	secret, err := os.ReadFile(cfg.TokenSecretFile)
+	if err != nil {
+		return nil, err
+	}
+	return middleware.NewTokenVerifier(bytes.TrimSpace(secret), cfg.TokenIssuer)
+}
+
+func initTrustedProxies(cfg *config.ServerConfig) ([]netip.Prefix, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	proxies := make([]netip.Prefix, 0, len(cfg.TrustedProxies))
+	for _, proxy := range cfg.TrustedProxies {
+		proxies = append(proxies, netip.MustParsePrefix(proxy))
+	}
+	return proxies, nil
+}
+
+func initExpiryPolicy(cfg *config.ExpiryConfig) (*domain.ExpiryPolicy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	parse := func(ttls map[string]string) map[domain.ApplicationStatus]time.Duration {
+		parsed := make(map[domain.ApplicationStatus]time.Duration, len(ttls))
+		for status, ttl := range ttls {
+			parsed[domain.ApplicationStatus(strings.ToUpper(status))], _ = time.ParseDuration(ttl)
+		}
+		return parsed
+	}
+	products := make(map[string]map[domain.ApplicationStatus]time.Duration, len(cfg.ProductTTLs))
+	for product, ttls := range cfg.ProductTTLs {
+		products[product] = parse(ttls)
+	}
+	return domain.NewExpiryPolicy(parse(cfg.TTLs), products)
+}
+
+func initFourEyesPolicy(cfg *config.ReviewConfig) (*domain.FourEyesPolicy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	fallback, _ := decimal.NewFromString(cfg.FourEyesThreshold)
+	currencies := make(map[string]decimal.Decimal, len(cfg.FourEyesThresholds))
+	for currency, amount := range cfg.FourEyesThresholds {
+		currencies[currency], _ = decimal.NewFromString(amount)
+	}
+	return domain.NewFourEyesPolicy(fallback, currencies), nil
+}
+
+// initCreditBureau returns nil when bureau scoring is disabled.
+func initCreditBureau(cfg *config.BureauConfig, dependencies *resilience.Registry) (domain.CreditBureau, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	return bureau.NewHTTPBureau(cfg.Name, cfg.URL, newHTTPClient(dependencies, "bureau", cfg.Client)), nil
+}
+
+func dependencySettings(cfg config.DependencyConfig) resilience.Settings {
+	return resilience.Settings{
+		Timeout: cfg.Timeout,
+		Retry: resilience.RetryPolicy{
+			Attempts:  cfg.Retries + 1,
+			BaseDelay: cfg.RetryBaseDelay,
+			MaxDelay:  cfg.RetryMaxDelay,
+		},
+		BreakerFailures: cfg.BreakerFailures,
+		BreakerCooldown: cfg.BreakerCooldown,
+		MaxConcurrent:   cfg.MaxConcurrent,
+		MaxWait:         cfg.MaxWait,
+	}
+}
+
+func newHTTPClient(dependencies *resilience.Registry, name string, cfg config.DependencyConfig) *resilience.HTTPClient {
+	return resilience.NewHTTPClient(dependencies.Register(name, dependencySettings(cfg)))
+}
+
+func newScorecard(cfg *config.BureauConfig) domain.Scorecard {
+	return domain.Scorecard{
+		Base:            cfg.ScorecardBase,
+		Cutoff:          cfg.ScorecardCutoff,
+		RecentInquiries: cfg.ScorecardRecentInquiries,
+		InquiryWindow:   cfg.ScorecardInquiryWindow,
+	}
+}
+
+// initAffordabilityPolicy returns nil when the DTI check is disabled.
+func initAffordabilityPolicy(cfg *config.AffordabilityConfig) (*domain.AffordabilityPolicy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	fallback, _ := decimal.NewFromString(cfg.MaxDTI)
+	step, _ := decimal.NewFromString(cfg.DependantStep)
+	products := make(map[string]decimal.Decimal, len(cfg.ProductMaxDTI))
+	for product, ratio := range cfg.ProductMaxDTI {
+		products[product], _ = decimal.NewFromString(ratio)
+	}
+	return domain.NewAffordabilityPolicy(fallback, products, step), nil
+}
+
+// initCounterOfferGenerator returns nil when the DTI check is disabled or the
+// catalogue is empty.
+func initCounterOfferGenerator(cfg *config.CatalogueConfig, affordability *domain.AffordabilityPolicy) (*domain.CounterOfferGenerator, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if affordability == nil || len(cfg.Products) == 0 {
+		return nil, nil
+	}
+
+	products := make(map[string]domain.ProductLimits, len(cfg.Products))
+	for product, spec := range cfg.Products {
+		limits, _ := config.ParseProductLimits(spec)
+		products[product] = domain.ProductLimits(limits)
+	}
+	return domain.NewCounterOfferGenerator(domain.NewProductCatalogue(products), affordability), nil
+}
+
+func initConsentPolicy(cfg *config.ConsentConfig) (*domain.ConsentPolicy, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	parse := func(required map[string][]string) (map[domain.ApplicationStatus][]domain.ConsentType, error) {
+		parsed := make(map[domain.ApplicationStatus][]domain.ConsentType, len(required))
+		for status, consents := range required {
+			types := make([]domain.ConsentType, 0, len(consents))
+			for _, consent := range consents {
+				consentType, err := domain.ParseConsentType(consent)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", status, err)
+				}
+				types = append(types, consentType)
+			}
+			parsed[domain.ApplicationStatus(strings.ToUpper(status))] = types
+		}
+		return parsed, nil
+	}
+	defaults, err := parse(cfg.Required)
+	if err != nil {
+		return nil, err
+	}
+	products := make(map[string]map[domain.ApplicationStatus][]domain.ConsentType, len(cfg.ProductRequired))
+	for product, required := range cfg.ProductRequired {
+		if products[product], err = parse(required); err != nil {
+			return nil, fmt.Errorf("product %s: %w", product, err)
+		}
+	}
+	return domain.NewConsentPolicy(defaults, products), nil
+}
+
+// initScreener returns nil when antifraud is disabled.
+func initScreener(cfg *config.AntifraudConfig, repo domain.CreditRepository) (*antifraud.Screener, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	spikeFactor, _ := decimal.NewFromString(cfg.AmountSpikeFactor)
+	known := map[string]antifraud.Rule{
+		"velocity":                antifraud.VelocityRule{Max: cfg.VelocityMax, Window: cfg.VelocityWindow},
+		"shared_bank_account":     antifraud.SharedBankAccountRule{},
+		"amount_spike":            antifraud.AmountSpikeRule{Factor: spikeFactor},
+		"reapply_after_rejection": antifraud.ReapplyAfterRejectionRule{Cooldown: cfg.ReapplyCooldown},
+	}
+
+	var rules []antifraud.WeightedRule
+	for name, score := range cfg.RuleScores {
+		rule, ok := known[name]
+		if !ok {
+			return nil, fmt.Errorf("ANTIFRAUD_RULE_SCORES: unknown rule %q", name)
+		}
+		if score > 0 {
+			rules = append(rules, antifraud.WeightedRule{Rule: rule, Score: score})
+		}
+	}
+	// Порядок флагов в заявке не должен зависеть от обхода map.
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Rule.Name() < rules[j].Rule.Name() })
+
+	return antifraud.NewScreener(
+		repo,
+		rules,
+		antifraud.Thresholds{Review: cfg.ReviewScore, Reject: cfg.RejectScore},
+		cfg.Lookback,
+		cfg.HistoryLimit,
+	), nil
+}
+
+func initFaultInjector(log *zap.Logger, cfg *config.ChaosConfig) (*chaos.Injector, error) {
+	var rules []chaos.Rule
+	if cfg.RulesFile != "" {
+		var err error
+		rules, err = chaos.LoadRules(cfg.RulesFile)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.Enabled {
+		log.Warn("Fault injection is enabled", zap.Int("rules", len(rules)))
+	}
+
+	return chaos.NewInjector(cfg.Enabled, rules), nil
+}
+
+func initKafkaConsumer(
+	cfg *config.KafkaConfig,
+	registry *client.SchemaRegistryClient,
+	updateStatusUC *usecase.UpdateStatusUseCase,
+	scoreUC *usecase.ScoreApplicationUseCase,
+	injector *chaos.Injector,
+) (*messaging.KafkaAvroConsumer, error) {
+	decoder := messaging.NewEventDecoder(registry)
+
+	agreementHandler, err := handlers.NewAgreementCreatedHandler(updateStatusUC, decoder)
+	if err != nil {
+		return nil, err
+	}
+	scoringHandler, err := handlers.NewScoringHandler(updateStatusUC, scoreUC, decoder)
+	if err != nil {
+		return nil, err
+	}
+
+	handlers := []messaging.MessageHandler{
+		chaos.NewFaultyHandler(agreementHandler, injector),
+		chaos.NewFaultyHandler(scoringHandler, injector),
+	}
+
+	consumer, err := messaging.NewKafkaAvroConsumer(
+		cfg.Brokers,
+		cfg.ConsumerGroup,
+		cfg.StatusTopic,
+		decoder,
+		handlers,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return consumer, nil
+}
+
+func hostPort(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid url %q", rawURL)
+	}
+	return u.Host, nil
+}
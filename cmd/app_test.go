@@ -0,0 +1,113 @@
+package main
+
+import (
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestInitTrustedProxies(t *testing.T) {
+	tests := []struct {
+		name    string
+		proxies []string
+		want    []netip.Prefix
+		wantErr bool
+	}{
+		{"loopback", []string{"127.0.0.0/8", "::1/128"},
+			[]netip.Prefix{netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("::1/128")}, false},
+		{"none", nil, []netip.Prefix{}, false},
+		{"not a cidr", []string{"10.0.0.1"}, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewServerConfig()
+			cfg.TrustedProxies = tt.proxies
+
+			got, err := initTrustedProxies(cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("initTrustedProxies() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("initTrustedProxies() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("initTrustedProxies() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestInitTokenVerifier(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	// Файлы секретов обычно заканчиваются переводом строки.
+	if err := os.WriteFile(secretFile, []byte("test-secret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	emptyFile := filepath.Join(dir, "empty")
+	if err := os.WriteFile(emptyFile, []byte(" \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		cfg     config.AuthConfig
+		wantErr bool
+	}{
+		{"secret file", config.AuthConfig{TokenSecretFile: secretFile, TokenIssuer: "credit-origination"}, false},
+		{"missing file", config.AuthConfig{TokenSecretFile: filepath.Join(dir, "missing"), TokenIssuer: "credit-origination"}, true},
+		{"empty secret", config.AuthConfig{TokenSecretFile: emptyFile, TokenIssuer: "credit-origination"}, true},
+		{"no issuer", config.AuthConfig{TokenSecretFile: secretFile}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifier, err := initTokenVerifier(&tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("initTokenVerifier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+				Subject:   "alice",
+				Issuer:    tt.cfg.TokenIssuer,
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			}).SignedString([]byte("test-secret"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if subject, err := verifier.Verify(token); err != nil || subject != "alice" {
+				t.Fatalf("Verify() = %q, %v, want alice", subject, err)
+			}
+		})
+	}
+}
+
+func TestHostPort(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"http://schema-registry:8081", "schema-registry:8081", false},
+		{"https://bureau.example.com/v1", "bureau.example.com", false},
+		{"schema-registry:8081", "", true},
+		{"", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := hostPort(tt.in)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Fatalf("hostPort(%q) = %q, %v, want %q, wantErr %v", tt.in, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Andronzi/credit-origination/config"
+	"github.com/Andronzi/credit-origination/internal/bureau"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+func newStubBureauCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stub-bureau",
+		Short: "Serve credit bureau reports from a fixtures file for local runs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, log, err := initCLILogger(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer log.Sync()
+
+			cfg := config.NewBureauConfig()
+			fixtures, err := bureau.LoadFixtures(cfg.StubFixtures)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			srv := &http.Server{
+				Addr:              cfg.StubAddr,
+				Handler:           bureau.NewStubHandler(fixtures),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+			go func() {
+				<-ctx.Done()
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+				defer cancel()
+				_ = srv.Shutdown(shutdownCtx)
+			}()
+
+			log.Info("Stub bureau is running",
+				zap.String("addr", cfg.StubAddr),
+				zap.String("fixtures", cfg.StubFixtures),
+				zap.Int("reports", len(fixtures)),
+			)
+			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+	}
+}
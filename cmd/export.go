@@ -0,0 +1,100 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/export"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+)
+
+func newExportCmd() *cobra.Command {
+	var (
+		format   string
+		columns  []string
+		statuses []string
+		userID   string
+		from     string
+		to       string
+		output   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export applications as CSV, JSON Lines or Parquet",
+		Example: `  main export --format csv --status APPROVED --from 2025-03-01T00:00:00Z > approved.csv
+  main export --format parquet --columns id,status,disbursement_amount,currency -o march.parquet`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			exportFormat, err := export.ParseFormat(format)
+			if err != nil {
+				return err
+			}
+			exportColumns, err := export.Columns(columns)
+			if err != nil {
+				return err
+			}
+
+			filter := domain.ApplicationFilter{UserID: userID}
+			if userID != "" {
+				if _, err := uuid.Parse(userID); err != nil {
+					return fmt.Errorf("invalid --user-id: %w", err)
+				}
+			}
+			for _, status := range statuses {
+				filter.Statuses = append(filter.Statuses, domain.ApplicationStatus(strings.ToUpper(status)))
+			}
+			if from != "" {
+				if filter.CreatedFrom, err = time.Parse(time.RFC3339, from); err != nil {
+					return fmt.Errorf("invalid --from: %w", err)
+				}
+			}
+			if to != "" {
+				if filter.CreatedTo, err = time.Parse(time.RFC3339, to); err != nil {
+					return fmt.Errorf("invalid --to: %w", err)
+				}
+			}
+
+			var w io.Writer = os.Stdout
+			if output != "" && output != "-" {
+				f, err := os.Create(output)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+
+			ctx, log, err := initCLILogger(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer log.Sync()
+
+			a, err := newApp(ctx, appOptions{})
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+
+			rows, err := a.exportUC.Execute(ctx, filter, exportFormat, exportColumns, w)
+			fmt.Fprintf(os.Stderr, "exported %d rows\n", rows)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&format, "format", "csv", "output format: csv, jsonl or parquet")
+	cmd.Flags().StringSliceVar(&columns, "columns", nil, "columns to export, in order (default all)")
+	cmd.Flags().StringSliceVar(&statuses, "status", nil, "status filter, repeatable")
+	cmd.Flags().StringVar(&userID, "user-id", "", "user ID filter")
+	cmd.Flags().StringVar(&from, "from", "", "created_at lower bound, RFC 3339, inclusive")
+	cmd.Flags().StringVar(&to, "to", "", "created_at upper bound, RFC 3339, exclusive")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "output file (default stdout)")
+
+	return cmd
+}
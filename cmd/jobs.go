@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Andronzi/credit-origination/internal/leader"
+	"github.com/Andronzi/credit-origination/internal/repository"
+	"github.com/Andronzi/credit-origination/internal/scheduler"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+)
+
+// singletonJobs lists the background jobs that must run on one replica only.
+func singletonJobs(a *app) []leader.Job {
+	var jobs []leader.Job
+	if a.expiryCfg.Enabled() {
+		locker := repository.NewAdvisoryLock(a.db, int64(a.expiryCfg.LockKey))
+		jobs = append(jobs, scheduler.NewExpiryScheduler(a.expireUC, locker).Job(a.expiryCfg.Interval))
+	}
+	return jobs
+}
+
+// startSingletonJobs campaigns for leadership and runs the jobs while this
+// replica is the leader.
+func startSingletonJobs(ctx context.Context, wg *sync.WaitGroup, a *app) error {
+	jobs := singletonJobs(a)
+	if len(jobs) == 0 {
+		return nil
+	}
+	if err := a.leaderCfg.Validate(); err != nil {
+		return err
+	}
+
+	id := replicaID()
+	runner := leader.NewRunner(id, leader.NewHistory(a.redis, a.leaderCfg.Key, a.leaderCfg.HistorySize))
+	for _, job := range jobs {
+		runner.Register(job)
+	}
+	elector := leader.NewElector(a.redis, leader.Config{
+		Key:           a.leaderCfg.Key,
+		ID:            id,
+		LeaseTTL:      a.leaderCfg.LeaseTTL,
+		RenewInterval: a.leaderCfg.RenewInterval,
+		RetryInterval: a.leaderCfg.RetryInterval,
+	}, runner.Callbacks())
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		elector.Run(ctx)
+	}()
+	logger.FromContext(ctx).Info("Campaigning for singleton jobs", zap.String("id", id), zap.Int("jobs", len(jobs)))
+	return nil
+}
+
+func replicaID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "unknown"
+	}
+	return fmt.Sprintf("%s-%s", host, uuid.NewString()[:8])
+}
+
+func newJobsCmd() *cobra.Command {
+	var (
+		job   string
+		limit int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "jobs",
+		Short: "Show the status or run history of singleton background jobs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, log, err := initCLILogger(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer log.Sync()
+
+			a, err := newApp(ctx, appOptions{withRedis: true})
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+
+			history := leader.NewHistory(a.redis, a.leaderCfg.Key, a.leaderCfg.HistorySize)
+			if job != "" {
+				runs, err := history.Runs(ctx, job, limit)
+				if err != nil {
+					return err
+				}
+				return printJSON(runs)
+			}
+
+			current, err := leader.CurrentLeader(ctx, a.redis, a.leaderCfg.Key)
+			if err != nil {
+				return err
+			}
+			names, err := history.Jobs(ctx)
+			if err != nil {
+				return err
+			}
+			statuses := make([]leader.JobStatus, 0, len(names))
+			for _, name := range names {
+				status, err := history.Status(ctx, name, current)
+				if err != nil {
+					return err
+				}
+				statuses = append(statuses, status)
+			}
+			return printJSON(statuses)
+		},
+	}
+
+	cmd.Flags().StringVar(&job, "job", "", "print the run history of this job")
+	cmd.Flags().IntVar(&limit, "limit", 20, "runs to print with --job")
+
+	return cmd
+}
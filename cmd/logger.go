@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Andronzi/credit-origination/config"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// initLogger builds the logger from LOG_* settings and attaches it to ctx.
+func initLogger(ctx context.Context, cfg *config.LoggerConfig, console io.Writer) (context.Context, *zap.Logger, zap.AtomicLevel, error) {
+	if err := cfg.Validate(); err != nil {
+		return ctx, nil, zap.AtomicLevel{}, err
+	}
+	log, level, err := logger.New(logger.Options{
+		Profile:    cfg.Profile,
+		Level:      cfg.Level,
+		Output:     cfg.Output,
+		Console:    console,
+		File:       cfg.File,
+		MaxSizeMB:  cfg.MaxSizeMB,
+		MaxBackups: cfg.MaxBackups,
+		MaxAgeDays: cfg.MaxAgeDays,
+		Compress:   cfg.Compress,
+	})
+	if err != nil {
+		return ctx, nil, level, err
+	}
+	return logger.WithLogger(ctx, log), log, level, nil
+}
+
+// initCLILogger is initLogger for one-shot commands: logs go to stderr so
+// they do not mix with the command's output.
+func initCLILogger(ctx context.Context) (context.Context, *zap.Logger, error) {
+	ctx, log, _, err := initLogger(ctx, config.NewLoggerConfig(), os.Stderr)
+	return ctx, log, err
+}
+
+// startLogAdmin serves GET/PUT /admin/log/level until ctx is cancelled, e.g.
+// curl -X PUT -d '{"level":"debug"}' localhost:9090/admin/log/level.
+func startLogAdmin(ctx context.Context, wg *sync.WaitGroup, addr string, level zap.AtomicLevel) {
+	mux := http.NewServeMux()
+	mux.Handle("/admin/log/level", level)
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	log := logger.FromContext(ctx)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Error("Log admin server shutdown failed", zap.Error(err))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		log.Info("Log admin server is running", zap.String("addr", addr))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Error("Log admin server stopped", zap.Error(err))
+		}
+	}()
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Andronzi/credit-origination/internal/repository"
+	"github.com/Andronzi/credit-origination/pkg/database"
+	"github.com/spf13/cobra"
+)
+
+func newMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Manage the database schema with the embedded migrations",
+	}
+
+	for _, sub := range []struct {
+		name  string
+		short string
+	}{
+		{"up", "Apply all pending migrations"},
+		{"down", "Roll back the latest migration"},
+		{"redo", "Roll back and re-apply the latest migration"},
+		{"status", "Print the status of every migration"},
+		{"version", "Print the current schema version"},
+	} {
+		command := sub.name
+		cmd.AddCommand(&cobra.Command{
+			Use:   command,
+			Short: sub.short,
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				return runMigrate(cmd.Context(), command)
+			},
+		})
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "check",
+		Short: "Compare gorm models with the migrated schema",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runMigrateCheck(cmd.Context())
+		},
+	})
+
+	return cmd
+}
+
+func runMigrate(ctx context.Context, command string) error {
+	db, err := database.ConnectPostgres(nil)
+	if err != nil {
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	defer sqlDB.Close()
+
+	return database.Migrate(ctx, sqlDB, command)
+}
+
+func runMigrateCheck(ctx context.Context) error {
+	db, err := database.ConnectPostgres(nil)
+	if err != nil {
+		return fmt.Errorf("database connection failed: %w", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return fmt.Errorf("failed to get database instance: %w", err)
+	}
+	defer sqlDB.Close()
+
+	if err := database.EnsureSchemaUpToDate(ctx, sqlDB); err != nil {
+		return err
+	}
+
+	drift, err := database.CheckSchemaDrift(db, repository.Models()...)
+	if err != nil {
+		return err
+	}
+	for _, line := range drift {
+		fmt.Println(line)
+	}
+	if len(drift) > 0 {
+		return errors.New("schema drift detected")
+	}
+
+	fmt.Println("schema matches models")
+	return nil
+}
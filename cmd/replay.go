@@ -0,0 +1,81 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newReplayEventsCmd() *cobra.Command {
+	var (
+		ids  []string
+		from string
+		to   string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay-events",
+		Short: "Re-publish current status events for a range of applications",
+		Example: `  main replay-events --id 550e8400-e29b-41d4-a716-446655440000
+  main replay-events --from 2025-03-01T00:00:00Z --to 2025-03-02T00:00:00Z`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if len(ids) == 0 && from == "" {
+				return errors.New("either --id or --from is required")
+			}
+
+			ctx, log, err := initCLILogger(cmd.Context())
+			if err != nil {
+				return err
+			}
+			defer log.Sync()
+
+			a, err := newApp(ctx, appOptions{withProducer: true})
+			if err != nil {
+				return err
+			}
+			defer a.Close()
+
+			var published int
+			if len(ids) > 0 {
+				published, err = a.replayUC.ExecuteByIDs(ctx, ids)
+			} else {
+				fromTime, toTime, parseErr := parseRange(from, to)
+				if parseErr != nil {
+					return parseErr
+				}
+				published, err = a.replayUC.ExecuteByCreatedAt(ctx, fromTime, toTime)
+			}
+
+			fmt.Printf("published %d events\n", published)
+			return err
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&ids, "id", nil, "application ID, repeatable")
+	cmd.Flags().StringVar(&from, "from", "", "created_at lower bound, RFC 3339, inclusive")
+	cmd.Flags().StringVar(&to, "to", "", "created_at upper bound, RFC 3339, exclusive (default now)")
+
+	return cmd
+}
+
+func parseRange(from string, to string) (time.Time, time.Time, error) {
+	fromTime, err := time.Parse(time.RFC3339, from)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid --from: %w", err)
+	}
+
+	toTime := time.Now().UTC()
+	if to != "" {
+		if toTime, err = time.Parse(time.RFC3339, to); err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid --to: %w", err)
+		}
+	}
+
+	if !fromTime.Before(toTime) {
+		return time.Time{}, time.Time{}, errors.New("--from must be before --to")
+	}
+	return fromTime, toTime, nil
+}
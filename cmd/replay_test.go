@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name     string
+		from     string
+		to       string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  string
+	}{
+		{"both bounds", "2025-03-01T00:00:00Z", "2025-03-02T00:00:00Z",
+			time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), ""},
+		{"malformed from", "2025-03-01", "", time.Time{}, time.Time{}, "invalid --from"},
+		{"malformed to", "2025-03-01T00:00:00Z", "tomorrow", time.Time{}, time.Time{}, "invalid --to"},
+		{"empty range", "2025-03-01T00:00:00Z", "2025-03-01T00:00:00Z", time.Time{}, time.Time{}, "--from must be before --to"},
+		{"reversed range", "2025-03-02T00:00:00Z", "2025-03-01T00:00:00Z", time.Time{}, time.Time{}, "--from must be before --to"},
+		{"from in the future", "2999-01-01T00:00:00Z", "", time.Time{}, time.Time{}, "--from must be before --to"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := parseRange(tt.from, tt.to)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseRange() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Fatalf("parseRange() = %s, %s, want %s, %s", from, to, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestParseRangeDefaultsToNow(t *testing.T) {
+	before := time.Now()
+	_, to, err := parseRange("2025-03-01T00:00:00Z", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if to.Before(before) || to.After(time.Now()) {
+		t.Fatalf("to = %s, want now", to)
+	}
+}
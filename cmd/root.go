@@ -0,0 +1,31 @@
+package main
+
+import (
+	"github.com/spf13/cobra"
+)
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "main",
+		Short:         "Credit origination service",
+		SilenceUsage:  true,
+		SilenceErrors: false,
+	}
+
+	root.AddCommand(
+		newServeCmd(),
+		newAPIOnlyCmd(),
+		newConsumeOnlyCmd(),
+		newMigrateCmd(),
+		newReplayEventsCmd(),
+		newExportCmd(),
+		newGetCmd(),
+		newListCmd(),
+		newTransitionCmd(),
+		newJobsCmd(),
+		newStubBureauCmd(),
+		newValidateConfigCmd(),
+	)
+
+	return root
+}
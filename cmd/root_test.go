@@ -0,0 +1,78 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestRootCommands(t *testing.T) {
+	root := newRootCmd()
+	for _, name := range []string{
+		"serve", "api-only", "consume-only", "migrate", "replay-events", "export",
+		"get", "list", "transition", "jobs", "validate-config",
+	} {
+		if cmd, _, err := root.Find([]string{name}); err != nil || cmd.Name() != name {
+			t.Errorf("command %q is not registered", name)
+		}
+	}
+	for _, name := range []string{"up", "down", "redo", "status", "version", "check"} {
+		if cmd, _, err := root.Find([]string{"migrate", name}); err != nil || cmd.Name() != name {
+			t.Errorf("command %q is not registered", "migrate "+name)
+		}
+	}
+}
+
+// Эти ошибки возвращаются до подключения к зависимостям.
+func TestCommandsRejectInvalidInput(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{"get without id", []string{"get"}, "accepts 1 arg(s)"},
+		{"list with args", []string{"list", "extra"}, "unknown command"},
+		{"transition without status", []string{"transition", "550e8400-e29b-41d4-a716-446655440000"}, "accepts 2 arg(s)"},
+		{"transition with malformed id", []string{"transition", "not-a-uuid", "APPROVED"}, "invalid application ID"},
+		{"replay without range", []string{"replay-events"}, "either --id or --from is required"},
+		{"export unknown format", []string{"export", "--format", "xlsx"}, "unknown export format"},
+		{"export unknown column", []string{"export", "--format", "csv", "--columns", "id,password"}, "unknown export column"},
+		{"export malformed user", []string{"export", "--format", "csv", "--user-id", "bob"}, "invalid --user-id"},
+		{"export malformed from", []string{"export", "--format", "csv", "--from", "yesterday"}, "invalid --from"},
+		{"migrate with args", []string{"migrate", "up", "42"}, "unknown command"},
+		{"unknown command", []string{"deploy"}, "unknown command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := newRootCmd()
+			root.SetArgs(tt.args)
+			root.SetOut(io.Discard)
+			root.SetErr(io.Discard)
+
+			err := root.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Execute(%v) = %v, want an error containing %q", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateConfigNamesFailedSections(t *testing.T) {
+	t.Setenv("DB_HOST", "")
+	t.Setenv("CHAOS_RULES_FILE", "/nonexistent/rules.json")
+
+	root := newRootCmd()
+	root.SetArgs([]string{"validate-config"})
+	root.SetOut(io.Discard)
+	root.SetErr(io.Discard)
+
+	err := root.Execute()
+	if err == nil {
+		t.Fatal("Execute() = nil, want configuration errors")
+	}
+	for _, want := range []string{"database: ", "DB_HOST is required", "chaos: "} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Andronzi/credit-origination/config"
+
+	"github.com/Andronzi/credit-origination/internal/middleware"
+	"github.com/Andronzi/credit-origination/internal/transport/gateway"
+	grpcserver "github.com/Andronzi/credit-origination/internal/transport/grpc"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"github.com/spf13/cobra"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+type serveMode struct {
+	api      bool
+	consumer bool
+}
+
+func newServeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Run the gRPC API and the Kafka consumers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runServe(cmd.Context(), serveMode{api: true, consumer: true})
+		},
+	}
+}
+
+func newAPIOnlyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "api-only",
+		Short: "Run only the gRPC API",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runServe(cmd.Context(), serveMode{api: true})
+		},
+	}
+}
+
+func newConsumeOnlyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "consume-only",
+		Short: "Run only the Kafka consumers",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runServe(cmd.Context(), serveMode{consumer: true})
+		},
+	}
+}
+
+func runServe(ctx context.Context, mode serveMode) error {
+	logCfg := config.NewLoggerConfig()
+	ctx, log, level, err := initLogger(ctx, logCfg, os.Stdout)
+	if err != nil {
+		return err
+	}
+	defer log.Sync()
+
+	log.Info("Starting application",
+		zap.String("profile", logCfg.Profile),
+		zap.String("log_output", logCfg.Output),
+		zap.Bool("api", mode.api),
+		zap.Bool("consumer", mode.consumer),
+	)
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	tp, err := middleware.InitTracer(ctx, config.NewTelemetryConfig())
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracer: %w", err)
+	}
+	defer tp.Shutdown(context.Background())
+
+	a, err := newApp(ctx, appOptions{withRedis: true, withProducer: true})
+	if err != nil {
+		return fmt.Errorf("failed to initialize application: %w", err)
+	}
+	defer a.Close()
+
+	var wg sync.WaitGroup
+	// fail stops what is already running before runServe returns err.
+	fail := func(err error) error {
+		stop()
+		wg.Wait()
+		return err
+	}
+
+	if logCfg.AdminAddr != "" {
+		startLogAdmin(ctx, &wg, logCfg.AdminAddr, level)
+	}
+
+	if err := startSingletonJobs(ctx, &wg, a); err != nil {
+		return fail(fmt.Errorf("failed to start singleton jobs: %w", err))
+	}
+
+	if mode.consumer {
+		consumer, err := initKafkaConsumer(a.kafkaCfg, a.schemaRegistry, a.updateStatusUC, a.scoreUC, a.injector)
+		if err != nil {
+			return fail(fmt.Errorf("failed to init Kafka consumer: %w", err))
+		}
+		defer consumer.Close()
+		log.Info("Kafka consumer connection success", zap.String("origination-service", "main.go"))
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				if err := consumer.Consume(ctx); err != nil {
+					log.Error("Failed to consume message", zap.Error(err))
+				}
+			}
+		}()
+	}
+
+	if mode.api {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.redisNotifier.Run(ctx); err != nil {
+				log.Error("Status change subscription stopped", zap.Error(err))
+			}
+		}()
+
+		verifier, err := initTokenVerifier(config.NewAuthConfig())
+		if err != nil {
+			return fail(fmt.Errorf("invalid auth configuration: %w", err))
+		}
+		trustedProxies, err := initTrustedProxies(a.serverCfg)
+		if err != nil {
+			return fail(fmt.Errorf("invalid server configuration: %w", err))
+		}
+
+		healthServer := health.NewServer()
+		grpcServer := newGRPCServer(a, verifier, trustedProxies, healthServer)
+		reporter := grpcserver.NewHealthReporter(healthServer, a.dependencies)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reporter.Run(ctx, a.resilienceCfg.HealthInterval)
+		}()
+
+		lis, err := net.Listen("tcp", a.serverCfg.GRPCAddr)
+		if err != nil {
+			return fail(fmt.Errorf("failed to listen tcp: %w", err))
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-ctx.Done()
+			a.hub.Close()
+			grpcServer.GracefulStop()
+		}()
+
+		if a.serverCfg.GatewayEnabled {
+			if err := startGateway(ctx, &wg, a); err != nil {
+				return fail(fmt.Errorf("failed to start REST gateway: %w", err))
+			}
+		}
+
+		log.Info("gRPC server is running", zap.String("addr", a.serverCfg.GRPCAddr))
+		if err := grpcServer.Serve(lis); err != nil {
+			return fail(fmt.Errorf("failed to serve gRPC server: %w", err))
+		}
+	}
+
+	<-ctx.Done()
+	wg.Wait()
+	log.Info("Application stopped")
+
+	return nil
+}
+
+// startGateway serves the REST gateway until ctx is cancelled. The gateway
+// talks to this process's own gRPC server, so every interceptor applies.
+func startGateway(ctx context.Context, wg *sync.WaitGroup, a *app) error {
+	cfg := a.serverCfg
+	host, port, err := net.SplitHostPort(cfg.GRPCAddr)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		host = "localhost"
+	}
+
+	dep := a.dependencies.Register("gateway-client", dependencySettings(a.resilienceCfg.Gateway))
+	handler, closeConn, err := gateway.NewHandler(ctx, net.JoinHostPort(host, port), dep, a.dependencies)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{
+		Addr:              cfg.HTTPAddr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.FromContext(ctx).Error("REST gateway shutdown failed", zap.Error(err))
+		}
+		closeConn()
+	}()
+	go func() {
+		defer wg.Done()
+		logger.FromContext(ctx).Info("REST gateway is running", zap.String("addr", cfg.HTTPAddr))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.FromContext(ctx).Error("REST gateway stopped", zap.Error(err))
+		}
+	}()
+
+	return nil
+}
+
+func newGRPCServer(a *app, verifier *middleware.TokenVerifier, trustedProxies []netip.Prefix, healthServer *health.Server) *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			middleware.AuthInterceptor(verifier),
+			middleware.ClientIPInterceptor(trustedProxies),
+			middleware.LoggingInterceptor(a.log),
+			middleware.TracingInterceptor,
+			middleware.FaultInjectionInterceptor(a.injector),
+			middleware.NewIdempotencyInterceptor(
+				middleware.NewRedisIdempotencyStore(a.redis),
+				a.idempotency,
+			),
+		),
+		grpc.ChainStreamInterceptor(
+			middleware.StreamAuthInterceptor(verifier),
+			middleware.StreamLoggingInterceptor(a.log),
+			middleware.StreamTracingInterceptor,
+		),
+	)
+	createApplicationServer := grpcserver.NewCreateApplicationServer(
+		a.getUC,
+		a.createUC,
+		a.listUC,
+		a.updateUC,
+		a.updateStatusUC,
+		a.deleteUC,
+		a.cancelUC,
+		a.batchGetUC,
+		a.batchCreateUC,
+		a.bulkTransitionUC,
+		a.exportUC,
+		a.offerUC,
+		a.consentUC,
+		a.producer,
+		a.currencies,
+		a.hub,
+		a.watchCfg.HeartbeatInterval,
+	)
+
+	credit.RegisterApplicationServiceServer(grpcServer, createApplicationServer)
+	credit.RegisterManualReviewServiceServer(grpcServer, grpcserver.NewManualReviewServer(a.reviewUC))
+	if a.chaosCfg.AdminEnabled {
+		credit.RegisterFaultInjectionAdminServiceServer(grpcServer, grpcserver.NewFaultInjectionAdminServer(a.injector))
+	}
+
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+
+	return grpcServer
+}
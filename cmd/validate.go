@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Andronzi/credit-origination/config"
+	"github.com/Andronzi/credit-origination/internal/chaos"
+	"github.com/spf13/cobra"
+)
+
+func newValidateConfigCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate-config",
+		Short: "Check the configuration without connecting to dependencies",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			chaosCfg := config.NewChaosConfig()
+
+			var errs []error
+			for _, section := range []struct {
+				name     string
+				validate func() error
+			}{
+				{"server", config.NewServerConfig().Validate},
+				{"database", config.NewDatabaseConfig().Validate},
+				{"logger", config.NewLoggerConfig().Validate},
+				{"telemetry", config.NewTelemetryConfig().Validate},
+				{"kafka", config.NewKafkaConfig().Validate},
+				{"cache", config.NewCacheConfig().Validate},
+				{"idempotency", config.NewIdempotencyConfig().Validate},
+				{"currency", config.NewCurrencyConfig().Validate},
+				{"watch", config.NewWatchConfig().Validate},
+				{"bulk", config.NewBulkConfig().Validate},
+				{"expiry", func() error {
+					_, err := initExpiryPolicy(config.NewExpiryConfig())
+					return err
+				}},
+				{"leader", config.NewLeaderConfig().Validate},
+				{"review", config.NewReviewConfig().Validate},
+				{"affordability", config.NewAffordabilityConfig().Validate},
+				{"catalogue", config.NewCatalogueConfig().Validate},
+				{"consent", func() error {
+					_, err := initConsentPolicy(config.NewConsentConfig())
+					return err
+				}},
+				{"bureau", config.NewBureauConfig().Validate},
+				{"resilience", config.NewResilienceConfig().Validate},
+				{"antifraud", func() error {
+					_, err := initScreener(config.NewAntifraudConfig(), nil)
+					return err
+				}},
+				{"chaos", chaosCfg.Validate},
+			} {
+				if err := section.validate(); err != nil {
+					errs = append(errs, fmt.Errorf("%s: %w", section.name, err))
+				}
+			}
+
+			if chaosCfg.RulesFile != "" {
+				if _, err := chaos.LoadRules(chaosCfg.RulesFile); err != nil {
+					errs = append(errs, fmt.Errorf("chaos: %w", err))
+				}
+			}
+
+			if err := errors.Join(errs...); err != nil {
+				return err
+			}
+
+			fmt.Println("configuration is valid")
+			return nil
+		},
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type AffordabilityConfig struct {
+	Enabled bool
+	// MaxDTI is the highest debt-to-income ratio accepted; ProductMaxDTI
+	// overrides it per product, AFFORDABILITY_PRODUCT_MAX_DTI=code-1:0.4.
+	MaxDTI        string
+	ProductMaxDTI map[string]string
+	// DependantStep lowers the maximum DTI for every dependant.
+	DependantStep string
+}
+
+func NewAffordabilityConfig() *AffordabilityConfig {
+	products := make(map[string]string)
+	for _, item := range getEnvList("AFFORDABILITY_PRODUCT_MAX_DTI", nil) {
+		product, ratio, _ := strings.Cut(item, ":")
+		products[strings.TrimSpace(product)] = strings.TrimSpace(ratio)
+	}
+
+	return &AffordabilityConfig{
+		Enabled:       getEnvBool("AFFORDABILITY_ENABLED", true),
+		MaxDTI:        getEnv("AFFORDABILITY_MAX_DTI", "0.5"),
+		ProductMaxDTI: products,
+		DependantStep: getEnv("AFFORDABILITY_DEPENDANT_STEP", "0.05"),
+	}
+}
+
+func (c *AffordabilityConfig) Validate() error {
+	var errs []error
+	if err := validateRatio(c.MaxDTI); err != nil {
+		errs = append(errs, fmt.Errorf("AFFORDABILITY_MAX_DTI: %w", err))
+	}
+	for product, ratio := range c.ProductMaxDTI {
+		if err := validateRatio(ratio); err != nil {
+			errs = append(errs, fmt.Errorf("AFFORDABILITY_PRODUCT_MAX_DTI %s: %w", product, err))
+		}
+	}
+	if step, err := decimal.NewFromString(c.DependantStep); err != nil || step.IsNegative() {
+		errs = append(errs, errors.New("AFFORDABILITY_DEPENDANT_STEP must be a non-negative number"))
+	}
+	return errors.Join(errs...)
+}
+
+func validateRatio(ratio string) error {
+	d, err := decimal.NewFromString(ratio)
+	if err != nil {
+		return err
+	}
+	if !d.IsPositive() || d.GreaterThan(decimal.NewFromInt(1)) {
+		return errors.New("must be in (0, 1]")
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type AntifraudConfig struct {
+	Enabled bool
+	// RuleScores is the risk score each rule adds when it fires,
+	// ANTIFRAUD_RULE_SCORES=velocity=40,amount_spike=30. Zero disables a rule.
+	RuleScores        map[string]int
+	VelocityMax       int
+	VelocityWindow    time.Duration
+	AmountSpikeFactor string
+	ReapplyCooldown   time.Duration
+	ReviewScore       int
+	RejectScore       int
+	// Lookback and HistoryLimit bound the earlier applications loaded per check.
+	Lookback     time.Duration
+	HistoryLimit int
+}
+
+func NewAntifraudConfig() *AntifraudConfig {
+	scores := map[string]int{
+		"velocity":                40,
+		"shared_bank_account":     60,
+		"amount_spike":            30,
+		"reapply_after_rejection": 30,
+	}
+	for _, item := range getEnvList("ANTIFRAUD_RULE_SCORES", nil) {
+		rule, score, _ := strings.Cut(item, "=")
+		// Некорректное значение отловит Validate.
+		parsed, err := strconv.Atoi(strings.TrimSpace(score))
+		if err != nil {
+			parsed = -1
+		}
+		scores[strings.TrimSpace(rule)] = parsed
+	}
+
+	return &AntifraudConfig{
+		Enabled:           getEnvBool("ANTIFRAUD_ENABLED", true),
+		RuleScores:        scores,
+		VelocityMax:       getEnvInt("ANTIFRAUD_VELOCITY_MAX", 3),
+		VelocityWindow:    getEnvDuration("ANTIFRAUD_VELOCITY_WINDOW", 24*time.Hour),
+		AmountSpikeFactor: getEnv("ANTIFRAUD_AMOUNT_SPIKE_FACTOR", "3"),
+		ReapplyCooldown:   getEnvDuration("ANTIFRAUD_REAPPLY_COOLDOWN", 30*24*time.Hour),
+		ReviewScore:       getEnvInt("ANTIFRAUD_REVIEW_SCORE", 40),
+		RejectScore:       getEnvInt("ANTIFRAUD_REJECT_SCORE", 80),
+		Lookback:          getEnvDuration("ANTIFRAUD_LOOKBACK", 90*24*time.Hour),
+		HistoryLimit:      getEnvInt("ANTIFRAUD_HISTORY_LIMIT", 500),
+	}
+}
+
+func (c *AntifraudConfig) Validate() error {
+	var errs []error
+	for rule, score := range c.RuleScores {
+		if score < 0 {
+			errs = append(errs, fmt.Errorf("ANTIFRAUD_RULE_SCORES %s: score must be a non-negative integer", rule))
+		}
+	}
+	if c.VelocityMax <= 0 || c.HistoryLimit <= 0 {
+		errs = append(errs, errors.New("ANTIFRAUD_VELOCITY_MAX and ANTIFRAUD_HISTORY_LIMIT must be positive"))
+	}
+	if c.VelocityWindow <= 0 || c.ReapplyCooldown <= 0 || c.Lookback <= 0 {
+		errs = append(errs, errors.New("ANTIFRAUD_VELOCITY_WINDOW, ANTIFRAUD_REAPPLY_COOLDOWN and ANTIFRAUD_LOOKBACK must be positive"))
+	}
+	if factor, err := decimal.NewFromString(c.AmountSpikeFactor); err != nil || !factor.GreaterThan(decimal.NewFromInt(1)) {
+		errs = append(errs, errors.New("ANTIFRAUD_AMOUNT_SPIKE_FACTOR must be a number greater than 1"))
+	}
+	if c.ReviewScore <= 0 || c.RejectScore < c.ReviewScore {
+		errs = append(errs, errors.New("ANTIFRAUD_REVIEW_SCORE must be positive and not above ANTIFRAUD_REJECT_SCORE"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+type AuthConfig struct {
+	// TokenSecretFile holds the HS256 key the identity provider signs
+	// bearer tokens with; the token subject is the caller of a request.
+	TokenSecretFile string
+	TokenIssuer     string
+}
+
+func NewAuthConfig() *AuthConfig {
+	return &AuthConfig{
+		TokenSecretFile: os.Getenv("AUTH_TOKEN_SECRET_FILE"),
+		TokenIssuer:     getEnv("AUTH_TOKEN_ISSUER", "credit-origination"),
+	}
+}
+
+func (c *AuthConfig) Validate() error {
+	var errs []error
+	if _, err := os.Stat(c.TokenSecretFile); err != nil {
+		errs = append(errs, fmt.Errorf("AUTH_TOKEN_SECRET_FILE: %w", err))
+	}
+	if c.TokenIssuer == "" {
+		errs = append(errs, errors.New("AUTH_TOKEN_ISSUER is required"))
+	}
+	return errors.Join(errs...)
+}
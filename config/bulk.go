@@ -0,0 +1,30 @@
+package config
+
+import "errors"
+
+type BulkConfig struct {
+	// ChunkSize is how many applications are written in one transaction.
+	ChunkSize int
+	// Concurrency bounds the chunks processed at the same time.
+	Concurrency int
+	// MaxBatchSize limits ids or items passed explicitly in one request.
+	MaxBatchSize int
+	// MaxFilterMatches limits applications selected by a bulk transition filter.
+	MaxFilterMatches int
+}
+
+func NewBulkConfig() *BulkConfig {
+	return &BulkConfig{
+		ChunkSize:        getEnvInt("BULK_CHUNK_SIZE", 100),
+		Concurrency:      getEnvInt("BULK_CONCURRENCY", 4),
+		MaxBatchSize:     getEnvInt("BULK_MAX_BATCH_SIZE", 500),
+		MaxFilterMatches: getEnvInt("BULK_MAX_FILTER_MATCHES", 10000),
+	}
+}
+
+func (c *BulkConfig) Validate() error {
+	if c.ChunkSize <= 0 || c.Concurrency <= 0 || c.MaxBatchSize <= 0 || c.MaxFilterMatches <= 0 {
+		return errors.New("BULK_CHUNK_SIZE, BULK_CONCURRENCY, BULK_MAX_BATCH_SIZE and BULK_MAX_FILTER_MATCHES must be positive")
+	}
+	return nil
+}
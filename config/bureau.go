@@ -0,0 +1,76 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+	"time"
+)
+
+type BureauConfig struct {
+	// Enabled scores applications on the bureau report before approval.
+	Enabled bool
+	Name    string
+	URL     string
+	Client  DependencyConfig
+
+	ScorecardBase            int
+	ScorecardCutoff          int
+	ScorecardRecentInquiries int
+	ScorecardInquiryWindow   time.Duration
+
+	// StubAddr and StubFixtures configure the stub-bureau command.
+	StubAddr     string
+	StubFixtures string
+}
+
+func NewBureauConfig() *BureauConfig {
+	return &BureauConfig{
+		Enabled: getEnvBool("BUREAU_ENABLED", false),
+		Name:    getEnv("BUREAU_NAME", "stub"),
+		URL:     getEnv("BUREAU_URL", "http://localhost:8090"),
+		Client: newDependencyConfig("BUREAU", DependencyConfig{
+			Timeout:         3 * time.Second,
+			Retries:         2,
+			RetryBaseDelay:  200 * time.Millisecond,
+			RetryMaxDelay:   3 * time.Second,
+			BreakerFailures: 5,
+			BreakerCooldown: 30 * time.Second,
+			MaxConcurrent:   20,
+			MaxWait:         time.Second,
+		}),
+
+		ScorecardBase:            getEnvInt("SCORECARD_BASE", 720),
+		ScorecardCutoff:          getEnvInt("SCORECARD_CUTOFF", 600),
+		ScorecardRecentInquiries: getEnvInt("SCORECARD_RECENT_INQUIRIES", 3),
+		ScorecardInquiryWindow:   getEnvDuration("SCORECARD_INQUIRY_WINDOW", 90*24*time.Hour),
+
+		StubAddr:     getEnv("BUREAU_STUB_ADDR", ":8090"),
+		StubFixtures: getEnv("BUREAU_STUB_FIXTURES", "docker/bureau/fixtures.json"),
+	}
+}
+
+func (c *BureauConfig) Validate() error {
+	var errs []error
+	if u, err := url.Parse(c.URL); err != nil || u.Scheme == "" || u.Host == "" {
+		errs = append(errs, errors.New("BUREAU_URL must be an absolute URL"))
+	}
+	if c.Name == "" {
+		errs = append(errs, errors.New("BUREAU_NAME is required"))
+	}
+	if err := c.Client.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if c.ScorecardCutoff < 300 || c.ScorecardCutoff > 850 {
+		errs = append(errs, errors.New("SCORECARD_CUTOFF must be in [300, 850]"))
+	}
+	if c.ScorecardBase < 300 || c.ScorecardBase > 850 {
+		errs = append(errs, errors.New("SCORECARD_BASE must be in [300, 850]"))
+	}
+	if c.ScorecardRecentInquiries < 0 {
+		errs = append(errs, errors.New("SCORECARD_RECENT_INQUIRIES must not be negative"))
+	}
+	if c.ScorecardInquiryWindow <= 0 {
+		errs = append(errs, errors.New("SCORECARD_INQUIRY_WINDOW must be positive"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,29 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type CacheConfig struct {
+	Enabled        bool
+	ApplicationTTL time.Duration
+	// ListTTL is kept short: list pages are invalidated per user, but
+	// pagination offsets shift whenever another application is created.
+	ListTTL time.Duration
+}
+
+func NewCacheConfig() *CacheConfig {
+	return &CacheConfig{
+		Enabled:        getEnvBool("CACHE_ENABLED", false),
+		ApplicationTTL: getEnvDuration("CACHE_APPLICATION_TTL", 10*time.Minute),
+		ListTTL:        getEnvDuration("CACHE_LIST_TTL", 30*time.Second),
+	}
+}
+
+func (c *CacheConfig) Validate() error {
+	if c.Enabled && (c.ApplicationTTL <= 0 || c.ListTTL <= 0) {
+		return errors.New("CACHE_APPLICATION_TTL and CACHE_LIST_TTL must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+type CatalogueConfig struct {
+	// Products maps a product code to its limits, amount:term:rate ranges,
+	// PRODUCT_CATALOGUE=code-1:10000-3000000:3-60:9.9-29.9. Declined
+	// applications of products missing here get no counter-offers.
+	Products map[string]string
+}
+
+// ProductLimits is one parsed PRODUCT_CATALOGUE entry.
+type ProductLimits struct {
+	MinAmount, MaxAmount decimal.Decimal
+	MinTerm, MaxTerm     uint32
+	MinRate, MaxRate     decimal.Decimal
+}
+
+func NewCatalogueConfig() *CatalogueConfig {
+	products := make(map[string]string)
+	for _, item := range getEnvList("PRODUCT_CATALOGUE", nil) {
+		code, limits, _ := strings.Cut(item, ":")
+		products[strings.TrimSpace(code)] = strings.TrimSpace(limits)
+	}
+	return &CatalogueConfig{Products: products}
+}
+
+func (c *CatalogueConfig) Validate() error {
+	var errs []error
+	for product, spec := range c.Products {
+		if _, err := ParseProductLimits(spec); err != nil {
+			errs = append(errs, fmt.Errorf("PRODUCT_CATALOGUE %s: %w", product, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func ParseProductLimits(spec string) (ProductLimits, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) != 3 {
+		return ProductLimits{}, errors.New("want amount:term:rate ranges")
+	}
+
+	var limits ProductLimits
+	var err error
+	if limits.MinAmount, limits.MaxAmount, err = decimalRange(parts[0]); err != nil {
+		return ProductLimits{}, fmt.Errorf("amount: %w", err)
+	}
+	minTerm, maxTerm, err := decimalRange(parts[1])
+	if err != nil || !minTerm.IsInteger() || !maxTerm.IsInteger() || !minTerm.IsPositive() {
+		return ProductLimits{}, errors.New("term: want a range of positive month counts")
+	}
+	limits.MinTerm, limits.MaxTerm = uint32(minTerm.IntPart()), uint32(maxTerm.IntPart())
+	if limits.MinRate, limits.MaxRate, err = decimalRange(parts[2]); err != nil {
+		return ProductLimits{}, fmt.Errorf("rate: %w", err)
+	}
+	return limits, nil
+}
+
+func decimalRange(s string) (decimal.Decimal, decimal.Decimal, error) {
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return decimal.Zero, decimal.Zero, errors.New("want min-max")
+	}
+	lo, err := decimal.NewFromString(strings.TrimSpace(from))
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	hi, err := decimal.NewFromString(strings.TrimSpace(to))
+	if err != nil {
+		return decimal.Zero, decimal.Zero, err
+	}
+	if lo.IsNegative() || hi.LessThan(lo) {
+		return decimal.Zero, decimal.Zero, errors.New("want 0 <= min <= max")
+	}
+	return lo, hi, nil
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+type ChaosConfig struct {
+	// Enabled turns fault injection on at startup. Off by default, so a
+	// production deployment never injects faults unless explicitly asked to.
+	Enabled bool
+	// AdminEnabled registers the FaultInjectionAdminService RPCs.
+	AdminEnabled bool
+	RulesFile    string
+}
+
+func NewChaosConfig() *ChaosConfig {
+	return &ChaosConfig{
+		Enabled:      getEnvBool("CHAOS_ENABLED", false),
+		AdminEnabled: getEnvBool("CHAOS_ADMIN_ENABLED", false),
+		RulesFile:    getEnv("CHAOS_RULES_FILE", ""),
+	}
+}
+
+func (c *ChaosConfig) Validate() error {
+	if c.RulesFile == "" {
+		return nil
+	}
+	if _, err := os.Stat(c.RulesFile); err != nil {
+		return fmt.Errorf("CHAOS_RULES_FILE: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type ConsentConfig struct {
+	// Required maps a status to the consents needed to enter it,
+	// CONSENT_REQUIRED=SCORING=CREDIT_BUREAU_CHECK+AGREEMENT_TERMS;
+	// SCORING= requires nothing.
+	Required map[string][]string
+	// ProductRequired overrides Required per product,
+	// CONSENT_PRODUCT_REQUIRED=code-1:SCORING=AGREEMENT_TERMS.
+	ProductRequired map[string]map[string][]string
+}
+
+func NewConsentConfig() *ConsentConfig {
+	required := make(map[string][]string)
+	for _, item := range getEnvList("CONSENT_REQUIRED", []string{"SCORING=CREDIT_BUREAU_CHECK+PERSONAL_DATA_PROCESSING+AGREEMENT_TERMS"}) {
+		status, consents, _ := strings.Cut(item, "=")
+		required[strings.TrimSpace(status)] = splitConsents(consents)
+	}
+
+	productRequired := make(map[string]map[string][]string)
+	for _, item := range getEnvList("CONSENT_PRODUCT_REQUIRED", nil) {
+		product, rest, _ := strings.Cut(item, ":")
+		status, consents, _ := strings.Cut(rest, "=")
+		product = strings.TrimSpace(product)
+		if productRequired[product] == nil {
+			productRequired[product] = make(map[string][]string)
+		}
+		productRequired[product][strings.TrimSpace(status)] = splitConsents(consents)
+	}
+
+	return &ConsentConfig{
+		Required:        required,
+		ProductRequired: productRequired,
+	}
+}
+
+func splitConsents(s string) []string {
+	var consents []string
+	for _, consent := range strings.Split(s, "+") {
+		if consent = strings.TrimSpace(consent); consent != "" {
+			consents = append(consents, consent)
+		}
+	}
+	return consents
+}
+
+func (c *ConsentConfig) Validate() error {
+	var errs []error
+	for status := range c.Required {
+		if status == "" {
+			errs = append(errs, errors.New("CONSENT_REQUIRED: empty status"))
+		}
+	}
+	for product, required := range c.ProductRequired {
+		if product == "" {
+			errs = append(errs, errors.New("CONSENT_PRODUCT_REQUIRED: empty product code"))
+			continue
+		}
+		for status := range required {
+			if status == "" {
+				errs = append(errs, fmt.Errorf("CONSENT_PRODUCT_REQUIRED %s: empty status", product))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
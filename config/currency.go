@@ -0,0 +1,45 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Andronzi/credit-origination/pkg/money"
+)
+
+type CurrencyConfig struct {
+	Default string
+	// Products maps a product code to its currency, PRODUCT_CURRENCIES=code-1:RUB,code-2:USD.
+	Products map[string]string
+}
+
+func NewCurrencyConfig() *CurrencyConfig {
+	products := make(map[string]string)
+	for _, item := range getEnvList("PRODUCT_CURRENCIES", nil) {
+		code, currency, _ := strings.Cut(item, ":")
+		products[strings.TrimSpace(code)] = strings.TrimSpace(currency)
+	}
+
+	return &CurrencyConfig{
+		Default:  getEnv("DEFAULT_CURRENCY", "RUB"),
+		Products: products,
+	}
+}
+
+func (c *CurrencyConfig) Validate() error {
+	var errs []error
+	if _, err := money.ParseCurrency(c.Default); err != nil {
+		errs = append(errs, fmt.Errorf("DEFAULT_CURRENCY: %w", err))
+	}
+	for product, currency := range c.Products {
+		if product == "" {
+			errs = append(errs, errors.New("PRODUCT_CURRENCIES: empty product code"))
+			continue
+		}
+		if _, err := money.ParseCurrency(currency); err != nil {
+			errs = append(errs, fmt.Errorf("PRODUCT_CURRENCIES %s: %w", product, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+type DatabaseConfig struct {
+	Host         string
+	Port         string
+	UserFile     string
+	PasswordFile string
+	NameFile     string
+	RedisAddr    string
+	// LogLevel is the gorm log level: silent, error, warn or info (every
+	// query at debug). Queries slower than SlowQueryThreshold log a warning.
+	LogLevel           string
+	SlowQueryThreshold time.Duration
+}
+
+func NewDatabaseConfig() *DatabaseConfig {
+	return &DatabaseConfig{
+		Host:         os.Getenv("DB_HOST"),
+		Port:         os.Getenv("DB_PORT"),
+		UserFile:     os.Getenv("DB_USER_FILE"),
+		PasswordFile: os.Getenv("DB_PASSWORD_FILE"),
+		NameFile:     os.Getenv("DB_NAME_FILE"),
+		RedisAddr:    os.Getenv("REDIS_ADDR"),
+
+		LogLevel:           getEnv("DB_LOG_LEVEL", "warn"),
+		SlowQueryThreshold: getEnvDuration("DB_SLOW_QUERY_THRESHOLD", 200*time.Millisecond),
+	}
+}
+
+func (c *DatabaseConfig) Validate() error {
+	var errs []error
+	if c.Host == "" {
+		errs = append(errs, errors.New("DB_HOST is required"))
+	}
+	if c.Port == "" {
+		errs = append(errs, errors.New("DB_PORT is required"))
+	}
+	for _, secret := range []struct{ name, path string }{
+		{"DB_USER_FILE", c.UserFile},
+		{"DB_PASSWORD_FILE", c.PasswordFile},
+		{"DB_NAME_FILE", c.NameFile},
+	} {
+		if _, err := os.Stat(secret.path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", secret.name, err))
+		}
+	}
+	switch strings.ToLower(c.LogLevel) {
+	case "silent", "error", "warn", "info":
+	default:
+		errs = append(errs, errors.New("DB_LOG_LEVEL must be silent, error, warn or info"))
+	}
+	if c.SlowQueryThreshold < 0 {
+		errs = append(errs, errors.New("DB_SLOW_QUERY_THRESHOLD must not be negative"))
+	}
+	if c.RedisAddr == "" {
+		errs = append(errs, errors.New("REDIS_ADDR is required"))
+	}
+	return errors.Join(errs...)
+}
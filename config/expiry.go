@@ -0,0 +1,94 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ExpiryConfig struct {
+	// TTLs maps a status to how long an application may stay in it,
+	// EXPIRY_TTLS=DRAFT=720h,AGREEMENT_CREATED=72h. Empty disables expiry.
+	TTLs map[string]string
+	// ProductTTLs overrides TTLs per product, EXPIRY_PRODUCT_TTLS=code-1:SCORING=24h.
+	ProductTTLs map[string]map[string]string
+	Interval    time.Duration
+	// BatchSize limits applications expired per status and product in one run.
+	BatchSize int
+	// LockKey is the Postgres advisory lock that keeps a run on one replica.
+	LockKey int
+}
+
+func NewExpiryConfig() *ExpiryConfig {
+	ttls := make(map[string]string)
+	for _, item := range getEnvList("EXPIRY_TTLS", nil) {
+		status, ttl, _ := strings.Cut(item, "=")
+		ttls[strings.TrimSpace(status)] = strings.TrimSpace(ttl)
+	}
+
+	productTTLs := make(map[string]map[string]string)
+	for _, item := range getEnvList("EXPIRY_PRODUCT_TTLS", nil) {
+		product, rest, _ := strings.Cut(item, ":")
+		status, ttl, _ := strings.Cut(rest, "=")
+		product = strings.TrimSpace(product)
+		if productTTLs[product] == nil {
+			productTTLs[product] = make(map[string]string)
+		}
+		productTTLs[product][strings.TrimSpace(status)] = strings.TrimSpace(ttl)
+	}
+
+	return &ExpiryConfig{
+		TTLs:        ttls,
+		ProductTTLs: productTTLs,
+		Interval:    getEnvDuration("EXPIRY_INTERVAL", 5*time.Minute),
+		BatchSize:   getEnvInt("EXPIRY_BATCH_SIZE", 100),
+		LockKey:     getEnvInt("EXPIRY_LOCK_KEY", 7301),
+	}
+}
+
+// Enabled reports whether any TTL is configured.
+func (c *ExpiryConfig) Enabled() bool {
+	return len(c.TTLs) > 0 || len(c.ProductTTLs) > 0
+}
+
+func (c *ExpiryConfig) Validate() error {
+	var errs []error
+	if c.Interval <= 0 {
+		errs = append(errs, errors.New("EXPIRY_INTERVAL must be positive"))
+	}
+	if c.BatchSize <= 0 {
+		errs = append(errs, errors.New("EXPIRY_BATCH_SIZE must be positive"))
+	}
+	for status, ttl := range c.TTLs {
+		if err := validateTTL(status, ttl); err != nil {
+			errs = append(errs, fmt.Errorf("EXPIRY_TTLS: %w", err))
+		}
+	}
+	for product, ttls := range c.ProductTTLs {
+		if product == "" {
+			errs = append(errs, errors.New("EXPIRY_PRODUCT_TTLS: empty product code"))
+			continue
+		}
+		for status, ttl := range ttls {
+			if err := validateTTL(status, ttl); err != nil {
+				errs = append(errs, fmt.Errorf("EXPIRY_PRODUCT_TTLS %s: %w", product, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateTTL(status string, ttl string) error {
+	if status == "" {
+		return errors.New("empty status")
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		return fmt.Errorf("%s: %w", status, err)
+	}
+	if d <= 0 {
+		return fmt.Errorf("%s: ttl must be positive", status)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type IdempotencyConfig struct {
+	// TTL of a recorded result for methods without an entry in MethodTTLs.
+	TTL time.Duration
+	// MethodTTLs overrides TTL per full gRPC method,
+	// IDEMPOTENCY_METHOD_TTLS=/credit.v1.ApplicationService/Create=72h.
+	MethodTTLs   map[string]string
+	LockTTL      time.Duration
+	WaitTimeout  time.Duration
+	PollInterval time.Duration
+}
+
+func NewIdempotencyConfig() *IdempotencyConfig {
+	methodTTLs := make(map[string]string)
+	for _, item := range getEnvList("IDEMPOTENCY_METHOD_TTLS", nil) {
+		method, ttl, _ := strings.Cut(item, "=")
+		methodTTLs[strings.TrimSpace(method)] = strings.TrimSpace(ttl)
+	}
+
+	return &IdempotencyConfig{
+		TTL:          getEnvDuration("IDEMPOTENCY_TTL", 24*time.Hour),
+		MethodTTLs:   methodTTLs,
+		LockTTL:      getEnvDuration("IDEMPOTENCY_LOCK_TTL", 30*time.Second),
+		WaitTimeout:  getEnvDuration("IDEMPOTENCY_WAIT_TIMEOUT", 5*time.Second),
+		PollInterval: getEnvDuration("IDEMPOTENCY_POLL_INTERVAL", 100*time.Millisecond),
+	}
+}
+
+func (c *IdempotencyConfig) Validate() error {
+	var errs []error
+	if c.TTL <= 0 || c.LockTTL <= 0 || c.WaitTimeout <= 0 || c.PollInterval <= 0 {
+		errs = append(errs, errors.New("IDEMPOTENCY_TTL, IDEMPOTENCY_LOCK_TTL, IDEMPOTENCY_WAIT_TIMEOUT and IDEMPOTENCY_POLL_INTERVAL must be positive"))
+	}
+	for method, ttl := range c.MethodTTLs {
+		if !strings.HasPrefix(method, "/") {
+			errs = append(errs, fmt.Errorf("IDEMPOTENCY_METHOD_TTLS: %q is not a full method name, e.g. /credit.v1.ApplicationService/Create", method))
+			continue
+		}
+		if err := validateTTL(method, ttl); err != nil {
+			errs = append(errs, fmt.Errorf("IDEMPOTENCY_METHOD_TTLS: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
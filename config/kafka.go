@@ -1,17 +1,50 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
 type KafkaConfig struct {
-	Brokers       []string
-	StatusTopic   string
-	ReplyTopic    string
-	ConsumerGroup string
+	Brokers           []string
+	StatusTopic       string
+	ReplyTopic        string
+	ConsumerGroup     string
+	SchemaRegistryURL string
+	// SchemaSubject and SchemaFile describe the writer schema of produced
+	// events. Consumers resolve writer schemas by ID through the registry.
+	// application-v2 is the subject of decimal events: v2 is frozen as
+	// shipped and v3 is registered in it as a compatible version.
+	SchemaSubject string
+	SchemaFile    string
 }
 
 func NewKafkaConfig() *KafkaConfig {
 	return &KafkaConfig{
-		Brokers:       []string{"localhost:9092"},
-		StatusTopic:   "application-status-events",
-		ReplyTopic:    "status-change-responses",
-		ConsumerGroup: "credit-service-group",
+		Brokers:           getEnvList("KAFKA_BROKERS", []string{"host.docker.internal:9092"}),
+		StatusTopic:       getEnv("KAFKA_STATUS_TOPIC", "application"),
+		ReplyTopic:        getEnv("KAFKA_REPLY_TOPIC", "status-change-responses"),
+		ConsumerGroup:     getEnv("KAFKA_CONSUMER_GROUP", "credit-group"),
+		SchemaRegistryURL: getEnv("SCHEMA_REGISTRY_URL", "http://host.docker.internal:8081"),
+		SchemaSubject:     getEnv("KAFKA_SCHEMA_SUBJECT", "application-v2"),
+		SchemaFile:        getEnv("KAFKA_SCHEMA_FILE", "/schemas/avro/application/v3/ApplicationEvent.avsc"),
+	}
+}
+
+func (c *KafkaConfig) Validate() error {
+	var errs []error
+	if len(c.Brokers) == 0 {
+		errs = append(errs, errors.New("KAFKA_BROKERS: at least one broker is required"))
+	}
+	if c.StatusTopic == "" {
+		errs = append(errs, errors.New("KAFKA_STATUS_TOPIC is required"))
+	}
+	if c.ConsumerGroup == "" {
+		errs = append(errs, errors.New("KAFKA_CONSUMER_GROUP is required"))
+	}
+	if _, err := os.Stat(c.SchemaFile); err != nil {
+		errs = append(errs, fmt.Errorf("KAFKA_SCHEMA_FILE: %w", err))
 	}
+	return errors.Join(errs...)
 }
@@ -0,0 +1,41 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type LeaderConfig struct {
+	// Key is the Redis lease shared by all replicas running singleton jobs.
+	Key           string
+	LeaseTTL      time.Duration
+	RenewInterval time.Duration
+	RetryInterval time.Duration
+	// HistorySize is how many runs are kept per job.
+	HistorySize int
+}
+
+func NewLeaderConfig() *LeaderConfig {
+	return &LeaderConfig{
+		Key:           getEnv("LEADER_KEY", "origination:leader"),
+		LeaseTTL:      getEnvDuration("LEADER_LEASE_TTL", 15*time.Second),
+		RenewInterval: getEnvDuration("LEADER_RENEW_INTERVAL", 5*time.Second),
+		RetryInterval: getEnvDuration("LEADER_RETRY_INTERVAL", 5*time.Second),
+		HistorySize:   getEnvInt("JOB_HISTORY_SIZE", 50),
+	}
+}
+
+func (c *LeaderConfig) Validate() error {
+	var errs []error
+	if c.Key == "" {
+		errs = append(errs, errors.New("LEADER_KEY is required"))
+	}
+	if c.RenewInterval <= 0 || c.RetryInterval <= 0 || c.HistorySize <= 0 {
+		errs = append(errs, errors.New("LEADER_RENEW_INTERVAL, LEADER_RETRY_INTERVAL and JOB_HISTORY_SIZE must be positive"))
+	}
+	// Лидер должен успеть продлить аренду хотя бы дважды до её истечения.
+	if c.LeaseTTL < 2*c.RenewInterval {
+		errs = append(errs, errors.New("LEADER_LEASE_TTL must be at least twice LEADER_RENEW_INTERVAL"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+)
+
+type LoggerConfig struct {
+	// Profile is dev or prod; Level defaults to debug for dev and info for
+	// prod.
+	Profile string
+	Level   string
+	// Output is stdout, file or both.
+	Output     string
+	File       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+	// AdminAddr serves GET/PUT /admin/log/level when set, e.g. ":9090".
+	AdminAddr string
+}
+
+func NewLoggerConfig() *LoggerConfig {
+	return &LoggerConfig{
+		Profile:    getEnv("LOG_PROFILE", "prod"),
+		Level:      getEnv("LOG_LEVEL", ""),
+		Output:     getEnv("LOG_OUTPUT", "stdout"),
+		File:       getEnv("LOG_FILE", "/var/log/myapp.log"),
+		MaxSizeMB:  getEnvInt("LOG_FILE_MAX_SIZE_MB", 10),
+		MaxBackups: getEnvInt("LOG_FILE_MAX_BACKUPS", 5),
+		MaxAgeDays: getEnvInt("LOG_FILE_MAX_AGE_DAYS", 30),
+		Compress:   getEnvBool("LOG_FILE_COMPRESS", true),
+		AdminAddr:  getEnv("LOG_ADMIN_ADDR", ""),
+	}
+}
+
+func (c *LoggerConfig) Validate() error {
+	var errs []error
+	switch c.Profile {
+	case "dev", "prod":
+	default:
+		errs = append(errs, fmt.Errorf("LOG_PROFILE: unknown profile %q", c.Profile))
+	}
+	switch c.Level {
+	case "", "debug", "info", "warn", "error", "dpanic", "panic", "fatal":
+	default:
+		errs = append(errs, fmt.Errorf("LOG_LEVEL: unknown level %q", c.Level))
+	}
+	switch c.Output {
+	case "stdout":
+	case "file", "both":
+		if c.File == "" {
+			errs = append(errs, errors.New("LOG_FILE is required for file output"))
+		}
+		if c.MaxSizeMB <= 0 || c.MaxBackups < 0 || c.MaxAgeDays < 0 {
+			errs = append(errs, errors.New("LOG_FILE_MAX_SIZE_MB must be positive, LOG_FILE_MAX_BACKUPS and LOG_FILE_MAX_AGE_DAYS not negative"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("LOG_OUTPUT: unknown output %q", c.Output))
+	}
+	if c.AdminAddr != "" {
+		if _, _, err := net.SplitHostPort(c.AdminAddr); err != nil {
+			errs = append(errs, errors.Join(errors.New("LOG_ADMIN_ADDR is invalid"), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DependencyConfig holds the timeout, retry, breaker and bulkhead settings
+// of one outbound dependency, read from <PREFIX>_TIMEOUT and friends.
+type DependencyConfig struct {
+	prefix string
+
+	// Timeout bounds a single attempt; Retries counts the attempts after the
+	// first one and applies to idempotent calls only.
+	Timeout         time.Duration
+	Retries         int
+	RetryBaseDelay  time.Duration
+	RetryMaxDelay   time.Duration
+	BreakerFailures int
+	BreakerCooldown time.Duration
+	// MaxConcurrent limits calls in flight, 0 means unlimited; MaxWait is how
+	// long a call may wait for a free slot.
+	MaxConcurrent int
+	MaxWait       time.Duration
+}
+
+func newDependencyConfig(prefix string, defaults DependencyConfig) DependencyConfig {
+	return DependencyConfig{
+		prefix:          prefix,
+		Timeout:         getEnvDuration(prefix+"_TIMEOUT", defaults.Timeout),
+		Retries:         getEnvInt(prefix+"_RETRIES", defaults.Retries),
+		RetryBaseDelay:  getEnvDuration(prefix+"_RETRY_BASE_DELAY", defaults.RetryBaseDelay),
+		RetryMaxDelay:   getEnvDuration(prefix+"_RETRY_MAX_DELAY", defaults.RetryMaxDelay),
+		BreakerFailures: getEnvInt(prefix+"_BREAKER_FAILURES", defaults.BreakerFailures),
+		BreakerCooldown: getEnvDuration(prefix+"_BREAKER_COOLDOWN", defaults.BreakerCooldown),
+		MaxConcurrent:   getEnvInt(prefix+"_MAX_CONCURRENT", defaults.MaxConcurrent),
+		MaxWait:         getEnvDuration(prefix+"_MAX_WAIT", defaults.MaxWait),
+	}
+}
+
+func (c DependencyConfig) Validate() error {
+	var errs []error
+	if c.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("%s_TIMEOUT must be positive", c.prefix))
+	}
+	if c.Retries < 0 {
+		errs = append(errs, fmt.Errorf("%s_RETRIES must not be negative", c.prefix))
+	}
+	if c.RetryBaseDelay < 0 || c.RetryMaxDelay < 0 {
+		errs = append(errs, fmt.Errorf("%s_RETRY_BASE_DELAY and %s_RETRY_MAX_DELAY must not be negative", c.prefix, c.prefix))
+	}
+	if c.BreakerFailures <= 0 {
+		errs = append(errs, fmt.Errorf("%s_BREAKER_FAILURES must be positive", c.prefix))
+	}
+	if c.BreakerCooldown <= 0 {
+		errs = append(errs, fmt.Errorf("%s_BREAKER_COOLDOWN must be positive", c.prefix))
+	}
+	if c.MaxConcurrent < 0 || c.MaxWait < 0 {
+		errs = append(errs, fmt.Errorf("%s_MAX_CONCURRENT and %s_MAX_WAIT must not be negative", c.prefix, c.prefix))
+	}
+	return errors.Join(errs...)
+}
+
+type ResilienceConfig struct {
+	SchemaRegistry DependencyConfig
+	Scoring        DependencyConfig
+	KafkaProducer  DependencyConfig
+	// Gateway is the REST gateway's connection to the gRPC API.
+	Gateway DependencyConfig
+	// HealthInterval is how often breaker states are copied to the gRPC
+	// health service.
+	HealthInterval time.Duration
+}
+
+func NewResilienceConfig() *ResilienceConfig {
+	return &ResilienceConfig{
+		SchemaRegistry: newDependencyConfig("SCHEMA_REGISTRY", DependencyConfig{
+			Timeout:         5 * time.Second,
+			Retries:         3,
+			RetryBaseDelay:  200 * time.Millisecond,
+			RetryMaxDelay:   2 * time.Second,
+			BreakerFailures: 5,
+			BreakerCooldown: 30 * time.Second,
+			MaxConcurrent:   10,
+			MaxWait:         time.Second,
+		}),
+		Scoring: newDependencyConfig("SCORING", DependencyConfig{
+			Timeout:         5 * time.Second,
+			Retries:         2,
+			RetryBaseDelay:  200 * time.Millisecond,
+			RetryMaxDelay:   time.Second,
+			BreakerFailures: 5,
+			BreakerCooldown: 30 * time.Second,
+			MaxConcurrent:   50,
+			MaxWait:         500 * time.Millisecond,
+		}),
+		KafkaProducer: newDependencyConfig("KAFKA_PRODUCER", DependencyConfig{
+			Timeout:         10 * time.Second,
+			Retries:         5,
+			RetryBaseDelay:  100 * time.Millisecond,
+			RetryMaxDelay:   2 * time.Second,
+			BreakerFailures: 10,
+			BreakerCooldown: 15 * time.Second,
+			MaxConcurrent:   100,
+			MaxWait:         time.Second,
+		}),
+		Gateway: newDependencyConfig("GATEWAY_CLIENT", DependencyConfig{
+			Timeout:         30 * time.Second,
+			Retries:         1,
+			RetryBaseDelay:  100 * time.Millisecond,
+			RetryMaxDelay:   time.Second,
+			BreakerFailures: 20,
+			BreakerCooldown: 5 * time.Second,
+			MaxConcurrent:   200,
+			MaxWait:         time.Second,
+		}),
+		HealthInterval: getEnvDuration("HEALTH_CHECK_INTERVAL", 5*time.Second),
+	}
+}
+
+func (c *ResilienceConfig) Validate() error {
+	errs := []error{
+		c.SchemaRegistry.Validate(),
+		c.Scoring.Validate(),
+		c.KafkaProducer.Validate(),
+		c.Gateway.Validate(),
+	}
+	if c.HealthInterval <= 0 {
+		errs = append(errs, errors.New("HEALTH_CHECK_INTERVAL must be positive"))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type ReviewConfig struct {
+	// SLA is how long an application may wait in the manual review queue.
+	SLA time.Duration
+	// FourEyesThreshold is the disbursement amount above which two
+	// underwriters must approve; FourEyesThresholds overrides it per
+	// currency, REVIEW_FOUR_EYES_THRESHOLDS=RUB:1000000,USD:15000.
+	FourEyesThreshold  string
+	FourEyesThresholds map[string]string
+}
+
+func NewReviewConfig() *ReviewConfig {
+	thresholds := make(map[string]string)
+	for _, item := range getEnvList("REVIEW_FOUR_EYES_THRESHOLDS", nil) {
+		currency, amount, _ := strings.Cut(item, ":")
+		thresholds[strings.ToUpper(strings.TrimSpace(currency))] = strings.TrimSpace(amount)
+	}
+
+	return &ReviewConfig{
+		SLA:                getEnvDuration("REVIEW_SLA", 24*time.Hour),
+		FourEyesThreshold:  getEnv("REVIEW_FOUR_EYES_THRESHOLD", "1000000"),
+		FourEyesThresholds: thresholds,
+	}
+}
+
+func (c *ReviewConfig) Validate() error {
+	var errs []error
+	if c.SLA <= 0 {
+		errs = append(errs, errors.New("REVIEW_SLA must be positive"))
+	}
+	if err := validateThreshold(c.FourEyesThreshold); err != nil {
+		errs = append(errs, fmt.Errorf("REVIEW_FOUR_EYES_THRESHOLD: %w", err))
+	}
+	for currency, amount := range c.FourEyesThresholds {
+		if err := validateThreshold(amount); err != nil {
+			errs = append(errs, fmt.Errorf("REVIEW_FOUR_EYES_THRESHOLDS %s: %w", currency, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func validateThreshold(amount string) error {
+	d, err := decimal.NewFromString(amount)
+	if err != nil {
+		return err
+	}
+	if d.IsNegative() {
+		return errors.New("must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+)
+
+type ServerConfig struct {
+	GRPCAddr string
+	// HTTPAddr is where the REST gateway listens when GatewayEnabled.
+	HTTPAddr       string
+	GatewayEnabled bool
+	ScoringURL     string
+	// TrustedProxies are the networks whose X-Forwarded-For is believed,
+	// CIDRs. The default is loopback, where the in-process REST gateway
+	// connects from.
+	TrustedProxies []string
+}
+
+func NewServerConfig() *ServerConfig {
+	return &ServerConfig{
+		GRPCAddr:       getEnv("GRPC_ADDR", ":50051"),
+		HTTPAddr:       getEnv("HTTP_ADDR", ":8080"),
+		GatewayEnabled: getEnvBool("GATEWAY_ENABLED", true),
+		ScoringURL:     getEnv("SCORING_URL", "http://scoring-service:8080"),
+		TrustedProxies: getEnvList("TRUSTED_PROXIES", []string{"127.0.0.0/8", "::1/128"}),
+	}
+}
+
+func (c *ServerConfig) Validate() error {
+	if _, _, err := net.SplitHostPort(c.GRPCAddr); err != nil {
+		return errors.Join(errors.New("GRPC_ADDR is invalid"), err)
+	}
+	if c.GatewayEnabled {
+		if _, _, err := net.SplitHostPort(c.HTTPAddr); err != nil {
+			return errors.Join(errors.New("HTTP_ADDR is invalid"), err)
+		}
+	}
+	for _, proxy := range c.TrustedProxies {
+		if _, err := netip.ParsePrefix(proxy); err != nil {
+			return fmt.Errorf("TRUSTED_PROXIES: %w", err)
+		}
+	}
+	return nil
+}
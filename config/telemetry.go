@@ -0,0 +1,73 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type TelemetryConfig struct {
+	// Exporter is otlp-grpc, otlp-http, stdout or none.
+	Exporter string
+	// Endpoint is host:port of the OTLP collector.
+	Endpoint string
+	Insecure bool
+	// Sampler is always_on, always_off, traceidratio or
+	// parentbased_traceidratio; SamplerArg is the ratio of the last two.
+	Sampler    string
+	SamplerArg string
+	// ServiceName and ResourceAttributes describe this process in traces,
+	// OTEL_RESOURCE_ATTRIBUTES=deployment.environment=prod,team=credit.
+	ServiceName        string
+	ResourceAttributes map[string]string
+}
+
+func NewTelemetryConfig() *TelemetryConfig {
+	attributes := map[string]string{"deployment.environment": "development"}
+	for _, item := range getEnvList("OTEL_RESOURCE_ATTRIBUTES", nil) {
+		key, value, _ := strings.Cut(item, "=")
+		attributes[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return &TelemetryConfig{
+		Exporter:           getEnv("OTEL_TRACES_EXPORTER", "otlp-grpc"),
+		Endpoint:           getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "host.docker.internal:4317"),
+		Insecure:           getEnvBool("OTEL_EXPORTER_OTLP_INSECURE", true),
+		Sampler:            getEnv("OTEL_TRACES_SAMPLER", "parentbased_traceidratio"),
+		SamplerArg:         getEnv("OTEL_TRACES_SAMPLER_ARG", "1"),
+		ServiceName:        getEnv("OTEL_SERVICE_NAME", "credit-origination-service"),
+		ResourceAttributes: attributes,
+	}
+}
+
+func (c *TelemetryConfig) Validate() error {
+	var errs []error
+	switch c.Exporter {
+	case "otlp-grpc", "otlp-http":
+		if c.Endpoint == "" {
+			errs = append(errs, errors.New("OTEL_EXPORTER_OTLP_ENDPOINT is required for OTLP exporters"))
+		}
+	case "stdout", "none":
+	default:
+		errs = append(errs, fmt.Errorf("OTEL_TRACES_EXPORTER: unknown exporter %q", c.Exporter))
+	}
+	switch c.Sampler {
+	case "always_on", "always_off":
+	case "traceidratio", "parentbased_traceidratio":
+		if ratio, err := strconv.ParseFloat(c.SamplerArg, 64); err != nil || ratio < 0 || ratio > 1 {
+			errs = append(errs, errors.New("OTEL_TRACES_SAMPLER_ARG must be a ratio in [0, 1]"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("OTEL_TRACES_SAMPLER: unknown sampler %q", c.Sampler))
+	}
+	if c.ServiceName == "" {
+		errs = append(errs, errors.New("OTEL_SERVICE_NAME is required"))
+	}
+	for key := range c.ResourceAttributes {
+		if key == "" {
+			errs = append(errs, errors.New("OTEL_RESOURCE_ATTRIBUTES: empty attribute name"))
+		}
+	}
+	return errors.Join(errs...)
+}
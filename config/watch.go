@@ -0,0 +1,33 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+type WatchConfig struct {
+	// Channel is the Redis pub/sub channel shared by all replicas.
+	Channel           string
+	HeartbeatInterval time.Duration
+	// BufferSize is how many changes a slow stream may lag behind before it is dropped.
+	BufferSize int
+}
+
+func NewWatchConfig() *WatchConfig {
+	return &WatchConfig{
+		Channel:           getEnv("WATCH_CHANNEL", "applications:status-changes"),
+		HeartbeatInterval: getEnvDuration("WATCH_HEARTBEAT_INTERVAL", 15*time.Second),
+		BufferSize:        getEnvInt("WATCH_BUFFER_SIZE", 64),
+	}
+}
+
+func (c *WatchConfig) Validate() error {
+	var errs []error
+	if c.HeartbeatInterval <= 0 {
+		errs = append(errs, errors.New("WATCH_HEARTBEAT_INTERVAL must be positive"))
+	}
+	if c.BufferSize <= 0 {
+		errs = append(errs, errors.New("WATCH_BUFFER_SIZE must be positive"))
+	}
+	return errors.Join(errs...)
+}
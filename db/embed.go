@@ -0,0 +1,8 @@
+package db
+
+import "embed"
+
+// Migrations holds the goose migrations compiled into the service binary.
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- До появления валюты все заявки оформлялись в рублях.
+ALTER TABLE credit_applications
+ADD COLUMN currency VARCHAR(3) NOT NULL DEFAULT 'RUB';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE credit_applications
+DROP COLUMN currency;
+-- +goose StatementEnd
@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE credit_applications
+ADD COLUMN cancel_reason TEXT;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE credit_applications
+DROP COLUMN cancel_reason;
+-- +goose StatementEnd
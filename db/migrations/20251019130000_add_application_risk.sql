@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE credit_applications
+ADD COLUMN risk_score INT NOT NULL DEFAULT 0,
+ADD COLUMN risk_flags JSONB,
+ADD COLUMN risk_decision VARCHAR(20);
+
+CREATE INDEX IF NOT EXISTS idx_credit_applications_to_bank_account_id
+ON credit_applications (to_bank_account_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_credit_applications_to_bank_account_id;
+
+ALTER TABLE credit_applications
+DROP COLUMN risk_decision,
+DROP COLUMN risk_flags,
+DROP COLUMN risk_score;
+-- +goose StatementEnd
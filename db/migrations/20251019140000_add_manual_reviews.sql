@@ -0,0 +1,49 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE reviews (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES credit_applications (id) ON DELETE CASCADE,
+    state VARCHAR(20) NOT NULL,
+    assignee VARCHAR(255),
+    claimed_at TIMESTAMP,
+    first_approver VARCHAR(255),
+    decision VARCHAR(20),
+    decision_reason TEXT,
+    decided_by VARCHAR(255),
+    queued_at TIMESTAMP NOT NULL,
+    decided_at TIMESTAMP,
+    version BIGINT NOT NULL DEFAULT 1
+);
+
+CREATE UNIQUE INDEX idx_reviews_application_id ON reviews (application_id);
+CREATE INDEX idx_reviews_state_queued_at ON reviews (state, queued_at);
+
+CREATE TABLE review_notes (
+    id UUID PRIMARY KEY,
+    review_id UUID NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    author VARCHAR(255) NOT NULL,
+    text TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_review_notes_review_id ON review_notes (review_id);
+
+CREATE TABLE review_attachments (
+    id UUID PRIMARY KEY,
+    review_id UUID NOT NULL REFERENCES reviews (id) ON DELETE CASCADE,
+    author VARCHAR(255) NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255),
+    url TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_review_attachments_review_id ON review_attachments (review_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE review_attachments;
+DROP TABLE review_notes;
+DROP TABLE reviews;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE credit_applications
+ADD COLUMN monthly_income DECIMAL(15,2),
+ADD COLUMN monthly_obligations DECIMAL(15,2),
+ADD COLUMN dependants INT,
+ADD COLUMN monthly_payment DECIMAL(15,2),
+ADD COLUMN dti NUMERIC,
+ADD COLUMN max_dti DECIMAL(5,4),
+ADD COLUMN affordability_decision VARCHAR(20);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE credit_applications
+DROP COLUMN affordability_decision,
+DROP COLUMN max_dti,
+DROP COLUMN dti,
+DROP COLUMN monthly_payment,
+DROP COLUMN dependants,
+DROP COLUMN monthly_obligations,
+DROP COLUMN monthly_income;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE counter_offers (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES credit_applications (id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    disbursement_amount DECIMAL(15,2),
+    origination_amount DECIMAL(15,2),
+    term INT,
+    interest DECIMAL(15,2),
+    monthly_payment DECIMAL(15,2),
+    dti NUMERIC,
+    accepted_at TIMESTAMP,
+    created_at TIMESTAMP
+);
+
+CREATE INDEX idx_counter_offers_application_id ON counter_offers (application_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE counter_offers;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE consents (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES credit_applications (id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    version VARCHAR(50) NOT NULL,
+    channel VARCHAR(50),
+    ip_address VARCHAR(64),
+    user_agent TEXT,
+    given_at TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_consents_application_id ON consents (application_id);
+
+ALTER TABLE credit_applications
+ADD COLUMN pending_status VARCHAR(50);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE credit_applications
+DROP COLUMN pending_status;
+
+DROP TABLE consents;
+-- +goose StatementEnd
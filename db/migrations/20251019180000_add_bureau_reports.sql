@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE bureau_reports (
+    id UUID PRIMARY KEY,
+    application_id UUID NOT NULL REFERENCES credit_applications (id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    bureau VARCHAR(50) NOT NULL,
+    data JSONB NOT NULL,
+    fetched_at TIMESTAMP NOT NULL
+);
+
+CREATE UNIQUE INDEX idx_bureau_reports_application_id ON bureau_reports (application_id);
+CREATE INDEX idx_bureau_reports_user_id ON bureau_reports (user_id);
+
+ALTER TABLE credit_applications
+ADD COLUMN credit_score INTEGER;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE credit_applications
+DROP COLUMN credit_score;
+
+DROP TABLE bureau_reports;
+-- +goose StatementEnd
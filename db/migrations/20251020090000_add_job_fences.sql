@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE job_fences (
+    name VARCHAR(100) PRIMARY KEY,
+    token BIGINT NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE job_fences;
+-- +goose StatementEnd
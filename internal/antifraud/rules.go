@@ -0,0 +1,102 @@
+package antifraud
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+// Facts is what rules know about an application besides the application
+// itself. History holds earlier applications of the same user or bank
+// account within the screener lookback, newest first.
+type Facts struct {
+	Now     time.Time
+	History []*domain.CreditApplication
+}
+
+// Rule inspects one application; it returns ok=false when it does not fire.
+// Rules are pure, the screener loads the facts for them.
+type Rule interface {
+	Name() string
+	Check(app *domain.CreditApplication, facts Facts) (detail string, ok bool)
+}
+
+// VelocityRule fires when the user already has Max applications in Window.
+type VelocityRule struct {
+	Max    int
+	Window time.Duration
+}
+
+func (r VelocityRule) Name() string { return "velocity" }
+
+func (r VelocityRule) Check(app *domain.CreditApplication, facts Facts) (string, bool) {
+	since := facts.Now.Add(-r.Window)
+	count := 0
+	for _, prev := range facts.History {
+		if prev.UserID == app.UserID && !prev.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	if count < r.Max {
+		return "", false
+	}
+	return fmt.Sprintf("%d applications in %s", count, r.Window), true
+}
+
+// SharedBankAccountRule fires when another user pays out to the same account.
+type SharedBankAccountRule struct{}
+
+func (r SharedBankAccountRule) Name() string { return "shared_bank_account" }
+
+func (r SharedBankAccountRule) Check(app *domain.CreditApplication, facts Facts) (string, bool) {
+	users := make(map[string]bool)
+	for _, prev := range facts.History {
+		if prev.ToBankAccountID == app.ToBankAccountID && prev.UserID != app.UserID {
+			users[prev.UserID.String()] = true
+		}
+	}
+	if len(users) == 0 {
+		return "", false
+	}
+	return fmt.Sprintf("bank account used by %d other users", len(users)), true
+}
+
+// AmountSpikeRule fires when the amount exceeds Factor times the user's
+// largest previous application in the same currency.
+type AmountSpikeRule struct {
+	Factor decimal.Decimal
+}
+
+func (r AmountSpikeRule) Name() string { return "amount_spike" }
+
+func (r AmountSpikeRule) Check(app *domain.CreditApplication, facts Facts) (string, bool) {
+	largest := decimal.Zero
+	for _, prev := range facts.History {
+		if prev.UserID == app.UserID && prev.Currency == app.Currency && prev.DisbursementAmount.GreaterThan(largest) {
+			largest = prev.DisbursementAmount
+		}
+	}
+	if largest.IsZero() || app.DisbursementAmount.LessThanOrEqual(largest.Mul(r.Factor)) {
+		return "", false
+	}
+	return fmt.Sprintf("amount %s is over %s times the previous maximum %s", app.DisbursementAmount, r.Factor, largest), true
+}
+
+// ReapplyAfterRejectionRule fires when the user was rejected within Cooldown.
+type ReapplyAfterRejectionRule struct {
+	Cooldown time.Duration
+}
+
+func (r ReapplyAfterRejectionRule) Name() string { return "reapply_after_rejection" }
+
+func (r ReapplyAfterRejectionRule) Check(app *domain.CreditApplication, facts Facts) (string, bool) {
+	since := facts.Now.Add(-r.Cooldown)
+	for _, prev := range facts.History {
+		if prev.UserID == app.UserID && prev.Status == domain.REJECTED && !prev.UpdatedAt.Before(since) {
+			return fmt.Sprintf("rejected %s ago", facts.Now.Sub(prev.UpdatedAt).Round(time.Minute)), true
+		}
+	}
+	return "", false
+}
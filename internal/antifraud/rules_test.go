@@ -0,0 +1,163 @@
+package antifraud
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var now = time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+
+var (
+	user    = uuid.MustParse("11111111-1111-1111-1111-111111111111")
+	other   = uuid.MustParse("22222222-2222-2222-2222-222222222222")
+	account = uuid.MustParse("33333333-3333-3333-3333-333333333333")
+)
+
+func application(userID uuid.UUID, amount string, created time.Time) *domain.CreditApplication {
+	return &domain.CreditApplication{
+		ID:                 uuid.New(),
+		UserID:             userID,
+		ToBankAccountID:    account,
+		DisbursementAmount: decimal.RequireFromString(amount),
+		Currency:           "RUB",
+		Status:             domain.DRAFT,
+		CreatedAt:          created,
+		UpdatedAt:          created,
+	}
+}
+
+func TestVelocityRule(t *testing.T) {
+	rule := VelocityRule{Max: 2, Window: 24 * time.Hour}
+	app := application(user, "1000", now)
+
+	tests := []struct {
+		name    string
+		history []*domain.CreditApplication
+		fires   bool
+	}{
+		{"no history", nil, false},
+		{"below max", []*domain.CreditApplication{application(user, "1000", now.Add(-time.Hour))}, false},
+		{"at max", []*domain.CreditApplication{
+			application(user, "1000", now.Add(-time.Hour)),
+			application(user, "1000", now.Add(-2*time.Hour)),
+		}, true},
+		{"outside window", []*domain.CreditApplication{
+			application(user, "1000", now.Add(-time.Hour)),
+			application(user, "1000", now.Add(-25*time.Hour)),
+		}, false},
+		{"window boundary counts", []*domain.CreditApplication{
+			application(user, "1000", now.Add(-time.Hour)),
+			application(user, "1000", now.Add(-24*time.Hour)),
+		}, true},
+		{"other users ignored", []*domain.CreditApplication{
+			application(other, "1000", now.Add(-time.Hour)),
+			application(other, "1000", now.Add(-2*time.Hour)),
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := rule.Check(app, Facts{Now: now, History: tt.history}); ok != tt.fires {
+				t.Fatalf("fires = %v, want %v", ok, tt.fires)
+			}
+		})
+	}
+}
+
+func TestSharedBankAccountRule(t *testing.T) {
+	rule := SharedBankAccountRule{}
+	app := application(user, "1000", now)
+
+	ownHistory := []*domain.CreditApplication{application(user, "1000", now.Add(-time.Hour))}
+	if _, ok := rule.Check(app, Facts{Now: now, History: ownHistory}); ok {
+		t.Fatal("the user's own applications must not fire")
+	}
+
+	otherAccount := application(other, "1000", now.Add(-time.Hour))
+	otherAccount.ToBankAccountID = uuid.New()
+	if _, ok := rule.Check(app, Facts{Now: now, History: []*domain.CreditApplication{otherAccount}}); ok {
+		t.Fatal("another user with another account must not fire")
+	}
+
+	shared := []*domain.CreditApplication{
+		application(other, "1000", now.Add(-time.Hour)),
+		application(other, "1000", now.Add(-2*time.Hour)),
+	}
+	detail, ok := rule.Check(app, Facts{Now: now, History: shared})
+	if !ok {
+		t.Fatal("another user on the same account must fire")
+	}
+	if detail != "bank account used by 1 other users" {
+		t.Fatalf("detail = %q, users must be counted once", detail)
+	}
+}
+
+func TestAmountSpikeRule(t *testing.T) {
+	rule := AmountSpikeRule{Factor: decimal.NewFromInt(3)}
+
+	tests := []struct {
+		name    string
+		amount  string
+		history []*domain.CreditApplication
+		fires   bool
+	}{
+		{"no history", "1000000", nil, false},
+		{"at factor", "3000", []*domain.CreditApplication{application(user, "1000", now.Add(-time.Hour))}, false},
+		{"over factor", "3000.01", []*domain.CreditApplication{application(user, "1000", now.Add(-time.Hour))}, true},
+		{"compares with largest", "4000", []*domain.CreditApplication{
+			application(user, "1000", now.Add(-time.Hour)),
+			application(user, "2000", now.Add(-2*time.Hour)),
+		}, false},
+		{"other users ignored", "4000", []*domain.CreditApplication{application(other, "1000", now.Add(-time.Hour))}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := application(user, tt.amount, now)
+			if _, ok := rule.Check(app, Facts{Now: now, History: tt.history}); ok != tt.fires {
+				t.Fatalf("fires = %v, want %v", ok, tt.fires)
+			}
+		})
+	}
+
+	t.Run("other currency ignored", func(t *testing.T) {
+		prev := application(user, "1000", now.Add(-time.Hour))
+		prev.Currency = "USD"
+		app := application(user, "4000", now)
+		if _, ok := rule.Check(app, Facts{Now: now, History: []*domain.CreditApplication{prev}}); ok {
+			t.Fatal("amounts in another currency must not be compared")
+		}
+	})
+}
+
+func TestReapplyAfterRejectionRule(t *testing.T) {
+	rule := ReapplyAfterRejectionRule{Cooldown: 7 * 24 * time.Hour}
+	app := application(user, "1000", now)
+
+	rejected := func(userID uuid.UUID, ago time.Duration) *domain.CreditApplication {
+		prev := application(userID, "1000", now.Add(-30*24*time.Hour))
+		prev.Status = domain.REJECTED
+		prev.UpdatedAt = now.Add(-ago)
+		return prev
+	}
+
+	tests := []struct {
+		name  string
+		prev  *domain.CreditApplication
+		fires bool
+	}{
+		{"recent rejection", rejected(user, 48*time.Hour), true},
+		{"rejection after cooldown", rejected(user, 8*24*time.Hour), false},
+		{"other user's rejection", rejected(other, time.Hour), false},
+		{"not rejected", application(user, "1000", now.Add(-time.Hour)), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := rule.Check(app, Facts{Now: now, History: []*domain.CreditApplication{tt.prev}}); ok != tt.fires {
+				t.Fatalf("fires = %v, want %v", ok, tt.fires)
+			}
+		})
+	}
+}
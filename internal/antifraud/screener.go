@@ -0,0 +1,109 @@
+package antifraud
+
+import (
+	"context"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// HistorySource loads the applications rules compare against.
+type HistorySource interface {
+	ListByUserOrBankAccount(ctx context.Context, userID string, bankAccountID string, since time.Time, limit int) ([]*domain.CreditApplication, error)
+}
+
+// WeightedRule adds Score to the risk score when Rule fires.
+type WeightedRule struct {
+	Rule  Rule
+	Score int
+}
+
+type Thresholds struct {
+	Review int
+	Reject int
+}
+
+type Screener struct {
+	history    HistorySource
+	rules      []WeightedRule
+	thresholds Thresholds
+	// lookback and historyLimit bound the history loaded per application.
+	lookback     time.Duration
+	historyLimit int
+}
+
+func NewScreener(history HistorySource, rules []WeightedRule, thresholds Thresholds, lookback time.Duration, historyLimit int) *Screener {
+	return &Screener{
+		history:      history,
+		rules:        rules,
+		thresholds:   thresholds,
+		lookback:     lookback,
+		historyLimit: historyLimit,
+	}
+}
+
+// Assess scores app against the recent applications of its user and bank
+// account. pending are applications accepted earlier in the same batch that
+// are not saved yet.
+func (s *Screener) Assess(ctx context.Context, app *domain.CreditApplication, pending ...*domain.CreditApplication) (domain.RiskAssessment, error) {
+	now := time.Now().UTC()
+	since := now.Add(-s.lookback)
+	history, err := s.history.ListByUserOrBankAccount(ctx, app.UserID.String(), app.ToBankAccountID.String(), since, s.historyLimit)
+	if err != nil {
+		return domain.RiskAssessment{}, err
+	}
+
+	facts := Facts{Now: now}
+	for _, prev := range history {
+		if prev.ID != app.ID {
+			facts.History = append(facts.History, prev)
+		}
+	}
+	// Заявки того же пакета ещё не сохранены, но для правил они такая же история.
+	for _, prev := range pending {
+		related := prev.UserID == app.UserID || prev.ToBankAccountID == app.ToBankAccountID
+		if prev.ID != app.ID && related && !prev.CreatedAt.Before(since) {
+			facts.History = append(facts.History, prev)
+		}
+	}
+
+	assessment := Evaluate(app, facts, s.rules, s.thresholds)
+	if assessment.Decision != domain.RiskPass {
+		logger.FromContext(ctx).Warn("Antifraud flagged application",
+			zap.String("app_id", app.ID.String()),
+			zap.Int("score", assessment.Score),
+			zap.String("decision", string(assessment.Decision)),
+			zap.Any("flags", assessment.Flags),
+		)
+	}
+	return assessment, nil
+}
+
+// Evaluate sums the scores of the rules that fire, capped at 100, and maps
+// the total to a decision.
+func Evaluate(app *domain.CreditApplication, facts Facts, rules []WeightedRule, thresholds Thresholds) domain.RiskAssessment {
+	assessment := domain.RiskAssessment{Decision: domain.RiskPass}
+	for _, weighted := range rules {
+		detail, ok := weighted.Rule.Check(app, facts)
+		if !ok {
+			continue
+		}
+		assessment.Score += weighted.Score
+		assessment.Flags = append(assessment.Flags, domain.RiskFlag{
+			Rule:   weighted.Rule.Name(),
+			Score:  weighted.Score,
+			Detail: detail,
+		})
+	}
+	assessment.Score = min(assessment.Score, 100)
+
+	switch {
+	case assessment.Score >= thresholds.Reject:
+		assessment.Decision = domain.RiskReject
+	case assessment.Score >= thresholds.Review:
+		assessment.Decision = domain.RiskReview
+	}
+	return assessment
+}
@@ -0,0 +1,88 @@
+package antifraud
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+)
+
+// fixedRule fires with a constant result.
+type fixedRule struct {
+	name  string
+	fires bool
+}
+
+func (r fixedRule) Name() string { return r.name }
+
+func (r fixedRule) Check(*domain.CreditApplication, Facts) (string, bool) {
+	return r.name, r.fires
+}
+
+func TestEvaluate(t *testing.T) {
+	thresholds := Thresholds{Review: 40, Reject: 80}
+	app := application(user, "1000", now)
+
+	tests := []struct {
+		name     string
+		rules    []WeightedRule
+		score    int
+		decision domain.RiskDecision
+		flags    int
+	}{
+		{"nothing fires", []WeightedRule{{Rule: fixedRule{"a", false}, Score: 50}}, 0, domain.RiskPass, 0},
+		{"below review", []WeightedRule{{Rule: fixedRule{"a", true}, Score: 39}}, 39, domain.RiskPass, 1},
+		{"review threshold", []WeightedRule{
+			{Rule: fixedRule{"a", true}, Score: 20},
+			{Rule: fixedRule{"b", true}, Score: 20},
+			{Rule: fixedRule{"c", false}, Score: 50},
+		}, 40, domain.RiskReview, 2},
+		{"reject threshold", []WeightedRule{{Rule: fixedRule{"a", true}, Score: 80}}, 80, domain.RiskReject, 1},
+		{"score capped at 100", []WeightedRule{
+			{Rule: fixedRule{"a", true}, Score: 70},
+			{Rule: fixedRule{"b", true}, Score: 70},
+		}, 100, domain.RiskReject, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Evaluate(app, Facts{Now: now}, tt.rules, thresholds)
+			if got.Score != tt.score || got.Decision != tt.decision || len(got.Flags) != tt.flags {
+				t.Fatalf("got score %d decision %s flags %d, want %d %s %d",
+					got.Score, got.Decision, len(got.Flags), tt.score, tt.decision, tt.flags)
+			}
+		})
+	}
+}
+
+type historyStub struct {
+	apps []*domain.CreditApplication
+	err  error
+}
+
+func (h historyStub) ListByUserOrBankAccount(context.Context, string, string, time.Time, int) ([]*domain.CreditApplication, error) {
+	return h.apps, h.err
+}
+
+func TestScreenerExcludesTheApplicationItself(t *testing.T) {
+	app := application(user, "1000", time.Now().UTC())
+	rules := []WeightedRule{{Rule: VelocityRule{Max: 1, Window: time.Hour}, Score: 100}}
+	screener := NewScreener(historyStub{apps: []*domain.CreditApplication{app}}, rules, Thresholds{Review: 40, Reject: 80}, time.Hour, 10)
+
+	got, err := screener.Assess(context.Background(), app)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Decision != domain.RiskPass {
+		t.Fatalf("decision = %s, the application must not count against itself", got.Decision)
+	}
+}
+
+func TestScreenerReturnsHistoryError(t *testing.T) {
+	errHistory := errors.New("db down")
+	screener := NewScreener(historyStub{err: errHistory}, nil, Thresholds{}, time.Hour, 10)
+	if _, err := screener.Assess(context.Background(), application(user, "1000", now)); !errors.Is(err, errHistory) {
+		t.Fatalf("err = %v, want %v", err, errHistory)
+	}
+}
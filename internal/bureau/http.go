@@ -0,0 +1,58 @@
+package bureau
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/resilience"
+	"github.com/google/uuid"
+)
+
+// HTTPBureau fetches reports from GET {baseURL}/v1/reports/{userID}.
+type HTTPBureau struct {
+	name    string
+	baseURL string
+	client  *resilience.HTTPClient
+}
+
+var _ domain.CreditBureau = (*HTTPBureau)(nil)
+
+func NewHTTPBureau(name, baseURL string, client *resilience.HTTPClient) *HTTPBureau {
+	return &HTTPBureau{name: name, baseURL: baseURL, client: client}
+}
+
+func (b *HTTPBureau) Name() string { return b.name }
+
+func (b *HTTPBureau) FetchReport(ctx context.Context, userID uuid.UUID) (domain.BureauData, error) {
+	var data domain.BureauData
+
+	endpoint, err := url.JoinPath(b.baseURL, "v1", "reports", userID.String())
+	if err != nil {
+		return data, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return data, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	err = b.client.Do(req, func(resp *http.Response) error {
+		data = domain.BureauData{}
+		if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+			return fmt.Errorf("decode report: %w", err)
+		}
+		return nil
+	})
+	if resilience.IsHTTPStatus(err, http.StatusNotFound) {
+		// Бюро ничего не знает о клиенте — это пустая кредитная история.
+		return domain.BureauData{}, nil
+	}
+	if err != nil {
+		return domain.BureauData{}, fmt.Errorf("bureau %s: %w", b.name, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,62 @@
+package bureau
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+)
+
+// DefaultFixture is the fixtures key used for users without their own entry.
+const DefaultFixture = "default"
+
+// Fixtures maps user IDs, or DefaultFixture, to the reports the stub bureau
+// returns.
+type Fixtures map[string]domain.BureauData
+
+func LoadFixtures(path string) (Fixtures, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var fixtures Fixtures
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	for key := range fixtures {
+		if key == DefaultFixture {
+			continue
+		}
+		if _, err := uuid.Parse(key); err != nil {
+			return nil, fmt.Errorf("parse %s: fixture key %q is not a user ID", path, key)
+		}
+	}
+	return fixtures, nil
+}
+
+// NewStubHandler serves fixtures with the same API HTTPBureau expects. Users
+// without a fixture and no default get 404, an empty credit history.
+func NewStubHandler(fixtures Fixtures) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/reports/{userID}", func(w http.ResponseWriter, r *http.Request) {
+		userID, err := uuid.Parse(r.PathValue("userID"))
+		if err != nil {
+			http.Error(w, "invalid user ID", http.StatusBadRequest)
+			return
+		}
+		report, ok := fixtures[userID.String()]
+		if !ok {
+			report, ok = fixtures[DefaultFixture]
+		}
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(report)
+	})
+	return mux
+}
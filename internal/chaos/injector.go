@@ -0,0 +1,284 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type Target string
+
+const (
+	TargetGRPC       Target = "grpc"
+	TargetKafka      Target = "kafka"
+	TargetRepository Target = "repository"
+)
+
+type FaultType string
+
+const (
+	FaultLatency FaultType = "latency"
+	FaultError   FaultType = "error"
+	FaultAbort   FaultType = "abort"
+)
+
+type Fault struct {
+	Type    FaultType
+	Latency time.Duration
+	Code    codes.Code
+	Message string
+}
+
+// Rule describes when and which fault is injected. Empty Target matches every
+// target, Operation matches exactly or by prefix when it ends with "*"
+// (e.g. "/credit.v1.ApplicationService/*"), every Headers entry must be
+// present with the same value and Percentage is in the 0..100 range.
+type Rule struct {
+	Name       string
+	Target     Target
+	Operation  string
+	Headers    map[string]string
+	Percentage float64
+	Fault      Fault
+}
+
+// Headers gives access to request headers of the intercepted call: gRPC
+// metadata, Kafka record headers and so on.
+type Headers interface {
+	Get(key string) string
+}
+
+type Injector struct {
+	enabled atomic.Bool
+	mu      sync.RWMutex
+	rules   []Rule
+}
+
+func NewInjector(enabled bool, rules []Rule) *Injector {
+	inj := &Injector{rules: rules}
+	inj.enabled.Store(enabled)
+	return inj
+}
+
+func (i *Injector) Enabled() bool {
+	return i.enabled.Load()
+}
+
+func (i *Injector) SetEnabled(ctx context.Context, enabled bool) {
+	i.enabled.Store(enabled)
+	logger.FromContext(ctx).Warn("Fault injection toggled", zap.Bool("enabled", enabled))
+}
+
+func (i *Injector) Rules() []Rule {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	return append([]Rule(nil), i.rules...)
+}
+
+func (i *Injector) SetRules(ctx context.Context, rules []Rule) error {
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	i.mu.Lock()
+	i.rules = append([]Rule(nil), rules...)
+	i.mu.Unlock()
+
+	logger.FromContext(ctx).Warn("Fault injection rules replaced", zap.Int("count", len(rules)))
+	return nil
+}
+
+// Inject applies the first matching rule. Latency faults sleep (respecting
+// ctx) and return nil, error and abort faults return a gRPC status error.
+func (i *Injector) Inject(ctx context.Context, target Target, operation string, headers Headers) error {
+	if i == nil || !i.Enabled() {
+		return nil
+	}
+
+	i.mu.RLock()
+	rules := i.rules
+	i.mu.RUnlock()
+
+	for _, rule := range rules {
+		if !rule.matches(target, operation, headers) {
+			continue
+		}
+		if rand.Float64()*100 >= rule.Percentage {
+			continue
+		}
+
+		logger.FromContext(ctx).Warn("Injecting fault",
+			zap.String("rule", rule.Name),
+			zap.String("target", string(target)),
+			zap.String("operation", operation),
+			zap.String("fault", string(rule.Fault.Type)),
+		)
+		return rule.Fault.apply(ctx)
+	}
+
+	return nil
+}
+
+func (r Rule) Validate() error {
+	if r.Percentage < 0 || r.Percentage > 100 {
+		return fmt.Errorf("rule %q: percentage must be within 0..100", r.Name)
+	}
+	switch r.Target {
+	case "", TargetGRPC, TargetKafka, TargetRepository:
+	default:
+		return fmt.Errorf("rule %q: unknown target %q", r.Name, r.Target)
+	}
+	switch r.Fault.Type {
+	case FaultLatency:
+		if r.Fault.Latency <= 0 {
+			return fmt.Errorf("rule %q: latency must be positive", r.Name)
+		}
+	case FaultError:
+		if r.Fault.Code == codes.OK {
+			return fmt.Errorf("rule %q: error fault requires a non-OK code", r.Name)
+		}
+	case FaultAbort:
+	default:
+		return fmt.Errorf("rule %q: unknown fault type %q", r.Name, r.Fault.Type)
+	}
+	return nil
+}
+
+func (r Rule) matches(target Target, operation string, headers Headers) bool {
+	if r.Target != "" && r.Target != target {
+		return false
+	}
+
+	if r.Operation != "" {
+		if prefix, ok := strings.CutSuffix(r.Operation, "*"); ok {
+			if !strings.HasPrefix(operation, prefix) {
+				return false
+			}
+		} else if r.Operation != operation {
+			return false
+		}
+	}
+
+	for key, value := range r.Headers {
+		if headers == nil || headers.Get(key) != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f Fault) apply(ctx context.Context) error {
+	switch f.Type {
+	case FaultLatency:
+		timer := time.NewTimer(f.Latency)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	case FaultAbort:
+		return status.Error(codes.Aborted, f.message("injected abort"))
+	default:
+		return status.Error(f.Code, f.message("injected fault"))
+	}
+}
+
+func (f Fault) message(fallback string) string {
+	if f.Message != "" {
+		return f.Message
+	}
+	return fallback
+}
+
+// MetadataHeaders reads headers from incoming gRPC metadata.
+type MetadataHeaders metadata.MD
+
+func (h MetadataHeaders) Get(key string) string {
+	if values := metadata.MD(h).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func IncomingHeaders(ctx context.Context) Headers {
+	md, _ := metadata.FromIncomingContext(ctx)
+	return MetadataHeaders(md)
+}
+
+type ruleFile struct {
+	Name       string            `json:"name"`
+	Target     Target            `json:"target"`
+	Operation  string            `json:"operation"`
+	Headers    map[string]string `json:"headers"`
+	Percentage float64           `json:"percentage"`
+	Fault      struct {
+		Type    FaultType `json:"type"`
+		Latency string    `json:"latency"`
+		Code    string    `json:"code"`
+		Message string    `json:"message"`
+	} `json:"fault"`
+}
+
+// LoadRules reads rules from a JSON file, e.g.
+//
+//	[{"name": "slow-get", "target": "grpc", "operation": "/credit.v1.ApplicationService/Get",
+//	  "percentage": 50, "fault": {"type": "latency", "latency": "300ms"}}]
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read fault rules: %w", err)
+	}
+
+	var raw []ruleFile
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse fault rules: %w", err)
+	}
+
+	rules := make([]Rule, 0, len(raw))
+	for _, r := range raw {
+		rule := Rule{
+			Name:       r.Name,
+			Target:     r.Target,
+			Operation:  r.Operation,
+			Headers:    r.Headers,
+			Percentage: r.Percentage,
+			Fault: Fault{
+				Type:    r.Fault.Type,
+				Message: r.Fault.Message,
+			},
+		}
+		if r.Fault.Latency != "" {
+			if rule.Fault.Latency, err = time.ParseDuration(r.Fault.Latency); err != nil {
+				return nil, fmt.Errorf("rule %q: invalid latency: %w", r.Name, err)
+			}
+		}
+		if r.Fault.Code != "" {
+			if err := rule.Fault.Code.UnmarshalJSON([]byte(fmt.Sprintf("%q", r.Fault.Code))); err != nil {
+				return nil, fmt.Errorf("rule %q: invalid code: %w", r.Name, err)
+			}
+		}
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
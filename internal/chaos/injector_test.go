@@ -0,0 +1,192 @@
+package chaos
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func errorRule(name string, target Target, operation string) Rule {
+	return Rule{
+		Name:       name,
+		Target:     target,
+		Operation:  operation,
+		Percentage: 100,
+		Fault:      Fault{Type: FaultError, Code: codes.Unavailable, Message: name},
+	}
+}
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  Rule
+		valid bool
+	}{
+		{"error", errorRule("e", TargetGRPC, ""), true},
+		{"any target", errorRule("e", "", ""), true},
+		{"abort", Rule{Percentage: 10, Fault: Fault{Type: FaultAbort}}, true},
+		{"latency", Rule{Percentage: 10, Fault: Fault{Type: FaultLatency, Latency: time.Millisecond}}, true},
+		{"negative percentage", Rule{Percentage: -1, Fault: Fault{Type: FaultAbort}}, false},
+		{"percentage over 100", Rule{Percentage: 101, Fault: Fault{Type: FaultAbort}}, false},
+		{"unknown target", errorRule("e", "http", ""), false},
+		{"latency without duration", Rule{Fault: Fault{Type: FaultLatency}}, false},
+		{"error without code", Rule{Fault: Fault{Type: FaultError}}, false},
+		{"unknown fault", Rule{Fault: Fault{Type: "panic"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.rule.Validate(); (err == nil) != tt.valid {
+				t.Fatalf("Validate() = %v, valid = %v", err, tt.valid)
+			}
+		})
+	}
+}
+
+func TestInjectAppliesFirstMatchingRule(t *testing.T) {
+	const get = "/credit.v1.ApplicationService/Get"
+	withHeader := errorRule("header", TargetGRPC, "")
+	withHeader.Headers = map[string]string{"x-chaos": "on"}
+	never := errorRule("never", "", "")
+	never.Percentage = 0
+
+	tests := []struct {
+		name      string
+		enabled   bool
+		rules     []Rule
+		target    Target
+		operation string
+		headers   Headers
+		want      string
+	}{
+		{"exact operation", true, []Rule{errorRule("get", TargetGRPC, get)}, TargetGRPC, get, nil, "get"},
+		{"prefix operation", true, []Rule{errorRule("svc", TargetGRPC, "/credit.v1.ApplicationService/*")}, TargetGRPC, get, nil, "svc"},
+		{"other operation", true, []Rule{errorRule("get", TargetGRPC, get)}, TargetGRPC, "/credit.v1.ApplicationService/List", nil, ""},
+		{"other target", true, []Rule{errorRule("repo", TargetRepository, "")}, TargetKafka, "application", nil, ""},
+		{"any target", true, []Rule{errorRule("any", "", "")}, TargetKafka, "application", nil, "any"},
+		{"header present", true, []Rule{withHeader}, TargetGRPC, get, MetadataHeaders(metadata.Pairs("x-chaos", "on")), "header"},
+		{"header differs", true, []Rule{withHeader}, TargetGRPC, get, MetadataHeaders(metadata.Pairs("x-chaos", "off")), ""},
+		{"no headers", true, []Rule{withHeader}, TargetGRPC, get, nil, ""},
+		{"zero percentage", true, []Rule{never}, TargetGRPC, get, nil, ""},
+		{"first match wins", true, []Rule{never, errorRule("a", "", ""), errorRule("b", "", "")}, TargetGRPC, get, nil, "a"},
+		{"disabled", false, []Rule{errorRule("get", TargetGRPC, get)}, TargetGRPC, get, nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewInjector(tt.enabled, tt.rules).Inject(context.Background(), tt.target, tt.operation, tt.headers)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("Inject() = %v, want no fault", err)
+				}
+				return
+			}
+			if st := status.Convert(err); st.Code() != codes.Unavailable || st.Message() != tt.want {
+				t.Fatalf("Inject() = %v, want Unavailable %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestNilInjectorInjectsNothing(t *testing.T) {
+	var injector *Injector
+	if err := injector.Inject(context.Background(), TargetGRPC, "op", nil); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		fault   Fault
+		err     error
+		code    codes.Code
+	}{
+		{"latency then success", time.Second, Fault{Type: FaultLatency, Latency: time.Millisecond}, nil, codes.OK},
+		{"latency cut by the deadline", time.Millisecond, Fault{Type: FaultLatency, Latency: time.Hour}, context.DeadlineExceeded, codes.Unknown},
+		{"abort", time.Second, Fault{Type: FaultAbort}, nil, codes.Aborted},
+		{"error", time.Second, Fault{Type: FaultError, Code: codes.ResourceExhausted}, nil, codes.ResourceExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			err := NewInjector(true, []Rule{{Percentage: 100, Fault: tt.fault}}).Inject(ctx, TargetGRPC, "op", nil)
+			if tt.err != nil && !errors.Is(err, tt.err) {
+				t.Fatalf("Inject() = %v, want %v", err, tt.err)
+			}
+			if status.Code(err) != tt.code {
+				t.Fatalf("code = %s, want %s (%v)", status.Code(err), tt.code, err)
+			}
+		})
+	}
+}
+
+func TestSetRulesRejectsInvalidRule(t *testing.T) {
+	injector := NewInjector(true, []Rule{errorRule("kept", "", "")})
+	if err := injector.SetRules(context.Background(), []Rule{errorRule("ok", "", ""), {Name: "bad"}}); err == nil {
+		t.Fatal("SetRules accepted an invalid rule")
+	}
+	if rules := injector.Rules(); len(rules) != 1 || rules[0].Name != "kept" {
+		t.Fatalf("rules = %+v, an invalid set must not replace them", rules)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    Rule
+		wantErr bool
+	}{
+		{
+			name:    "latency",
+			content: `[{"name": "slow", "target": "grpc", "operation": "/a/B", "percentage": 50, "fault": {"type": "latency", "latency": "300ms"}}]`,
+			want:    Rule{Name: "slow", Target: TargetGRPC, Operation: "/a/B", Percentage: 50, Fault: Fault{Type: FaultLatency, Latency: 300 * time.Millisecond}},
+		},
+		{
+			name:    "error code",
+			content: `[{"name": "down", "percentage": 100, "fault": {"type": "error", "code": "UNAVAILABLE", "message": "db down"}}]`,
+			want:    Rule{Name: "down", Percentage: 100, Fault: Fault{Type: FaultError, Code: codes.Unavailable, Message: "db down"}},
+		},
+		{name: "bad json", content: `{`, wantErr: true},
+		{name: "bad latency", content: `[{"name": "x", "fault": {"type": "latency", "latency": "soon"}}]`, wantErr: true},
+		{name: "bad code", content: `[{"name": "x", "fault": {"type": "error", "code": "BROKEN"}}]`, wantErr: true},
+		{name: "invalid rule", content: `[{"name": "x", "percentage": 200, "fault": {"type": "abort"}}]`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "rules.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			rules, err := LoadRules(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("LoadRules accepted %s", tt.content)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := rules[0]
+			if len(rules) != 1 || got.Name != tt.want.Name || got.Target != tt.want.Target || got.Operation != tt.want.Operation ||
+				got.Percentage != tt.want.Percentage || got.Fault != tt.want.Fault {
+				t.Fatalf("rules = %+v, want [%+v]", rules, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadRulesMissingFile(t *testing.T) {
+	if _, err := LoadRules(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Fatal("LoadRules of a missing file succeeded")
+	}
+}
@@ -0,0 +1,41 @@
+package chaos
+
+import (
+	"context"
+
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/IBM/sarama"
+)
+
+// FaultyHandler injects faults before a Kafka message reaches the wrapped
+// handler. The operation name is the message topic.
+type FaultyHandler struct {
+	next     messaging.MessageHandler
+	injector *Injector
+}
+
+var _ messaging.MessageHandler = (*FaultyHandler)(nil)
+
+func NewFaultyHandler(next messaging.MessageHandler, injector *Injector) *FaultyHandler {
+	return &FaultyHandler{next: next, injector: injector}
+}
+
+func (h *FaultyHandler) Name() string { return h.next.Name() }
+
+func (h *FaultyHandler) Handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	if err := h.injector.Inject(ctx, TargetKafka, message.Topic, recordHeaders(message.Headers)); err != nil {
+		return err
+	}
+	return h.next.Handle(ctx, message)
+}
+
+type recordHeaders []*sarama.RecordHeader
+
+func (h recordHeaders) Get(key string) string {
+	for _, header := range h {
+		if header != nil && string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
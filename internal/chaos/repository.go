@@ -0,0 +1,137 @@
+package chaos
+
+import (
+	"context"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+)
+
+// FaultyRepository injects faults in front of every repository call.
+// Operation names are the method names, e.g. "FindByID".
+type FaultyRepository struct {
+	next     domain.CreditRepository
+	injector *Injector
+}
+
+var _ domain.CreditRepository = (*FaultyRepository)(nil)
+
+func NewFaultyRepository(next domain.CreditRepository, injector *Injector) *FaultyRepository {
+	return &FaultyRepository{next: next, injector: injector}
+}
+
+func (r *FaultyRepository) inject(ctx context.Context, operation string) error {
+	return r.injector.Inject(ctx, TargetRepository, operation, IncomingHeaders(ctx))
+}
+
+func (r *FaultyRepository) FindByID(ctx context.Context, id string) (*domain.CreditApplication, error) {
+	if err := r.inject(ctx, "FindByID"); err != nil {
+		return nil, err
+	}
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *FaultyRepository) FindByIDs(ctx context.Context, ids []string) ([]*domain.CreditApplication, error) {
+	if err := r.inject(ctx, "FindByIDs"); err != nil {
+		return nil, err
+	}
+	return r.next.FindByIDs(ctx, ids)
+}
+
+func (r *FaultyRepository) FindByUserID(ctx context.Context, userID string) (*domain.CreditApplication, error) {
+	if err := r.inject(ctx, "FindByUserID"); err != nil {
+		return nil, err
+	}
+	return r.next.FindByUserID(ctx, userID)
+}
+
+func (r *FaultyRepository) List(ctx context.Context, statuses []domain.ApplicationStatus, offset int, limit int, userID string) ([]*domain.CreditApplication, int, error) {
+	if err := r.inject(ctx, "List"); err != nil {
+		return nil, 0, err
+	}
+	return r.next.List(ctx, statuses, offset, limit, userID)
+}
+
+func (r *FaultyRepository) ListCreatedBetween(ctx context.Context, from time.Time, to time.Time, offset int, limit int) ([]*domain.CreditApplication, error) {
+	if err := r.inject(ctx, "ListCreatedBetween"); err != nil {
+		return nil, err
+	}
+	return r.next.ListCreatedBetween(ctx, from, to, offset, limit)
+}
+
+func (r *FaultyRepository) ListByUserOrBankAccount(ctx context.Context, userID string, bankAccountID string, since time.Time, limit int) ([]*domain.CreditApplication, error) {
+	if err := r.inject(ctx, "ListByUserOrBankAccount"); err != nil {
+		return nil, err
+	}
+	return r.next.ListByUserOrBankAccount(ctx, userID, bankAccountID, since, limit)
+}
+
+func (r *FaultyRepository) ListStale(ctx context.Context, filter domain.StaleFilter, limit int) ([]*domain.CreditApplication, error) {
+	if err := r.inject(ctx, "ListStale"); err != nil {
+		return nil, err
+	}
+	return r.next.ListStale(ctx, filter, limit)
+}
+
+func (r *FaultyRepository) Export(ctx context.Context, filter domain.ApplicationFilter, batchSize int, fn func([]*domain.CreditApplication) error) error {
+	if err := r.inject(ctx, "Export"); err != nil {
+		return err
+	}
+	return r.next.Export(ctx, filter, batchSize, fn)
+}
+
+func (r *FaultyRepository) Save(ctx context.Context, app *domain.CreditApplication) error {
+	if err := r.inject(ctx, "Save"); err != nil {
+		return err
+	}
+	return r.next.Save(ctx, app)
+}
+
+func (r *FaultyRepository) SaveAll(ctx context.Context, apps []*domain.CreditApplication) error {
+	if err := r.inject(ctx, "SaveAll"); err != nil {
+		return err
+	}
+	return r.next.SaveAll(ctx, apps)
+}
+
+func (r *FaultyRepository) UpdateAll(ctx context.Context, apps []*domain.CreditApplication) error {
+	if err := r.inject(ctx, "UpdateAll"); err != nil {
+		return err
+	}
+	return r.next.UpdateAll(ctx, apps)
+}
+
+func (r *FaultyRepository) Update(ctx context.Context, app *domain.CreditApplication) error {
+	if err := r.inject(ctx, "Update"); err != nil {
+		return err
+	}
+	return r.next.Update(ctx, app)
+}
+
+func (r *FaultyRepository) UpdateIfCurrent(ctx context.Context, app *domain.CreditApplication, expectedStatus domain.ApplicationStatus, expectedVersion int64) error {
+	if err := r.inject(ctx, "UpdateIfCurrent"); err != nil {
+		return err
+	}
+	return r.next.UpdateIfCurrent(ctx, app, expectedStatus, expectedVersion)
+}
+
+func (r *FaultyRepository) ClearPendingStatus(ctx context.Context, id string) error {
+	if err := r.inject(ctx, "ClearPendingStatus"); err != nil {
+		return err
+	}
+	return r.next.ClearPendingStatus(ctx, id)
+}
+
+func (r *FaultyRepository) UpdateStatus(ctx context.Context, id string, status domain.ApplicationStatus) error {
+	if err := r.inject(ctx, "UpdateStatus"); err != nil {
+		return err
+	}
+	return r.next.UpdateStatus(ctx, id, status)
+}
+
+func (r *FaultyRepository) Delete(ctx context.Context, id string) error {
+	if err := r.inject(ctx, "Delete"); err != nil {
+		return err
+	}
+	return r.next.Delete(ctx, id)
+}
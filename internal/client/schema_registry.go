@@ -2,34 +2,64 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
+	"net/url"
+
+	"github.com/Andronzi/credit-origination/internal/resilience"
 )
 
 type SchemaRegistryClient struct {
 	baseURL string
+	client  *resilience.HTTPClient
 }
 
-func NewSchemaRegistryClient(url string) *SchemaRegistryClient {
-	return &SchemaRegistryClient{baseURL: url}
+func NewSchemaRegistryClient(url string, client *resilience.HTTPClient) *SchemaRegistryClient {
+	return &SchemaRegistryClient{baseURL: url, client: client}
 }
 
-func (c *SchemaRegistryClient) GetSchemaID(subject string, schema string) (int, error) {
-	reqBody := fmt.Sprintf(`{"schema": %q}`, schema)
-	res, err := http.Post(
-		fmt.Sprintf("%s/subjects/%s/versions", c.baseURL, subject),
-		"application/vnd.schemaregistry.v1+json",
-		bytes.NewBufferString(reqBody),
-	)
+func (c *SchemaRegistryClient) GetSchemaID(ctx context.Context, subject string, schema string) (int, error) {
+	reqBody, err := json.Marshal(map[string]string{"schema": schema})
 	if err != nil {
 		return 0, err
 	}
-	defer res.Body.Close()
+	endpoint, err := url.JoinPath(c.baseURL, "subjects", subject, "versions")
+	if err != nil {
+		return 0, err
+	}
+	// Повторная регистрация той же схемы возвращает тот же ID.
+	req, err := http.NewRequestWithContext(resilience.WithIdempotent(ctx), http.MethodPost, endpoint, bytes.NewReader(reqBody))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/vnd.schemaregistry.v1+json")
 
-	body, _ := io.ReadAll(res.Body)
 	var result struct{ ID int }
-	json.Unmarshal(body, &result)
+	err = c.client.Do(req, func(res *http.Response) error {
+		return json.NewDecoder(res.Body).Decode(&result)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("register schema for subject %s: %w", subject, err)
+	}
 	return result.ID, nil
 }
+
+func (c *SchemaRegistryClient) GetSchemaByID(ctx context.Context, id int) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/schemas/ids/%d", c.baseURL, id), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Schema string `json:"schema"`
+	}
+	err = c.client.Do(req, func(res *http.Response) error {
+		return json.NewDecoder(res.Body).Decode(&result)
+	})
+	if err != nil {
+		return "", fmt.Errorf("schema registry: schema %d: %w", id, err)
+	}
+	return result.Schema, nil
+}
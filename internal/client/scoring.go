@@ -2,21 +2,46 @@ package client
 
 import (
 	"context"
-	"time"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/Andronzi/credit-origination/internal/resilience"
 )
 
 type ScoringClient struct {
 	baseURL string
-	timeout time.Duration
+	client  *resilience.HTTPClient
 }
 
-func NewScoringClient(baseURL string) *ScoringClient {
+func NewScoringClient(baseURL string, client *resilience.HTTPClient) *ScoringClient {
 	return &ScoringClient{
 		baseURL: baseURL,
-		timeout: 5 * time.Second,
+		client:  client,
 	}
 }
 
+// GetScore fetches GET {baseURL}/v1/scores/{userID}; the timeout comes from
+// the scoring dependency settings.
 func (c *ScoringClient) GetScore(ctx context.Context, userID string) (int, error) {
-	return 750, nil
+	endpoint, err := url.JoinPath(c.baseURL, "v1", "scores", userID)
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		Score int `json:"score"`
+	}
+	err = c.client.Do(req, func(res *http.Response) error {
+		return json.NewDecoder(res.Body).Decode(&result)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("scoring: %w", err)
+	}
+	return result.Score, nil
 }
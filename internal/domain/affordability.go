@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Andronzi/credit-origination/pkg/money"
+	"github.com/shopspring/decimal"
+)
+
+// UnaffordableReason is stored as the reject reason of applications whose
+// debt-to-income ratio is above the product maximum.
+const UnaffordableReason = "UNAFFORDABLE"
+
+type AffordabilityDecision string
+
+const (
+	AffordabilityPass AffordabilityDecision = "PASS"
+	AffordabilityFail AffordabilityDecision = "FAIL"
+)
+
+var (
+	ErrInvalidIncome      = errors.New("monthly income must be positive")
+	ErrInvalidObligations = errors.New("monthly obligations must not be negative")
+	ErrInvalidDependants  = errors.New("dependants must not be negative")
+)
+
+// SetFinancials stores the applicant's monthly figures, in the application
+// currency. They are optional: without them affordability is not assessed.
+func (a *CreditApplication) SetFinancials(currency money.Currency, income, obligations decimal.Decimal, dependants int) error {
+	if err := ValidateAmounts(currency, income, obligations); err != nil {
+		return err
+	}
+	if !income.IsPositive() {
+		return ErrInvalidIncome
+	}
+	if obligations.IsNegative() {
+		return ErrInvalidObligations
+	}
+	if dependants < 0 {
+		return ErrInvalidDependants
+	}
+	a.MonthlyIncome = decimal.NewNullDecimal(income)
+	a.MonthlyObligations = decimal.NewNullDecimal(obligations)
+	a.Dependants = sql.NullInt32{Int32: int32(dependants), Valid: true}
+	return nil
+}
+
+// HasFinancials reports whether the applicant provided income data.
+func (a *CreditApplication) HasFinancials() bool {
+	return a.MonthlyIncome.Valid
+}
+
+// AffordabilityAssessment is the debt-to-income check of an application.
+type AffordabilityAssessment struct {
+	MonthlyPayment decimal.Decimal
+	DTI            decimal.Decimal
+	MaxDTI         decimal.Decimal
+	Decision       AffordabilityDecision
+}
+
+// ApplyAffordability stores the assessment on the application. On a FAIL
+// decision it is counter-offered when there are offers and rejected otherwise.
+func (a *CreditApplication) ApplyAffordability(assessment AffordabilityAssessment, offers []*CounterOffer) error {
+	a.MonthlyPayment = decimal.NewNullDecimal(assessment.MonthlyPayment)
+	a.DTI = decimal.NewNullDecimal(assessment.DTI)
+	a.MaxDTI = decimal.NewNullDecimal(assessment.MaxDTI)
+	a.AffordabilityDecision = assessment.Decision
+	if assessment.Decision != AffordabilityFail {
+		return nil
+	}
+	if len(offers) > 0 {
+		return a.OfferCounter()
+	}
+	return a.Reject(UnaffordableReason)
+}
+
+// PassedChecks reports whether the application passed both antifraud and
+// affordability and may move on to AGREEMENT_CREATED.
+func (a *CreditApplication) PassedChecks() bool {
+	return a.PassedRiskCheck() && a.AffordabilityDecision != AffordabilityFail
+}
+
+// AffordabilityPolicy holds the maximum DTI, per product with a fallback.
+// Every dependant lowers the maximum by dependantStep.
+type AffordabilityPolicy struct {
+	fallback      decimal.Decimal
+	products      map[string]decimal.Decimal
+	dependantStep decimal.Decimal
+}
+
+func NewAffordabilityPolicy(fallback decimal.Decimal, products map[string]decimal.Decimal, dependantStep decimal.Decimal) *AffordabilityPolicy {
+	return &AffordabilityPolicy{fallback: fallback, products: products, dependantStep: dependantStep}
+}
+
+func (p *AffordabilityPolicy) MaxDTI(app *CreditApplication) decimal.Decimal {
+	maxDTI, ok := p.products[app.ProductCode]
+	if !ok {
+		maxDTI = p.fallback
+	}
+	maxDTI = maxDTI.Sub(p.dependantStep.Mul(decimal.NewFromInt32(app.Dependants.Int32)))
+	if maxDTI.IsNegative() {
+		return decimal.Zero
+	}
+	return maxDTI
+}
+
+// Assess computes the DTI of an application with financials:
+// (obligations + monthly payment) / income.
+func (p *AffordabilityPolicy) Assess(app *CreditApplication) (AffordabilityAssessment, error) {
+	if !app.HasFinancials() || !app.MonthlyIncome.Decimal.IsPositive() {
+		return AffordabilityAssessment{}, fmt.Errorf("application %s: %w", app.ID, ErrInvalidIncome)
+	}
+
+	payment := MonthlyPayment(app.DisbursementAmount, app.Interest, app.Term)
+	debt := app.MonthlyObligations.Decimal.Add(payment)
+	assessment := AffordabilityAssessment{
+		MonthlyPayment: payment,
+		DTI:            debt.DivRound(app.MonthlyIncome.Decimal, 4),
+		MaxDTI:         p.MaxDTI(app),
+		Decision:       AffordabilityPass,
+	}
+	if assessment.DTI.GreaterThan(assessment.MaxDTI) {
+		assessment.Decision = AffordabilityFail
+	}
+	return assessment, nil
+}
+
+// MonthlyPayment is the annuity payment for principal at an annual rate in
+// percent over term months, rounded to kopecks.
+func MonthlyPayment(principal, annualRate decimal.Decimal, term uint32) decimal.Decimal {
+	if term == 0 {
+		return principal
+	}
+	months := decimal.NewFromInt(int64(term))
+	rate := annualRate.DivRound(decimal.NewFromInt(1200), 16)
+	if rate.IsZero() {
+		return principal.DivRound(months, 2)
+	}
+	// P * r / (1 - (1 + r)^-n)
+	growth := decimal.NewFromInt(1).Add(rate).Pow(months)
+	discount := decimal.NewFromInt(1).Sub(decimal.NewFromInt(1).DivRound(growth, 16))
+	return principal.Mul(rate).DivRound(discount, 2)
+}
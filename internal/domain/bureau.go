@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+var ErrBureauReportNotFound = errors.New("bureau report not found")
+
+// BureauLoan is an existing loan of the applicant at another lender.
+type BureauLoan struct {
+	Lender         string          `json:"lender"`
+	Balance        decimal.Decimal `json:"balance"`
+	MonthlyPayment decimal.Decimal `json:"monthly_payment"`
+	Currency       string          `json:"currency"`
+	OpenedAt       time.Time       `json:"opened_at"`
+	Status         string          `json:"status"`
+}
+
+type BureauDelinquency struct {
+	Lender      string          `json:"lender"`
+	DaysPastDue int             `json:"days_past_due"`
+	Amount      decimal.Decimal `json:"amount"`
+	ReportedAt  time.Time       `json:"reported_at"`
+}
+
+// BureauInquiry is another lender's request for the applicant's report.
+type BureauInquiry struct {
+	Lender     string    `json:"lender"`
+	InquiredAt time.Time `json:"inquired_at"`
+}
+
+// BureauData is the credit history returned by a bureau.
+type BureauData struct {
+	Loans         []BureauLoan        `json:"loans"`
+	Delinquencies []BureauDelinquency `json:"delinquencies"`
+	Inquiries     []BureauInquiry     `json:"inquiries"`
+}
+
+func (d BureauData) Value() (driver.Value, error) {
+	data, err := json.Marshal(d)
+	return string(data), err
+}
+
+func (d *BureauData) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = BureauData{}
+		return nil
+	case []byte:
+		return json.Unmarshal(v, d)
+	case string:
+		return json.Unmarshal([]byte(v), d)
+	default:
+		return fmt.Errorf("cannot scan %T into BureauData", value)
+	}
+}
+
+// BureauReport is a bureau response kept per application, both as a cache
+// for repeated scoring and for audit.
+type BureauReport struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey" json:"id"`
+	ApplicationID uuid.UUID  `gorm:"type:uuid;uniqueIndex;not null" json:"application_id"`
+	UserID        uuid.UUID  `gorm:"type:uuid;index;not null" json:"user_id"`
+	Bureau        string     `gorm:"type:varchar(50);not null" json:"bureau" example:"stub"`
+	Data          BureauData `gorm:"type:jsonb;not null" json:"data"`
+	FetchedAt     time.Time  `gorm:"type:timestamp;not null" json:"fetched_at"`
+}
+
+// CreditBureau fetches the credit history of a user.
+type CreditBureau interface {
+	Name() string
+	FetchReport(ctx context.Context, userID uuid.UUID) (BureauData, error)
+}
+
+type BureauReportRepository interface {
+	Save(ctx context.Context, report *BureauReport) error
+	FindByApplication(ctx context.Context, applicationID string) (*BureauReport, error)
+}
+
+func NewBureauReport(app *CreditApplication, bureau string, data BureauData, now time.Time) *BureauReport {
+	return &BureauReport{
+		ID:            uuid.New(),
+		ApplicationID: app.ID,
+		UserID:        app.UserID,
+		Bureau:        bureau,
+		Data:          data,
+		FetchedAt:     now,
+	}
+}
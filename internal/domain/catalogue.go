@@ -0,0 +1,26 @@
+package domain
+
+import "github.com/shopspring/decimal"
+
+// ProductLimits are the amount, term and rate bounds of a product.
+type ProductLimits struct {
+	MinAmount decimal.Decimal
+	MaxAmount decimal.Decimal
+	MinTerm   uint32
+	MaxTerm   uint32
+	MinRate   decimal.Decimal
+	MaxRate   decimal.Decimal
+}
+
+type ProductCatalogue struct {
+	products map[string]ProductLimits
+}
+
+func NewProductCatalogue(products map[string]ProductLimits) *ProductCatalogue {
+	return &ProductCatalogue{products: products}
+}
+
+func (c *ProductCatalogue) Limits(productCode string) (ProductLimits, bool) {
+	limits, ok := c.products[productCode]
+	return limits, ok
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ConsentType string
+
+const (
+	ConsentCreditBureauCheck      ConsentType = "CREDIT_BUREAU_CHECK"
+	ConsentPersonalDataProcessing ConsentType = "PERSONAL_DATA_PROCESSING"
+	ConsentAgreementTerms         ConsentType = "AGREEMENT_TERMS"
+)
+
+var (
+	ErrUnknownConsentType  = errors.New("unknown consent type")
+	ErrEmptyConsentVersion = errors.New("consent version is required")
+	ErrConsentsMissing     = errors.New("required consents are missing")
+)
+
+func ParseConsentType(s string) (ConsentType, error) {
+	switch t := ConsentType(strings.ToUpper(strings.TrimSpace(s))); t {
+	case ConsentCreditBureauCheck, ConsentPersonalDataProcessing, ConsentAgreementTerms:
+		return t, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownConsentType, s)
+}
+
+// Consent is the customer's agreement to one document version, with where it
+// was given from.
+type Consent struct {
+	ID            uuid.UUID   `gorm:"type:uuid;primaryKey" json:"id"`
+	ApplicationID uuid.UUID   `gorm:"type:uuid;index;not null" json:"application_id"`
+	Type          ConsentType `gorm:"type:varchar(50);not null" json:"type" example:"AGREEMENT_TERMS"`
+	Version       string      `gorm:"type:varchar(50);not null" json:"version" example:"2025-10"`
+	Channel       string      `gorm:"type:varchar(50)" json:"channel" example:"MOBILE"`
+	IPAddress     string      `gorm:"type:varchar(64)" json:"ip_address" example:"203.0.113.7"`
+	UserAgent     string      `gorm:"type:text" json:"user_agent"`
+	GivenAt       time.Time   `gorm:"type:timestamp;not null" json:"given_at"`
+}
+
+func NewConsent(appID uuid.UUID, consentType ConsentType, version, channel, ipAddress, userAgent string, now time.Time) (*Consent, error) {
+	version = strings.TrimSpace(version)
+	if version == "" {
+		return nil, ErrEmptyConsentVersion
+	}
+	return &Consent{
+		ID:            uuid.New(),
+		ApplicationID: appID,
+		Type:          consentType,
+		Version:       version,
+		Channel:       strings.ToUpper(strings.TrimSpace(channel)),
+		IPAddress:     ipAddress,
+		UserAgent:     userAgent,
+		GivenAt:       now,
+	}, nil
+}
+
+type ConsentRepository interface {
+	Create(ctx context.Context, consent *Consent) error
+	// ListByApplications returns the consents of the applications, oldest first.
+	ListByApplications(ctx context.Context, applicationIDs []string) ([]*Consent, error)
+}
+
+// ConsentPolicy lists the consents required to enter a status, with
+// per-product overrides.
+type ConsentPolicy struct {
+	defaults map[ApplicationStatus][]ConsentType
+	products map[string]map[ApplicationStatus][]ConsentType
+}
+
+func NewConsentPolicy(defaults map[ApplicationStatus][]ConsentType, products map[string]map[ApplicationStatus][]ConsentType) *ConsentPolicy {
+	return &ConsentPolicy{defaults: defaults, products: products}
+}
+
+func (p *ConsentPolicy) Required(app *CreditApplication, status ApplicationStatus) []ConsentType {
+	if product, ok := p.products[app.ProductCode]; ok {
+		if required, ok := product[status]; ok {
+			return required
+		}
+	}
+	return p.defaults[status]
+}
+
+// Check fails with ErrConsentsMissing unless consents of the application cover
+// every consent required to enter status.
+func (p *ConsentPolicy) Check(app *CreditApplication, status ApplicationStatus, consents []*Consent) error {
+	given := make(map[ConsentType]bool, len(consents))
+	for _, consent := range consents {
+		if consent.ApplicationID == app.ID {
+			given[consent.Type] = true
+		}
+	}
+	var missing []string
+	for _, required := range p.Required(app, status) {
+		if !given[required] {
+			missing = append(missing, string(required))
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w for %s: %s", ErrConsentsMissing, status, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// AwaitConsents remembers a transition blocked by missing consents, so that
+// it is made once they are recorded. The version goes up with pending_status.
+func (a *CreditApplication) AwaitConsents(status ApplicationStatus) {
+	a.PendingStatus = sql.NullString{String: string(status), Valid: true}
+	a.touch()
+}
+
+// DropPending forgets a transition remembered by AwaitConsents that is no
+// longer possible.
+func (a *CreditApplication) DropPending() {
+	a.PendingStatus = sql.NullString{}
+	a.touch()
+}
+
+// ResumePending makes the transition remembered by AwaitConsents.
+func (a *CreditApplication) ResumePending() error {
+	if !a.PendingStatus.Valid {
+		return nil
+	}
+	if err := a.ChangeStatus(ApplicationStatus(a.PendingStatus.String)); err != nil {
+		return err
+	}
+	a.PendingStatus = sql.NullString{}
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Andronzi/credit-origination/pkg/money"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 )
@@ -21,6 +22,12 @@ const (
 	EMPLOYMENT_CHECK              ApplicationStatus = "EMPLOYMENT_CHECK"
 	APPROVED                      ApplicationStatus = "APPROVED"
 	REJECTED                      ApplicationStatus = "REJECTED"
+	// CANCELLED — клиент отозвал заявку до выдачи.
+	CANCELLED ApplicationStatus = "CANCELLED"
+	// MANUAL_REVIEW — заявка ждёт решения андеррайтера.
+	MANUAL_REVIEW ApplicationStatus = "MANUAL_REVIEW"
+	// COUNTER_OFFERED — запрошенные условия не прошли, клиенту предложены другие.
+	COUNTER_OFFERED ApplicationStatus = "COUNTER_OFFERED"
 )
 
 type CreditApplication struct {
@@ -29,14 +36,34 @@ type CreditApplication struct {
 	OriginationAmount  decimal.Decimal   `gorm:"type:decimal(15,2)" json:"origination_amount" example:"150000.50"`
 	ToBankAccountID    uuid.UUID         `gorm:"type:uuid;" json:"account_id" example:"550e8400-e29b-41d4-a716-446655440000"`
 	UserID             uuid.UUID         `gorm:"type:uuid;index;foreignKey:UserID;references:ID" json:"user_id" example:"550e8400-e29b-41d4-a716-446655440000"`
-	Term               uint32            `json:"term" example:"12"`
+	Term               uint32            `gorm:"type:int" json:"term" example:"12"`
 	Interest           decimal.Decimal   `gorm:"type:decimal(15,2)" json:"interest" example:"15.50"`
-	ProductCode        string            `gorm:"type:string; json:"product_code" example:"code-1"`
-	ProductVersion     string            `gorm:"type:srting; json:"product_version" example:"version1"`
-	Status             ApplicationStatus `gorm:"type:string"; "default: "DRAFT"`
-	RejectReason       sql.NullString    `json:"reject_reason" example:"Low credit score"`
-	CreatedAt          time.Time         `json:"created_at" example:"2023-10-01T12:34:56Z"`
-	UpdatedAt          time.Time         `json:"updated_at" example:"2023-10-01T12:34:56Z"`
+	ProductCode        string            `gorm:"type:varchar" json:"product_code" example:"code-1"`
+	ProductVersion     string            `gorm:"type:varchar(255)" json:"product_version" example:"version1"`
+	Currency           string            `gorm:"type:varchar(3)" json:"currency" example:"RUB"`
+	Status             ApplicationStatus `gorm:"type:varchar(50)" json:"status" example:"DRAFT"`
+	// Version растёт на каждом изменении статуса, по нему клиенты возобновляют подписку.
+	Version      int64          `gorm:"type:bigint;not null;default:1" json:"version" example:"3"`
+	RejectReason sql.NullString `gorm:"type:text" json:"reject_reason" example:"Low credit score"`
+	CancelReason sql.NullString `gorm:"type:text" json:"cancel_reason" example:"Found a better offer"`
+	RiskScore    int            `gorm:"type:int;not null;default:0" json:"risk_score" example:"40"`
+	RiskFlags    RiskFlags      `gorm:"type:jsonb" json:"risk_flags"`
+	RiskDecision RiskDecision   `gorm:"type:varchar(20)" json:"risk_decision" example:"PASS"`
+	// Доходы и расходы заявителя в месяц, необязательны.
+	MonthlyIncome      decimal.NullDecimal `gorm:"type:decimal(15,2)" json:"monthly_income" example:"120000.00"`
+	MonthlyObligations decimal.NullDecimal `gorm:"type:decimal(15,2)" json:"monthly_obligations" example:"15000.00"`
+	Dependants         sql.NullInt32       `gorm:"type:int" json:"dependants" example:"2"`
+	// Результат проверки долговой нагрузки при создании.
+	MonthlyPayment        decimal.NullDecimal   `gorm:"type:decimal(15,2)" json:"monthly_payment" example:"13850.12"`
+	DTI                   decimal.NullDecimal   `gorm:"column:dti;type:numeric" json:"dti" example:"0.2404"`
+	MaxDTI                decimal.NullDecimal   `gorm:"column:max_dti;type:decimal(5,4)" json:"max_dti" example:"0.5000"`
+	AffordabilityDecision AffordabilityDecision `gorm:"type:varchar(20)" json:"affordability_decision" example:"PASS"`
+	// PendingStatus — переход, отложенный до получения согласий клиента.
+	PendingStatus sql.NullString `gorm:"type:varchar(50)" json:"pending_status" example:"SCORING"`
+	// CreditScore — балл скоркарты по отчёту кредитного бюро.
+	CreditScore sql.NullInt32 `gorm:"type:int" json:"credit_score" example:"640"`
+	CreatedAt   time.Time     `gorm:"type:timestamp" json:"created_at" example:"2023-10-01T12:34:56Z"`
+	UpdatedAt   time.Time     `gorm:"type:timestamp" json:"updated_at" example:"2023-10-01T12:34:56Z"`
 }
 
 var (
@@ -49,6 +76,7 @@ var (
 	ErrInvalidProductCode        = errors.New("product code is required")
 	ErrInvalidProductVersion     = errors.New("product version cannot be empty")
 	ErrDisbursementExceedsAmount = errors.New("disbursement cannot exceed application amount")
+	ErrNotApplicationOwner       = errors.New("application belongs to another user")
 )
 
 func NewCreditApplication(
@@ -59,9 +87,14 @@ func NewCreditApplication(
 	interest decimal.Decimal,
 	productCode string,
 	productVersion string,
+	currency money.Currency,
 	userID uuid.UUID,
 	status ApplicationStatus,
 ) (*CreditApplication, error) {
+	if err := ValidateAmounts(currency, disbursementAmount, originationAmount); err != nil {
+		return nil, err
+	}
+
 	if disbursementAmount.IsZero() || disbursementAmount.IsNegative() {
 		return nil, fmt.Errorf("%w: disbursement amount must be positive", ErrInvalidAmount)
 	}
@@ -108,7 +141,9 @@ func NewCreditApplication(
 		Interest:           interest,
 		ProductCode:        productCode,
 		ProductVersion:     productVersion,
+		Currency:           currency.Code,
 		Status:             status,
+		Version:            1,
 		CreatedAt:          now,
 		UpdatedAt:          now,
 	}, nil
@@ -120,30 +155,137 @@ var (
 	ErrInvalidTransitionFromAgreementCreated   = errors.New("invalid transition from APPLICATION_AGREEMENT_CREATED")
 	ErrInvalidTransitionFromScoring            = errors.New("invalid transition from SCORING")
 	ErrInvalidTransitionFromEmploymentCheck    = errors.New("invalid transition from EMPLOYMENT_CHECK")
+	ErrInvalidTransitionFromManualReview       = errors.New("invalid transition from MANUAL_REVIEW")
+	ErrInvalidTransitionFromCounterOffered     = errors.New("invalid transition from COUNTER_OFFERED")
 	ErrTerminalStatus                          = errors.New("cannot transition from terminal status")
 	ErrUnknownStatus                           = errors.New("unknown current status")
+	ErrStatusAlreadySet                        = errors.New("status already set")
+	ErrApplicationCancelled                    = fmt.Errorf("%w: application was cancelled", ErrTerminalStatus)
+	ErrEmptyCancelReason                       = errors.New("cancel reason is required")
 )
 
+// IsTransitionError reports whether err was returned by ChangeStatus or a
+// consent check because the transition is not allowed.
+func IsTransitionError(err error) bool {
+	for _, target := range []error{
+		ErrInvalidTransitionFromDraft,
+		ErrInvalidTransitionFromApplicationCreated,
+		ErrInvalidTransitionFromAgreementCreated,
+		ErrInvalidTransitionFromScoring,
+		ErrInvalidTransitionFromEmploymentCheck,
+		ErrInvalidTransitionFromManualReview,
+		ErrInvalidTransitionFromCounterOffered,
+		ErrConsentsMissing,
+		ErrTerminalStatus,
+		ErrUnknownStatus,
+		ErrStatusAlreadySet,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *CreditApplication) ChangeStatus(newStatus ApplicationStatus) error {
 	if a.Status == newStatus {
-		return errors.New("status already set")
+		return ErrStatusAlreadySet
+	}
+
+	switch {
+	case newStatus == CANCELLED:
+		if err := a.checkBeforeDisbursement(); err != nil {
+			return err
+		}
+	default:
+		if err := a.checkTransition(newStatus); err != nil {
+			return err
+		}
+	}
+
+	a.setStatus(newStatus)
+	return nil
+}
+
+func (a *CreditApplication) setStatus(status ApplicationStatus) {
+	a.Status = status
+	a.touch()
+}
+
+// touch records a change watchers must see: they skip versions they have.
+func (a *CreditApplication) touch() {
+	a.Version++
+	a.UpdatedAt = time.Now().UTC()
+}
+
+// Cancel withdraws the application at the customer's request.
+func (a *CreditApplication) Cancel(reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrEmptyCancelReason
+	}
+	if err := a.ChangeStatus(CANCELLED); err != nil {
+		return err
 	}
+	a.CancelReason = sql.NullString{String: reason, Valid: true}
+	return nil
+}
+
+// OwnedBy reports whether userID is the customer who applied.
+func (a *CreditApplication) OwnedBy(userID string) bool {
+	return userID != "" && a.UserID.String() == userID
+}
+
+// Reject is a rejection decided by the service itself rather than by scoring,
+// so it is allowed from every status before disbursement.
+func (a *CreditApplication) Reject(reason string) error {
+	if err := a.checkBeforeDisbursement(); err != nil {
+		return err
+	}
+	a.setStatus(REJECTED)
+	a.RejectReason = sql.NullString{String: reason, Valid: true}
+	return nil
+}
 
+// checkBeforeDisbursement allows cancellation and expiry from every status
+// before the loan is approved, i.e. before disbursement.
+func (a *CreditApplication) checkBeforeDisbursement() error {
+	switch a.Status {
+	case DRAFT, APPLICATION_CREATED, APPLICATION_AGREEMENT_CREATED, SCORING, EMPLOYMENT_CHECK, MANUAL_REVIEW, COUNTER_OFFERED:
+		return nil
+	case CANCELLED:
+		return ErrApplicationCancelled
+	case APPROVED, REJECTED:
+		return ErrTerminalStatus
+	default:
+		return ErrUnknownStatus
+	}
+}
+
+func (a *CreditApplication) checkTransition(newStatus ApplicationStatus) error {
 	switch a.Status {
 	case DRAFT:
-		if newStatus != APPLICATION_CREATED && newStatus != APPLICATION_AGREEMENT_CREATED {
+		if newStatus != APPLICATION_CREATED && newStatus != APPLICATION_AGREEMENT_CREATED && newStatus != MANUAL_REVIEW && newStatus != COUNTER_OFFERED {
 			return ErrInvalidTransitionFromDraft
 		}
 	case APPLICATION_CREATED:
-		if newStatus != APPLICATION_AGREEMENT_CREATED {
+		if newStatus != APPLICATION_AGREEMENT_CREATED && newStatus != MANUAL_REVIEW && newStatus != COUNTER_OFFERED {
 			return ErrInvalidTransitionFromApplicationCreated
 		}
+	case MANUAL_REVIEW:
+		if newStatus != APPLICATION_AGREEMENT_CREATED && newStatus != REJECTED {
+			return ErrInvalidTransitionFromManualReview
+		}
+	case COUNTER_OFFERED:
+		if newStatus != APPLICATION_AGREEMENT_CREATED && newStatus != REJECTED {
+			return ErrInvalidTransitionFromCounterOffered
+		}
 	case APPLICATION_AGREEMENT_CREATED:
 		if newStatus != SCORING {
 			return ErrInvalidTransitionFromAgreementCreated
 		}
 	case SCORING:
-		if newStatus != EMPLOYMENT_CHECK && newStatus != REJECTED && newStatus != APPROVED {
+		if newStatus != EMPLOYMENT_CHECK && newStatus != REJECTED && newStatus != APPROVED && newStatus != COUNTER_OFFERED {
 			return ErrInvalidTransitionFromScoring
 		}
 	case EMPLOYMENT_CHECK:
@@ -152,12 +294,22 @@ func (a *CreditApplication) ChangeStatus(newStatus ApplicationStatus) error {
 		}
 	case APPROVED, REJECTED:
 		return ErrTerminalStatus
+	case CANCELLED:
+		return ErrApplicationCancelled
 	default:
 		return ErrUnknownStatus
 	}
+	return nil
+}
 
-	a.Status = newStatus
-	a.UpdatedAt = time.Now().UTC()
+// ValidateAmounts checks that every monetary amount is expressible in currency.
+// Interest is a rate and is not validated here.
+func ValidateAmounts(currency money.Currency, amounts ...decimal.Decimal) error {
+	for _, amount := range amounts {
+		if err := currency.ValidateAmount(amount); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidAmount, err)
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ExpiredReason is stored as the reject reason of applications that timed out.
+const ExpiredReason = "EXPIRED"
+
+var ErrStatusNotExpirable = errors.New("status cannot expire")
+
+// Expire rejects an application that stayed too long in a status before
+// disbursement, e.g. because a downstream event never arrived.
+func (a *CreditApplication) Expire() error {
+	return a.Reject(ExpiredReason)
+}
+
+// ExpiryPolicy holds per-status TTLs with per-product overrides.
+type ExpiryPolicy struct {
+	defaults map[ApplicationStatus]time.Duration
+	products map[string]map[ApplicationStatus]time.Duration
+}
+
+func NewExpiryPolicy(defaults map[ApplicationStatus]time.Duration, products map[string]map[ApplicationStatus]time.Duration) (*ExpiryPolicy, error) {
+	check := func(ttls map[ApplicationStatus]time.Duration) error {
+		for status := range ttls {
+			probe := CreditApplication{Status: status}
+			if err := probe.checkBeforeDisbursement(); err != nil {
+				return fmt.Errorf("%w: %s", ErrStatusNotExpirable, status)
+			}
+		}
+		return nil
+	}
+	if err := check(defaults); err != nil {
+		return nil, err
+	}
+	for product, ttls := range products {
+		if err := check(ttls); err != nil {
+			return nil, fmt.Errorf("product %s: %w", product, err)
+		}
+	}
+	return &ExpiryPolicy{defaults: defaults, products: products}, nil
+}
+
+// StaleFilter selects applications that stayed in Status since before
+// UpdatedBefore. Empty ProductCodes means every product except
+// ExcludeProductCodes.
+type StaleFilter struct {
+	Status              ApplicationStatus
+	UpdatedBefore       time.Time
+	ProductCodes        []string
+	ExcludeProductCodes []string
+}
+
+// StaleFilters returns one filter per product override and one per status
+// default that covers the remaining products.
+func (p *ExpiryPolicy) StaleFilters(now time.Time) []StaleFilter {
+	var filters []StaleFilter
+	overridden := make(map[ApplicationStatus][]string)
+
+	for _, product := range sortedKeys(p.products) {
+		for status, ttl := range p.products[product] {
+			filters = append(filters, StaleFilter{
+				Status:        status,
+				UpdatedBefore: now.Add(-ttl),
+				ProductCodes:  []string{product},
+			})
+			overridden[status] = append(overridden[status], product)
+		}
+	}
+	for status, ttl := range p.defaults {
+		filters = append(filters, StaleFilter{
+			Status:              status,
+			UpdatedBefore:       now.Add(-ttl),
+			ExcludeProductCodes: overridden[status],
+		})
+	}
+	return filters
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrStaleFencingToken = errors.New("fencing token is older than an accepted one")
+
+// Locker runs work on at most one replica at a time.
+type Locker interface {
+	// TryRun calls fn while holding the lock and reports whether the lock was
+	// free; it does not wait for another holder.
+	TryRun(ctx context.Context, fn func(ctx context.Context) error) (bool, error)
+}
+
+// Fence rejects writes of a singleton job from a leader that has already
+// been replaced, e.g. after a GC pause or a partition outlived its lease.
+type Fence interface {
+	// Check accepts token unless a greater one was accepted for name, and
+	// holds it until the transaction of ctx ends, so writes of a newer leader
+	// wait for the check. A stale token gives ErrStaleFencingToken.
+	Check(ctx context.Context, name string, token int64) error
+}
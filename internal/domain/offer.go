@@ -0,0 +1,190 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Andronzi/credit-origination/pkg/money"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type OfferKind string
+
+const (
+	OfferLowerAmount OfferKind = "LOWER_AMOUNT"
+	OfferLongerTerm  OfferKind = "LONGER_TERM"
+	OfferLowerRate   OfferKind = "LOWER_RATE"
+)
+
+var (
+	ErrOfferNotFound      = errors.New("offer not found")
+	ErrOfferNotAcceptable = errors.New("offer cannot be accepted")
+)
+
+// CounterOffer is an alternative to the declined terms of an application.
+type CounterOffer struct {
+	ID                 uuid.UUID       `gorm:"type:uuid;primaryKey" json:"id"`
+	ApplicationID      uuid.UUID       `gorm:"type:uuid;index;not null" json:"application_id"`
+	Kind               OfferKind       `gorm:"type:varchar(20);not null" json:"kind" example:"LONGER_TERM"`
+	DisbursementAmount decimal.Decimal `gorm:"type:decimal(15,2)" json:"disbursement_amount" example:"100000.00"`
+	OriginationAmount  decimal.Decimal `gorm:"type:decimal(15,2)" json:"origination_amount" example:"100000.00"`
+	Term               uint32          `gorm:"type:int" json:"term" example:"24"`
+	Interest           decimal.Decimal `gorm:"type:decimal(15,2)" json:"interest" example:"15.50"`
+	MonthlyPayment     decimal.Decimal `gorm:"type:decimal(15,2)" json:"monthly_payment" example:"4872.33"`
+	DTI                decimal.Decimal `gorm:"column:dti;type:numeric" json:"dti" example:"0.4512"`
+	AcceptedAt         sql.NullTime    `gorm:"type:timestamp" json:"accepted_at"`
+	CreatedAt          time.Time       `gorm:"type:timestamp" json:"created_at"`
+}
+
+type OfferRepository interface {
+	CreateAll(ctx context.Context, offers []*CounterOffer) error
+	FindByID(ctx context.Context, id string) (*CounterOffer, error)
+	// ListByApplication returns the offers of an application, oldest first.
+	ListByApplication(ctx context.Context, applicationID string) ([]*CounterOffer, error)
+	MarkAccepted(ctx context.Context, offer *CounterOffer) error
+}
+
+// OfferCounter parks an application that failed on its requested terms
+// until the applicant accepts one of the counter-offers.
+func (a *CreditApplication) OfferCounter() error {
+	return a.ChangeStatus(COUNTER_OFFERED)
+}
+
+// AcceptOffer replaces the requested terms with the offer's and moves the
+// application on as if it had passed the checks with them.
+func (a *CreditApplication) AcceptOffer(offer *CounterOffer, now time.Time) error {
+	if a.Status != COUNTER_OFFERED {
+		return fmt.Errorf("%w: application is %s", ErrOfferNotAcceptable, a.Status)
+	}
+	if offer.ApplicationID != a.ID || offer.AcceptedAt.Valid {
+		return ErrOfferNotAcceptable
+	}
+	if err := a.ChangeStatus(APPLICATION_AGREEMENT_CREATED); err != nil {
+		return err
+	}
+
+	a.DisbursementAmount = offer.DisbursementAmount
+	a.OriginationAmount = offer.OriginationAmount
+	a.Term = offer.Term
+	a.Interest = offer.Interest
+	a.MonthlyPayment = decimal.NewNullDecimal(offer.MonthlyPayment)
+	a.DTI = decimal.NewNullDecimal(offer.DTI)
+	a.AffordabilityDecision = AffordabilityPass
+	offer.AcceptedAt = sql.NullTime{Time: now, Valid: true}
+	return nil
+}
+
+// CounterOfferGenerator builds offers within the product catalogue limits
+// that pass the affordability check.
+type CounterOfferGenerator struct {
+	catalogue     *ProductCatalogue
+	affordability *AffordabilityPolicy
+}
+
+func NewCounterOfferGenerator(catalogue *ProductCatalogue, affordability *AffordabilityPolicy) *CounterOfferGenerator {
+	return &CounterOfferGenerator{catalogue: catalogue, affordability: affordability}
+}
+
+// Generate returns at most one offer of each kind: the requested term with the
+// largest affordable amount, the requested amount with the shortest
+// affordable longer term, and the requested amount and term at the product's
+// lowest rate. Products missing from the catalogue get no offers. A non-nil
+// passes is one more check every offer must pass, e.g. the scorecard.
+func (g *CounterOfferGenerator) Generate(app *CreditApplication, now time.Time, passes func(candidate *CreditApplication) bool) []*CounterOffer {
+	limits, ok := g.catalogue.Limits(app.ProductCode)
+	if !ok || !app.HasFinancials() {
+		return nil
+	}
+
+	var offers []*CounterOffer
+	add := func(kind OfferKind, amount decimal.Decimal, term uint32, rate decimal.Decimal) bool {
+		offer := g.offer(app, kind, amount, term, rate, now, passes)
+		if offer == nil {
+			return false
+		}
+		offers = append(offers, offer)
+		return true
+	}
+
+	maxPayment := g.affordability.MaxDTI(app).Mul(app.MonthlyIncome.Decimal).Sub(app.MonthlyObligations.Decimal)
+	if maxPayment.IsPositive() {
+		amount := AnnuityPrincipal(maxPayment, app.Interest, app.Term).Truncate(0)
+		if amount.LessThan(app.DisbursementAmount) && !amount.LessThan(limits.MinAmount) {
+			add(OfferLowerAmount, amount, app.Term, app.Interest)
+		}
+	}
+
+	for term := max(app.Term+1, limits.MinTerm); term <= limits.MaxTerm; term++ {
+		if add(OfferLongerTerm, app.DisbursementAmount, term, app.Interest) {
+			break
+		}
+	}
+
+	if limits.MinRate.IsPositive() && limits.MinRate.LessThan(app.Interest) {
+		add(OfferLowerRate, app.DisbursementAmount, app.Term, limits.MinRate)
+	}
+
+	return offers
+}
+
+// offer returns nil when the terms still fail the affordability check or passes.
+func (g *CounterOfferGenerator) offer(app *CreditApplication, kind OfferKind, amount decimal.Decimal, term uint32, rate decimal.Decimal, now time.Time, passes func(*CreditApplication) bool) *CounterOffer {
+	// Сумма к выдаче уменьшается в той же пропорции, что и сумма кредита,
+	// с округлением вниз до минимальной единицы валюты заявки.
+	origination := app.OriginationAmount
+	if !amount.Equal(app.DisbursementAmount) {
+		currency, err := money.ParseCurrency(app.Currency)
+		if err != nil {
+			return nil
+		}
+		origination = app.OriginationAmount.Mul(amount).Div(app.DisbursementAmount).Truncate(currency.MinorUnits)
+	}
+
+	candidate := *app
+	candidate.DisbursementAmount = amount
+	candidate.OriginationAmount = origination
+	candidate.Term = term
+	candidate.Interest = rate
+	assessment, err := g.affordability.Assess(&candidate)
+	if err != nil || assessment.Decision != AffordabilityPass {
+		return nil
+	}
+	if passes != nil && !passes(&candidate) {
+		return nil
+	}
+
+	return &CounterOffer{
+		ID:                 uuid.New(),
+		ApplicationID:      app.ID,
+		Kind:               kind,
+		DisbursementAmount: amount,
+		OriginationAmount:  origination,
+		Term:               term,
+		Interest:           rate,
+		MonthlyPayment:     assessment.MonthlyPayment,
+		DTI:                assessment.DTI,
+		CreatedAt:          now,
+	}
+}
+
+// AnnuityPrincipal is the inverse of MonthlyPayment: the largest principal
+// whose annuity payment at an annual rate in percent over term months does
+// not exceed payment.
+func AnnuityPrincipal(payment, annualRate decimal.Decimal, term uint32) decimal.Decimal {
+	if term == 0 {
+		return decimal.Zero
+	}
+	months := decimal.NewFromInt(int64(term))
+	rate := annualRate.DivRound(decimal.NewFromInt(1200), 16)
+	if rate.IsZero() {
+		return payment.Mul(months)
+	}
+	// p * (1 - (1 + r)^-n) / r
+	growth := decimal.NewFromInt(1).Add(rate).Pow(months)
+	discount := decimal.NewFromInt(1).Sub(decimal.NewFromInt(1).DivRound(growth, 16))
+	return payment.Mul(discount).DivRound(rate, 2)
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func testGenerator() *CounterOfferGenerator {
+	catalogue := NewProductCatalogue(map[string]ProductLimits{
+		"code-1": {
+			MinAmount: decimal.NewFromInt(1000),
+			MaxAmount: decimal.NewFromInt(100000000),
+			MinTerm:   6,
+			MaxTerm:   24,
+			MinRate:   decimal.NewFromInt(10),
+			MaxRate:   decimal.NewFromInt(30),
+		},
+	})
+	affordability := NewAffordabilityPolicy(decimal.RequireFromString("0.5"), nil, decimal.Zero)
+	return NewCounterOfferGenerator(catalogue, affordability)
+}
+
+func testOfferApplication(currency string, amount, origination string) *CreditApplication {
+	return &CreditApplication{
+		ID:                 uuid.New(),
+		UserID:             uuid.New(),
+		DisbursementAmount: decimal.RequireFromString(amount),
+		OriginationAmount:  decimal.RequireFromString(origination),
+		Term:               12,
+		Interest:           decimal.NewFromInt(10),
+		ProductCode:        "code-1",
+		Currency:           currency,
+		Status:             APPLICATION_CREATED,
+		MonthlyIncome:      decimal.NewNullDecimal(decimal.NewFromInt(300000)),
+		MonthlyObligations: decimal.NewNullDecimal(decimal.Zero),
+		Dependants:         sql.NullInt32{Valid: true},
+	}
+}
+
+func TestLowerAmountOfferRoundsOriginationToMinorUnits(t *testing.T) {
+	tests := []struct {
+		currency   string
+		minorUnits int32
+	}{
+		{"JPY", 0},
+		{"RUB", 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.currency, func(t *testing.T) {
+			app := testOfferApplication(tt.currency, "5000000", "4900001")
+			var lower *CounterOffer
+			for _, offer := range testGenerator().Generate(app, time.Now(), nil) {
+				if offer.Kind == OfferLowerAmount {
+					lower = offer
+				}
+			}
+			if lower == nil {
+				t.Fatal("no lower amount offer")
+			}
+			if !lower.OriginationAmount.Equal(lower.OriginationAmount.Truncate(tt.minorUnits)) {
+				t.Fatalf("origination %s has more than %d fractional digits", lower.OriginationAmount, tt.minorUnits)
+			}
+			if lower.OriginationAmount.GreaterThan(lower.DisbursementAmount) {
+				t.Fatalf("origination %s exceeds amount %s", lower.OriginationAmount, lower.DisbursementAmount)
+			}
+		})
+	}
+}
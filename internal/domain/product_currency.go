@@ -0,0 +1,21 @@
+package domain
+
+import "github.com/Andronzi/credit-origination/pkg/money"
+
+// ProductCurrencies maps product codes to the currency the product is issued
+// in. Products without an explicit entry use the fallback currency.
+type ProductCurrencies struct {
+	fallback money.Currency
+	products map[string]money.Currency
+}
+
+func NewProductCurrencies(fallback money.Currency, products map[string]money.Currency) *ProductCurrencies {
+	return &ProductCurrencies{fallback: fallback, products: products}
+}
+
+func (p *ProductCurrencies) CurrencyOf(productCode string) money.Currency {
+	if c, ok := p.products[productCode]; ok {
+		return c
+	}
+	return p.fallback
+}
@@ -2,14 +2,49 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"time"
 )
 
+var (
+	ErrApplicationNotFound = errors.New("application not found")
+	ErrApplicationConflict = errors.New("application was changed concurrently")
+)
+
+// ApplicationFilter selects applications for export; zero fields match everything.
+type ApplicationFilter struct {
+	Statuses    []ApplicationStatus
+	UserID      string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
 type CreditRepository interface {
 	FindByID(ctx context.Context, id string) (*CreditApplication, error)
+	FindByIDs(ctx context.Context, ids []string) ([]*CreditApplication, error)
 	FindByUserID(ctx context.Context, userID string) (*CreditApplication, error)
 	List(ctx context.Context, statuses []ApplicationStatus, offset int, limit int, userID string) ([]*CreditApplication, int, error)
+	ListCreatedBetween(ctx context.Context, from time.Time, to time.Time, offset int, limit int) ([]*CreditApplication, error)
+	// ListByUserOrBankAccount returns up to limit applications created since
+	// since by the user or paying out to the bank account, newest first.
+	ListByUserOrBankAccount(ctx context.Context, userID string, bankAccountID string, since time.Time, limit int) ([]*CreditApplication, error)
+	// ListStale returns up to limit matching applications, oldest update first.
+	ListStale(ctx context.Context, filter StaleFilter, limit int) ([]*CreditApplication, error)
+	// Export calls fn with consecutive batches of matching applications,
+	// holding at most one batch in memory.
+	Export(ctx context.Context, filter ApplicationFilter, batchSize int, fn func([]*CreditApplication) error) error
 	Save(ctx context.Context, app *CreditApplication) error
+	// SaveAll and UpdateAll write all applications in one transaction.
+	SaveAll(ctx context.Context, apps []*CreditApplication) error
+	// Update writes the non-zero fields of app, so a cleared pending_status
+	// is reset with ClearPendingStatus.
 	Update(ctx context.Context, app *CreditApplication) error
+	// UpdateIfCurrent is Update for an application read in expectedStatus
+	// with expectedVersion: if another write changed either since, nothing is
+	// written and ErrApplicationConflict is returned.
+	UpdateIfCurrent(ctx context.Context, app *CreditApplication, expectedStatus ApplicationStatus, expectedVersion int64) error
+	ClearPendingStatus(ctx context.Context, id string) error
+	UpdateAll(ctx context.Context, apps []*CreditApplication) error
 	UpdateStatus(ctx context.Context, id string, status ApplicationStatus) error
 	Delete(ctx context.Context, id string) error
 }
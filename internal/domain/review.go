@@ -0,0 +1,230 @@
+package domain
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+type ReviewState string
+
+const (
+	// REVIEW_OPEN — в очереди, никем не взята.
+	REVIEW_OPEN    ReviewState = "OPEN"
+	REVIEW_CLAIMED ReviewState = "CLAIMED"
+	REVIEW_DECIDED ReviewState = "DECIDED"
+)
+
+type ReviewDecision string
+
+const (
+	ReviewApproved ReviewDecision = "APPROVED"
+	ReviewRejected ReviewDecision = "REJECTED"
+)
+
+var (
+	ErrReviewNotFound       = errors.New("review not found")
+	ErrReviewConflict       = errors.New("review was changed concurrently")
+	ErrReviewAlreadyClaimed = errors.New("review is claimed by another underwriter")
+	ErrReviewNotClaimed     = errors.New("review must be claimed by the underwriter first")
+	ErrReviewDecided        = errors.New("review is already decided")
+	ErrSameApprover         = errors.New("second approval must come from another underwriter")
+	ErrEmptyUnderwriter     = errors.New("underwriter is required")
+	ErrEmptyReviewReason    = errors.New("reason is required")
+)
+
+// Review is the manual underwriting of one application in MANUAL_REVIEW.
+// Version guards concurrent claims and decisions.
+type Review struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey" json:"id"`
+	ApplicationID uuid.UUID      `gorm:"type:uuid;uniqueIndex" json:"application_id"`
+	State         ReviewState    `gorm:"type:varchar(20);index" json:"state"`
+	Assignee      sql.NullString `gorm:"type:varchar(255)" json:"assignee"`
+	ClaimedAt     sql.NullTime   `gorm:"type:timestamp" json:"claimed_at"`
+	// FirstApprover is set while a four-eyes approval waits for the second underwriter.
+	FirstApprover  sql.NullString     `gorm:"type:varchar(255)" json:"first_approver"`
+	Decision       sql.NullString     `gorm:"type:varchar(20)" json:"decision"`
+	DecisionReason sql.NullString     `gorm:"type:text" json:"decision_reason"`
+	DecidedBy      sql.NullString     `gorm:"type:varchar(255)" json:"decided_by"`
+	QueuedAt       time.Time          `gorm:"type:timestamp;index" json:"queued_at"`
+	DecidedAt      sql.NullTime       `gorm:"type:timestamp" json:"decided_at"`
+	Version        int64              `gorm:"type:bigint;not null;default:1" json:"version"`
+	Notes          []ReviewNote       `gorm:"foreignKey:ReviewID" json:"notes"`
+	Attachments    []ReviewAttachment `gorm:"foreignKey:ReviewID" json:"attachments"`
+	Application    *CreditApplication `gorm:"foreignKey:ApplicationID" json:"-"`
+}
+
+type ReviewNote struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ReviewID  uuid.UUID `gorm:"type:uuid;index" json:"review_id"`
+	Author    string    `gorm:"type:varchar(255)" json:"author"`
+	Text      string    `gorm:"type:text" json:"text"`
+	CreatedAt time.Time `gorm:"type:timestamp" json:"created_at"`
+}
+
+// ReviewAttachment references a document kept in external storage.
+type ReviewAttachment struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey" json:"id"`
+	ReviewID    uuid.UUID `gorm:"type:uuid;index" json:"review_id"`
+	Author      string    `gorm:"type:varchar(255)" json:"author"`
+	FileName    string    `gorm:"type:varchar(255)" json:"file_name"`
+	ContentType string    `gorm:"type:varchar(255)" json:"content_type"`
+	URL         string    `gorm:"type:text" json:"url"`
+	CreatedAt   time.Time `gorm:"type:timestamp" json:"created_at"`
+}
+
+func NewReview(applicationID uuid.UUID, now time.Time) *Review {
+	return &Review{
+		ID:            uuid.New(),
+		ApplicationID: applicationID,
+		State:         REVIEW_OPEN,
+		QueuedAt:      now,
+		Version:       1,
+	}
+}
+
+func (r *Review) Claim(underwriter string, now time.Time) error {
+	if err := validateUnderwriter(underwriter); err != nil {
+		return err
+	}
+	switch r.State {
+	case REVIEW_DECIDED:
+		return ErrReviewDecided
+	case REVIEW_CLAIMED:
+		if r.Assignee.String == underwriter {
+			return nil
+		}
+		return ErrReviewAlreadyClaimed
+	}
+	if r.FirstApprover.String == underwriter {
+		return ErrSameApprover
+	}
+	r.State = REVIEW_CLAIMED
+	r.Assignee = sql.NullString{String: underwriter, Valid: true}
+	r.ClaimedAt = sql.NullTime{Time: now, Valid: true}
+	return nil
+}
+
+// Release puts a claimed review back in the queue.
+func (r *Review) Release(underwriter string) error {
+	if err := r.checkAssignee(underwriter); err != nil {
+		return err
+	}
+	r.unassign()
+	return nil
+}
+
+// Approve records the underwriter's approval. With fourEyes the first
+// approval returns the review to the queue for a second underwriter, and
+// final is false until that second approval.
+func (r *Review) Approve(underwriter string, fourEyes bool, now time.Time) (final bool, err error) {
+	if err := r.checkAssignee(underwriter); err != nil {
+		return false, err
+	}
+	if fourEyes && !r.FirstApprover.Valid {
+		r.FirstApprover = sql.NullString{String: underwriter, Valid: true}
+		r.unassign()
+		return false, nil
+	}
+	r.decide(underwriter, ReviewApproved, "", now)
+	return true, nil
+}
+
+func (r *Review) Reject(underwriter string, reason string, now time.Time) error {
+	if err := r.checkAssignee(underwriter); err != nil {
+		return err
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrEmptyReviewReason
+	}
+	r.decide(underwriter, ReviewRejected, reason, now)
+	return nil
+}
+
+// TimeInQueue is how long the application has waited for a decision.
+func (r *Review) TimeInQueue(now time.Time) time.Duration {
+	if r.DecidedAt.Valid {
+		return r.DecidedAt.Time.Sub(r.QueuedAt)
+	}
+	return now.Sub(r.QueuedAt)
+}
+
+func (r *Review) checkAssignee(underwriter string) error {
+	if err := validateUnderwriter(underwriter); err != nil {
+		return err
+	}
+	if r.State == REVIEW_DECIDED {
+		return ErrReviewDecided
+	}
+	if r.State != REVIEW_CLAIMED || r.Assignee.String != underwriter {
+		return ErrReviewNotClaimed
+	}
+	return nil
+}
+
+func (r *Review) unassign() {
+	r.State = REVIEW_OPEN
+	r.Assignee = sql.NullString{}
+	r.ClaimedAt = sql.NullTime{}
+}
+
+func (r *Review) decide(underwriter string, decision ReviewDecision, reason string, now time.Time) {
+	r.State = REVIEW_DECIDED
+	r.Decision = sql.NullString{String: string(decision), Valid: true}
+	r.DecisionReason = sql.NullString{String: reason, Valid: reason != ""}
+	r.DecidedBy = sql.NullString{String: underwriter, Valid: true}
+	r.DecidedAt = sql.NullTime{Time: now, Valid: true}
+}
+
+func validateUnderwriter(underwriter string) error {
+	if strings.TrimSpace(underwriter) == "" {
+		return ErrEmptyUnderwriter
+	}
+	return nil
+}
+
+// ReviewQueueFilter selects open and claimed reviews; zero fields match everything.
+type ReviewQueueFilter struct {
+	States       []ReviewState
+	Assignee     string
+	ProductCode  string
+	QueuedBefore time.Time
+}
+
+type ReviewRepository interface {
+	Create(ctx context.Context, review *Review) error
+	// FindByID loads the review with its notes and attachments.
+	FindByID(ctx context.Context, id string) (*Review, error)
+	// ListQueue returns undecided reviews of applications still in
+	// MANUAL_REVIEW, oldest first, and their total count.
+	ListQueue(ctx context.Context, filter ReviewQueueFilter, offset int, limit int) ([]*Review, int, error)
+	// Update saves the review if it still has expectedVersion and bumps the
+	// version, otherwise returns ErrReviewConflict.
+	Update(ctx context.Context, review *Review, expectedVersion int64) error
+	AddNote(ctx context.Context, note *ReviewNote) error
+	AddAttachment(ctx context.Context, attachment *ReviewAttachment) error
+}
+
+// FourEyesPolicy decides which approvals need a second underwriter.
+type FourEyesPolicy struct {
+	fallback   decimal.Decimal
+	currencies map[string]decimal.Decimal
+}
+
+func NewFourEyesPolicy(fallback decimal.Decimal, currencies map[string]decimal.Decimal) *FourEyesPolicy {
+	return &FourEyesPolicy{fallback: fallback, currencies: currencies}
+}
+
+func (p *FourEyesPolicy) Requires(app *CreditApplication) bool {
+	threshold, ok := p.currencies[app.Currency]
+	if !ok {
+		threshold = p.fallback
+	}
+	return app.DisbursementAmount.GreaterThan(threshold)
+}
@@ -0,0 +1,78 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// FraudSuspectedReason is stored as the reject reason of applications
+// rejected by the antifraud check.
+const FraudSuspectedReason = "FRAUD_SUSPECTED"
+
+type RiskDecision string
+
+const (
+	RiskPass   RiskDecision = "PASS"
+	RiskReview RiskDecision = "REVIEW"
+	RiskReject RiskDecision = "REJECT"
+)
+
+// RiskFlag is one antifraud rule that fired for an application.
+type RiskFlag struct {
+	Rule   string `json:"rule"`
+	Score  int    `json:"score"`
+	Detail string `json:"detail"`
+}
+
+type RiskFlags []RiskFlag
+
+func (f RiskFlags) Value() (driver.Value, error) {
+	if f == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(f)
+	return string(data), err
+}
+
+func (f *RiskFlags) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*f = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, f)
+	case string:
+		return json.Unmarshal([]byte(v), f)
+	default:
+		return fmt.Errorf("cannot scan %T into RiskFlags", value)
+	}
+}
+
+// RiskAssessment is the antifraud verdict for an application.
+type RiskAssessment struct {
+	Score    int
+	Flags    RiskFlags
+	Decision RiskDecision
+}
+
+// ApplyRisk stores the assessment on the application; a REJECT decision also
+// rejects it and a REVIEW decision sends it to manual review.
+func (a *CreditApplication) ApplyRisk(assessment RiskAssessment) error {
+	a.RiskScore = assessment.Score
+	a.RiskFlags = assessment.Flags
+	a.RiskDecision = assessment.Decision
+	switch assessment.Decision {
+	case RiskReject:
+		return a.Reject(FraudSuspectedReason)
+	case RiskReview:
+		return a.ChangeStatus(MANUAL_REVIEW)
+	}
+	return nil
+}
+
+// PassedRiskCheck reports whether the application may proceed; applications
+// created with antifraud disabled have no decision and pass.
+func (a *CreditApplication) PassedRiskCheck() bool {
+	return a.RiskDecision == "" || a.RiskDecision == RiskPass
+}
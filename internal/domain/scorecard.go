@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// LowCreditScoreReason is stored as the reject reason of applications scored
+// below the cutoff.
+const LowCreditScoreReason = "LOW_CREDIT_SCORE"
+
+const (
+	minCreditScore = 300
+	maxCreditScore = 850
+)
+
+// Scorecard turns a bureau report into a credit score.
+type Scorecard struct {
+	// Base is the score of an applicant with a clean history.
+	Base int
+	// Cutoff is the lowest passing score.
+	Cutoff int
+	// RecentInquiries inquiries within InquiryWindow are free, each further
+	// one costs points.
+	RecentInquiries int
+	InquiryWindow   time.Duration
+}
+
+// ScoreResult is the scorecard outcome with the characteristics that cost
+// points.
+type ScoreResult struct {
+	Score   int
+	Passed  bool
+	Reasons []string
+}
+
+func (s Scorecard) Score(app *CreditApplication, data BureauData, now time.Time) ScoreResult {
+	result := ScoreResult{Score: s.Base}
+	deduct := func(points int, reason string) {
+		result.Score -= points
+		result.Reasons = append(result.Reasons, reason)
+	}
+
+	for _, delinquency := range data.Delinquencies {
+		switch {
+		case delinquency.DaysPastDue >= 90:
+			deduct(120, "DELINQUENCY_90_PLUS")
+		case delinquency.DaysPastDue >= 30:
+			deduct(60, "DELINQUENCY_30_PLUS")
+		case delinquency.DaysPastDue > 0:
+			deduct(30, "DELINQUENCY")
+		}
+	}
+
+	recent := 0
+	for _, inquiry := range data.Inquiries {
+		if now.Sub(inquiry.InquiredAt) <= s.InquiryWindow {
+			recent++
+		}
+	}
+	for i := s.RecentInquiries; i < recent; i++ {
+		deduct(15, "RECENT_INQUIRIES")
+	}
+
+	// Платежи по чужим кредитам вместе с новым платежом относительно дохода.
+	if app.HasFinancials() {
+		payments := MonthlyPayment(app.DisbursementAmount, app.Interest, app.Term)
+		for _, loan := range data.Loans {
+			if loan.Currency == "" || loan.Currency == app.Currency {
+				payments = payments.Add(loan.MonthlyPayment)
+			}
+		}
+		if payments.DivRound(app.MonthlyIncome.Decimal, 4).GreaterThan(decimal.RequireFromString("0.6")) {
+			deduct(50, "HIGH_BUREAU_DEBT_LOAD")
+		}
+	}
+
+	result.Score = min(max(result.Score, minCreditScore), maxCreditScore)
+	result.Passed = result.Score >= s.Cutoff
+	return result
+}
+
+// ApplyScore stores the score on the application. Below the cutoff it is
+// counter-offered when there are offers and rejected otherwise.
+func (a *CreditApplication) ApplyScore(result ScoreResult, offers []*CounterOffer) error {
+	a.CreditScore = sql.NullInt32{Int32: int32(result.Score), Valid: true}
+	if result.Passed {
+		return nil
+	}
+	if len(offers) > 0 {
+		return a.OfferCounter()
+	}
+	return a.Reject(LowCreditScoreReason)
+}
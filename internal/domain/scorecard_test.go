@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Заявка проходит DTI, но вместе с кредитами из бюро нагрузка выше 0.6.
+func scoringFailure() (*CreditApplication, Scorecard, BureauData) {
+	app := testOfferApplication("RUB", "1000000", "1000000")
+	app.Status = SCORING
+	scorecard := Scorecard{Base: 700, Cutoff: 660, InquiryWindow: 30 * 24 * time.Hour}
+	data := BureauData{Loans: []BureauLoan{{Lender: "other", MonthlyPayment: decimal.NewFromInt(100000), Currency: "RUB"}}}
+	return app, scorecard, data
+}
+
+func TestGenerateKeepsOffersThatPassTheScorecard(t *testing.T) {
+	app, scorecard, data := scoringFailure()
+	now := time.Now()
+	if scorecard.Score(app, data, now).Passed {
+		t.Fatal("requested terms must fail the scorecard")
+	}
+
+	passes := func(candidate *CreditApplication) bool {
+		return scorecard.Score(candidate, data, now).Passed
+	}
+	offers := testGenerator().Generate(app, now, passes)
+	if len(offers) == 0 {
+		t.Fatal("no offers for a debt load failure a longer term fixes")
+	}
+	for _, offer := range offers {
+		candidate := *app
+		candidate.DisbursementAmount = offer.DisbursementAmount
+		candidate.Term = offer.Term
+		candidate.Interest = offer.Interest
+		if !passes(&candidate) {
+			t.Errorf("%s offer %s for %d months fails the scorecard", offer.Kind, offer.DisbursementAmount, offer.Term)
+		}
+	}
+}
+
+func TestApplyScoreCounterOffersOrRejects(t *testing.T) {
+	failed := ScoreResult{Score: 650, Passed: false}
+
+	app, _, _ := scoringFailure()
+	if err := app.ApplyScore(failed, []*CounterOffer{{Kind: OfferLongerTerm}}); err != nil {
+		t.Fatal(err)
+	}
+	if app.Status != COUNTER_OFFERED {
+		t.Fatalf("status = %s, want COUNTER_OFFERED", app.Status)
+	}
+
+	app, _, _ = scoringFailure()
+	if err := app.ApplyScore(failed, nil); err != nil {
+		t.Fatal(err)
+	}
+	if app.Status != REJECTED || app.RejectReason.String != LowCreditScoreReason {
+		t.Fatalf("status = %s reason = %q, want REJECTED %s", app.Status, app.RejectReason.String, LowCreditScoreReason)
+	}
+
+	app, _, _ = scoringFailure()
+	if err := app.ApplyScore(ScoreResult{Score: 700, Passed: true}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if app.Status != SCORING || !app.CreditScore.Valid {
+		t.Fatalf("status = %s score = %v, a passing score must only be stored", app.Status, app.CreditScore)
+	}
+}
@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusChange describes a committed status transition of an application.
+type StatusChange struct {
+	ApplicationID uuid.UUID         `json:"application_id"`
+	UserID        uuid.UUID         `json:"user_id"`
+	Status        ApplicationStatus `json:"status"`
+	Version       int64             `json:"version"`
+	ChangedAt     time.Time         `json:"changed_at"`
+}
+
+// StatusNotifier fans status changes out to watchers in this and other replicas.
+type StatusNotifier interface {
+	NotifyStatusChange(ctx context.Context, change StatusChange) error
+}
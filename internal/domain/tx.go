@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// Transactor makes several repository writes atomic.
+type Transactor interface {
+	// WithinTx calls fn in one transaction: repositories called with the ctx
+	// passed to fn join it, an error from fn rolls everything back. Nested
+	// calls join the outer transaction.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
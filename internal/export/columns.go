@@ -0,0 +1,118 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+var ErrUnknownColumn = errors.New("unknown export column")
+
+type columnKind int
+
+const (
+	kindString columnKind = iota
+	kindNullableString
+	kindDecimal
+	kindInt
+	kindTimestamp
+)
+
+// Column is one exported field. value returns string, *string (nil is NULL),
+// decimal.Decimal, int64 or time.Time depending on kind.
+type Column struct {
+	Name  string
+	kind  columnKind
+	value func(app *domain.CreditApplication) interface{}
+}
+
+var allColumns = []Column{
+	{"id", kindString, func(a *domain.CreditApplication) interface{} { return a.ID.String() }},
+	{"user_id", kindString, func(a *domain.CreditApplication) interface{} { return a.UserID.String() }},
+	{"status", kindString, func(a *domain.CreditApplication) interface{} { return string(a.Status) }},
+	{"disbursement_amount", kindDecimal, func(a *domain.CreditApplication) interface{} { return a.DisbursementAmount }},
+	{"origination_amount", kindDecimal, func(a *domain.CreditApplication) interface{} { return a.OriginationAmount }},
+	{"currency", kindString, func(a *domain.CreditApplication) interface{} { return a.Currency }},
+	{"interest", kindDecimal, func(a *domain.CreditApplication) interface{} { return a.Interest }},
+	{"term", kindInt, func(a *domain.CreditApplication) interface{} { return int64(a.Term) }},
+	{"to_bank_account_id", kindString, func(a *domain.CreditApplication) interface{} { return a.ToBankAccountID.String() }},
+	{"product_code", kindString, func(a *domain.CreditApplication) interface{} { return a.ProductCode }},
+	{"product_version", kindString, func(a *domain.CreditApplication) interface{} { return a.ProductVersion }},
+	{"reject_reason", kindNullableString, func(a *domain.CreditApplication) interface{} {
+		if !a.RejectReason.Valid {
+			return (*string)(nil)
+		}
+		return &a.RejectReason.String
+	}},
+	{"cancel_reason", kindNullableString, func(a *domain.CreditApplication) interface{} {
+		if !a.CancelReason.Valid {
+			return (*string)(nil)
+		}
+		return &a.CancelReason.String
+	}},
+	{"risk_score", kindInt, func(a *domain.CreditApplication) interface{} { return int64(a.RiskScore) }},
+	{"risk_decision", kindString, func(a *domain.CreditApplication) interface{} { return string(a.RiskDecision) }},
+	{"version", kindInt, func(a *domain.CreditApplication) interface{} { return a.Version }},
+	{"created_at", kindTimestamp, func(a *domain.CreditApplication) interface{} { return a.CreatedAt }},
+	{"updated_at", kindTimestamp, func(a *domain.CreditApplication) interface{} { return a.UpdatedAt }},
+}
+
+// ColumnNames lists every exportable column in default order.
+func ColumnNames() []string {
+	names := make([]string, len(allColumns))
+	for i, c := range allColumns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+// Columns resolves names in the given order; no names means all columns.
+func Columns(names []string) ([]Column, error) {
+	if len(names) == 0 {
+		return allColumns, nil
+	}
+
+	columns := make([]Column, 0, len(names))
+	for _, name := range names {
+		column, ok := lookupColumn(strings.TrimSpace(name))
+		if !ok {
+			return nil, fmt.Errorf("%w: %q, known columns: %s", ErrUnknownColumn, name, strings.Join(ColumnNames(), ", "))
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+func lookupColumn(name string) (Column, bool) {
+	for _, c := range allColumns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Column{}, false
+}
+
+// formatText renders a value for text formats. Amounts keep exactly two
+// fractional digits and never pass through float64.
+func formatText(kind columnKind, v interface{}) (string, bool) {
+	switch kind {
+	case kindNullableString:
+		s := v.(*string)
+		if s == nil {
+			return "", false
+		}
+		return *s, true
+	case kindDecimal:
+		return v.(decimal.Decimal).StringFixed(amountScale), true
+	case kindInt:
+		return fmt.Sprint(v.(int64)), true
+	case kindTimestamp:
+		return v.(time.Time).UTC().Format(time.RFC3339Nano), true
+	default:
+		return v.(string), true
+	}
+}
@@ -0,0 +1,195 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/pkg/money"
+	"github.com/shopspring/decimal"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatJSONL   Format = "jsonl"
+	FormatParquet Format = "parquet"
+)
+
+const (
+	amountScale = money.MaxScale
+	// parquetRowGroupSize bounds the rows buffered before a row group is flushed.
+	parquetRowGroupSize = 8 * 1024 * 1024
+)
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case FormatCSV, FormatJSONL, FormatParquet:
+		return f, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownFormat, s)
+}
+
+// Writer encodes applications one by one; Close flushes what is buffered and
+// writes the trailer of the format, if any.
+type Writer interface {
+	Write(app *domain.CreditApplication) error
+	Close() error
+}
+
+func NewWriter(format Format, columns []Column, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(columns, w)
+	case FormatJSONL:
+		return newJSONLWriter(columns, w), nil
+	case FormatParquet:
+		return newParquetWriter(columns, w)
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+type csvWriter struct {
+	columns []Column
+	w       *csv.Writer
+	record  []string
+}
+
+func newCSVWriter(columns []Column, w io.Writer) (*csvWriter, error) {
+	cw := &csvWriter{columns: columns, w: csv.NewWriter(w), record: make([]string, len(columns))}
+	for i, c := range columns {
+		cw.record[i] = c.Name
+	}
+	if err := cw.w.Write(cw.record); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) Write(app *domain.CreditApplication) error {
+	for i, c := range cw.columns {
+		cw.record[i], _ = formatText(c.kind, c.value(app))
+	}
+	return cw.w.Write(cw.record)
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// jsonlWriter writes one object per line with keys in column order. Amounts
+// are JSON strings so that consumers do not parse them as floats.
+type jsonlWriter struct {
+	columns []Column
+	w       *bufio.Writer
+	keys    [][]byte
+}
+
+func newJSONLWriter(columns []Column, w io.Writer) *jsonlWriter {
+	jw := &jsonlWriter{columns: columns, w: bufio.NewWriter(w), keys: make([][]byte, len(columns))}
+	for i, c := range columns {
+		jw.keys[i], _ = json.Marshal(c.Name)
+	}
+	return jw
+}
+
+func (jw *jsonlWriter) Write(app *domain.CreditApplication) error {
+	jw.w.WriteByte('{')
+	for i, c := range jw.columns {
+		if i > 0 {
+			jw.w.WriteByte(',')
+		}
+		jw.w.Write(jw.keys[i])
+		jw.w.WriteByte(':')
+
+		text, ok := formatText(c.kind, c.value(app))
+		switch {
+		case !ok:
+			jw.w.WriteString("null")
+		case c.kind == kindInt:
+			jw.w.WriteString(text)
+		default:
+			value, err := json.Marshal(text)
+			if err != nil {
+				return err
+			}
+			jw.w.Write(value)
+		}
+	}
+	jw.w.WriteByte('}')
+	return jw.w.WriteByte('\n')
+}
+
+func (jw *jsonlWriter) Close() error {
+	return jw.w.Flush()
+}
+
+// parquetWriter stores amounts as DECIMAL(15,2) backed by INT64 and
+// timestamps as TIMESTAMP_MILLIS.
+type parquetWriter struct {
+	columns []Column
+	w       *writer.CSVWriter
+}
+
+func newParquetWriter(columns []Column, w io.Writer) (*parquetWriter, error) {
+	md := make([]string, len(columns))
+	for i, c := range columns {
+		switch c.kind {
+		case kindNullableString:
+			md[i] = fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=OPTIONAL", c.Name)
+		case kindDecimal:
+			md[i] = fmt.Sprintf("name=%s, type=INT64, convertedtype=DECIMAL, scale=%d, precision=%d, repetitiontype=REQUIRED",
+				c.Name, amountScale, money.MaxPrecision)
+		case kindInt:
+			md[i] = fmt.Sprintf("name=%s, type=INT64, repetitiontype=REQUIRED", c.Name)
+		case kindTimestamp:
+			md[i] = fmt.Sprintf("name=%s, type=INT64, convertedtype=TIMESTAMP_MILLIS, repetitiontype=REQUIRED", c.Name)
+		default:
+			md[i] = fmt.Sprintf("name=%s, type=BYTE_ARRAY, convertedtype=UTF8, repetitiontype=REQUIRED", c.Name)
+		}
+	}
+
+	pw, err := writer.NewCSVWriterFromWriter(md, w, 1)
+	if err != nil {
+		return nil, err
+	}
+	pw.RowGroupSize = parquetRowGroupSize
+
+	return &parquetWriter{columns: columns, w: pw}, nil
+}
+
+func (pw *parquetWriter) Write(app *domain.CreditApplication) error {
+	// Write хранит срез до сброса группы строк, поэтому запись каждый раз новая.
+	record := make([]interface{}, len(pw.columns))
+	for i, c := range pw.columns {
+		v := c.value(app)
+		switch c.kind {
+		case kindNullableString:
+			if s := v.(*string); s != nil {
+				record[i] = *s
+			}
+		case kindDecimal:
+			record[i] = v.(decimal.Decimal).Shift(amountScale).IntPart()
+		case kindTimestamp:
+			record[i] = v.(time.Time).UnixMilli()
+		default:
+			record[i] = v
+		}
+	}
+	return pw.w.Write(record)
+}
+
+func (pw *parquetWriter) Close() error {
+	return pw.w.WriteStop()
+}
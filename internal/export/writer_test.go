@@ -0,0 +1,231 @@
+package export
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+)
+
+var testColumns = []string{"id", "disbursement_amount", "interest", "term", "reject_reason", "created_at"}
+
+func newExportApplication(amount string, reject sql.NullString) *domain.CreditApplication {
+	return &domain.CreditApplication{
+		ID:                 uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+		DisbursementAmount: decimal.RequireFromString(amount),
+		Interest:           decimal.RequireFromString("12.5"),
+		Term:               12,
+		RejectReason:       reject,
+		CreatedAt:          time.Date(2024, 3, 1, 10, 0, 0, 0, time.UTC),
+	}
+}
+
+func writeAll(t *testing.T, format Format, apps ...*domain.CreditApplication) []byte {
+	t.Helper()
+	columns, err := Columns(testColumns)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(format, columns, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, app := range apps {
+		if err := w.Write(app); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Format
+		wantErr error
+	}{
+		{"csv", FormatCSV, nil},
+		{"JSONL", FormatJSONL, nil},
+		{"Parquet", FormatParquet, nil},
+		{"xlsx", "", ErrUnknownFormat},
+		{"", "", ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseFormat(tt.in)
+			if !errors.Is(err, tt.wantErr) || got != tt.want {
+				t.Fatalf("ParseFormat(%q) = %q, %v, want %q, %v", tt.in, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   []string
+		want    []string
+		wantErr error
+	}{
+		{"all by default", nil, ColumnNames(), nil},
+		{"keeps the requested order", []string{"status", " id "}, []string{"status", "id"}, nil},
+		{"unknown column", []string{"id", "password"}, nil, ErrUnknownColumn},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns, err := Columns(tt.names)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Columns() error = %v, want %v", err, tt.wantErr)
+			}
+			got := make([]string, len(columns))
+			for i, c := range columns {
+				got[i] = c.Name
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Fatalf("Columns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTextDecimalScale(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"1000", "1000.00"},
+		{"15.5", "15.50"},
+		{"0.01", "0.01"},
+		{"1.500", "1.50"},
+		{"9999999999999.99", "9999999999999.99"},
+		{"-3.1", "-3.10"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, ok := formatText(kindDecimal, decimal.RequireFromString(tt.in))
+			if !ok || got != tt.want {
+				t.Fatalf("formatText(%s) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSVWriter(t *testing.T) {
+	out := writeAll(t, FormatCSV,
+		newExportApplication("1000", sql.NullString{}),
+		newExportApplication("15.5", sql.NullString{String: "Low, \"bad\" score", Valid: true}),
+	)
+
+	records, err := csv.NewReader(bytes.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]string{
+		testColumns,
+		{"11111111-1111-1111-1111-111111111111", "1000.00", "12.50", "12", "", "2024-03-01T10:00:00Z"},
+		{"11111111-1111-1111-1111-111111111111", "15.50", "12.50", "12", "Low, \"bad\" score", "2024-03-01T10:00:00Z"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d:\n%s", len(records), len(want), out)
+	}
+	for i := range want {
+		if strings.Join(records[i], "|") != strings.Join(want[i], "|") {
+			t.Fatalf("record %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+}
+
+func TestJSONLWriter(t *testing.T) {
+	out := writeAll(t, FormatJSONL,
+		newExportApplication("1000", sql.NullString{}),
+		newExportApplication("0.1", sql.NullString{String: "Low score", Valid: true}),
+	)
+
+	want := []string{
+		`{"id":"11111111-1111-1111-1111-111111111111","disbursement_amount":"1000.00","interest":"12.50","term":12,"reject_reason":null,"created_at":"2024-03-01T10:00:00Z"}`,
+		`{"id":"11111111-1111-1111-1111-111111111111","disbursement_amount":"0.10","interest":"12.50","term":12,"reject_reason":"Low score","created_at":"2024-03-01T10:00:00Z"}`,
+	}
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), out)
+	}
+	for i, line := range lines {
+		if !json.Valid([]byte(line)) {
+			t.Fatalf("line %d is not valid JSON: %s", i, line)
+		}
+		if line != want[i] {
+			t.Fatalf("line %d = %s, want %s", i, line, want[i])
+		}
+	}
+}
+
+func TestParquetWriter(t *testing.T) {
+	out := writeAll(t, FormatParquet,
+		newExportApplication("1000", sql.NullString{}),
+		newExportApplication("15.5", sql.NullString{String: "Low score", Valid: true}),
+	)
+
+	file, err := buffer.NewBufferFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pr.ReadStop()
+	if got := pr.GetNumRows(); got != 2 {
+		t.Fatalf("rows = %d, want 2", got)
+	}
+
+	// Первый элемент схемы — корень, дальше колонки в порядке экспорта.
+	schema := pr.Footer.Schema[1:]
+	amount := schema[1]
+	if amount.GetConvertedType() != parquet.ConvertedType_DECIMAL || amount.GetScale() != amountScale {
+		t.Fatalf("disbursement_amount = %s scale %d, want DECIMAL scale %d",
+			amount.GetConvertedType(), amount.GetScale(), amountScale)
+	}
+	if schema[4].GetRepetitionType() != parquet.FieldRepetitionType_OPTIONAL {
+		t.Fatalf("reject_reason repetition = %s, want OPTIONAL", schema[4].GetRepetitionType())
+	}
+
+	tests := []struct {
+		column int64
+		want   []interface{}
+	}{
+		{1, []interface{}{int64(100000), int64(1550)}},
+		{2, []interface{}{int64(1250), int64(1250)}},
+		{3, []interface{}{int64(12), int64(12)}},
+		{4, []interface{}{nil, "Low score"}},
+		{5, []interface{}{int64(1709287200000), int64(1709287200000)}},
+	}
+	for _, tt := range tests {
+		values, _, _, err := pr.ReadColumnByIndex(tt.column, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(values) != len(tt.want) {
+			t.Fatalf("column %d = %v, want %v", tt.column, values, tt.want)
+		}
+		for i := range values {
+			if values[i] != tt.want[i] {
+				t.Fatalf("column %d row %d = %#v, want %#v", tt.column, i, values[i], tt.want[i])
+			}
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// Захват аренды и выдача нового fencing token одной операцией.
+var acquireScript = redis.NewScript(`
+if redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2]) then
+	return redis.call("INCR", KEYS[2])
+end
+return 0
+`)
+
+var renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+
+var releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Callbacks are invoked from the elector loop and must not block. The ctx
+// passed to OnElected is cancelled right before OnLost is called.
+type Callbacks struct {
+	OnElected func(ctx context.Context, token int64)
+	OnLost    func()
+}
+
+type Config struct {
+	// Key is the Redis lease key; the fencing token counter is Key + ":token".
+	Key string
+	// ID identifies this replica as the lease holder.
+	ID            string
+	LeaseTTL      time.Duration
+	RenewInterval time.Duration
+	RetryInterval time.Duration
+}
+
+// Elector keeps at most one replica leader through a Redis lease. Every
+// acquisition gets a fencing token greater than all previous ones, so a
+// resource can reject writes from a leader that has been replaced.
+type Elector struct {
+	client    *redis.Client
+	cfg       Config
+	callbacks Callbacks
+}
+
+func NewElector(client *redis.Client, cfg Config, callbacks Callbacks) *Elector {
+	return &Elector{client: client, cfg: cfg, callbacks: callbacks}
+}
+
+// Leader returns the ID of the current lease holder, empty if there is none.
+func (e *Elector) Leader(ctx context.Context) (string, error) {
+	return CurrentLeader(ctx, e.client, e.cfg.Key)
+}
+
+func CurrentLeader(ctx context.Context, client *redis.Client, key string) (string, error) {
+	id, err := client.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", nil
+	}
+	return id, err
+}
+
+// Run campaigns for leadership until ctx is cancelled and releases the lease
+// on the way out.
+func (e *Elector) Run(ctx context.Context) {
+	for {
+		token, err := e.acquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.FromContext(ctx).Error("Leader lease acquisition failed", zap.String("key", e.cfg.Key), zap.Error(err))
+		}
+		if token > 0 {
+			e.lead(ctx, token)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(e.cfg.RetryInterval):
+		}
+	}
+}
+
+func (e *Elector) acquire(ctx context.Context) (int64, error) {
+	return acquireScript.Run(ctx, e.client,
+		[]string{e.cfg.Key, e.cfg.Key + ":token"},
+		e.cfg.ID, e.cfg.LeaseTTL.Milliseconds(),
+	).Int64()
+}
+
+// lead holds the lease until it cannot be renewed or ctx is cancelled.
+func (e *Elector) lead(ctx context.Context, token int64) {
+	logger.FromContext(ctx).Info("Became leader",
+		zap.String("key", e.cfg.Key),
+		zap.String("id", e.cfg.ID),
+		zap.Int64("token", token),
+	)
+	leaderCtx, cancel := context.WithCancel(ctx)
+	e.callbacks.OnElected(leaderCtx, token)
+
+	defer func() {
+		cancel()
+		e.callbacks.OnLost()
+		e.release(ctx)
+		logger.FromContext(ctx).Info("Lost leadership", zap.String("key", e.cfg.Key), zap.Int64("token", token))
+	}()
+
+	ticker := time.NewTicker(e.cfg.RenewInterval)
+	defer ticker.Stop()
+	renewedAt := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		renewed, err := renewScript.Run(ctx, e.client, []string{e.cfg.Key}, e.cfg.ID, e.cfg.LeaseTTL.Milliseconds()).Int64()
+		switch {
+		case err == nil && renewed == 1:
+			renewedAt = time.Now()
+		case err == nil:
+			logger.FromContext(ctx).Warn("Leader lease taken over", zap.String("key", e.cfg.Key))
+			return
+		case time.Since(renewedAt) >= e.cfg.LeaseTTL-e.cfg.RenewInterval:
+			// Уходим раньше, чем аренда истечёт и её сможет взять другая реплика.
+			logger.FromContext(ctx).Error("Leader lease renewal failed", zap.String("key", e.cfg.Key), zap.Error(err))
+			return
+		default:
+			logger.FromContext(ctx).Warn("Leader lease renewal failed, retrying", zap.String("key", e.cfg.Key), zap.Error(err))
+		}
+	}
+}
+
+func (e *Elector) release(ctx context.Context) {
+	// ctx уже отменён при остановке, но аренду нужно отдать.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), e.cfg.RenewInterval)
+	defer cancel()
+	if err := releaseScript.Run(ctx, e.client, []string{e.cfg.Key}, e.cfg.ID).Err(); err != nil {
+		logger.FromContext(ctx).Warn("Leader lease release failed", zap.String("key", e.cfg.Key), zap.Error(err))
+	}
+}
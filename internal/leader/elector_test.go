@@ -0,0 +1,156 @@
+package leader
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+const testKey = "leader:test"
+
+func newTestClient(t *testing.T) (*redis.Client, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { client.Close() })
+	return client, server
+}
+
+// term records the callbacks of one elector.
+type term struct {
+	elected chan int64
+	lost    chan struct{}
+}
+
+func newTerm() *term {
+	return &term{elected: make(chan int64, 4), lost: make(chan struct{}, 4)}
+}
+
+func (tm *term) callbacks() Callbacks {
+	return Callbacks{
+		OnElected: func(_ context.Context, token int64) { tm.elected <- token },
+		OnLost:    func() { tm.lost <- struct{}{} },
+	}
+}
+
+func startElector(ctx context.Context, client *redis.Client, cfg Config, tm *term) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		NewElector(client, cfg, tm.callbacks()).Run(ctx)
+	}()
+	return done
+}
+
+func testConfig(id string) Config {
+	return Config{
+		Key:           testKey,
+		ID:            id,
+		LeaseTTL:      time.Second,
+		RenewInterval: 10 * time.Millisecond,
+		// Проигравшая реплика не переизбирается до конца теста.
+		RetryInterval: time.Hour,
+	}
+}
+
+func waitToken(t *testing.T, ch <-chan int64, what string) int64 {
+	t.Helper()
+	select {
+	case token := <-ch:
+		return token
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+		return 0
+	}
+}
+
+func waitSignal(t *testing.T, ch <-chan struct{}, what string) {
+	t.Helper()
+	select {
+	case <-ch:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %s", what)
+	}
+}
+
+func TestElectorHandsOverExpiredLeaseWithGreaterToken(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first, second := newTerm(), newTerm()
+	firstDone := startElector(ctx, client, testConfig("replica-1"), first)
+	firstToken := waitToken(t, first.elected, "first election")
+
+	// Пауза лидера дольше аренды: аренда истекает, её берёт вторая реплика.
+	server.FastForward(2 * time.Second)
+	secondDone := startElector(ctx, client, testConfig("replica-2"), second)
+	secondToken := waitToken(t, second.elected, "takeover")
+	waitSignal(t, first.lost, "the old leader to step down")
+
+	if secondToken <= firstToken {
+		t.Fatalf("takeover token %d, want greater than %d", secondToken, firstToken)
+	}
+	if leader, err := CurrentLeader(ctx, client, testKey); err != nil || leader != "replica-2" {
+		t.Fatalf("leader = %q (%v), the old leader must not release the new lease", leader, err)
+	}
+
+	cancel()
+	<-firstDone
+	<-secondDone
+	waitSignal(t, second.lost, "the new leader to stop")
+	if leader, _ := CurrentLeader(context.Background(), client, testKey); leader != "" {
+		t.Fatalf("leader = %q after shutdown, want the lease released", leader)
+	}
+}
+
+func TestElectorStepsDownWhenLeaseCannotBeRenewed(t *testing.T) {
+	client, server := newTestClient(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := testConfig("replica-1")
+	cfg.LeaseTTL = 100 * time.Millisecond
+	tm := newTerm()
+	done := startElector(ctx, client, cfg, tm)
+	waitToken(t, tm.elected, "election")
+
+	// Redis недоступен: лидер уходит до истечения аренды, не дожидаясь ответа.
+	started := time.Now()
+	server.Close()
+	waitSignal(t, tm.lost, "stepping down")
+	if elapsed := time.Since(started); elapsed > cfg.LeaseTTL+cfg.RenewInterval {
+		t.Fatalf("stepped down after %s, want before the %s lease expires", elapsed, cfg.LeaseTTL)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestElectorWaitsForHeldLease(t *testing.T) {
+	client, server := newTestClient(t)
+	if err := server.Set(testKey, "replica-0"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cfg := testConfig("replica-1")
+	cfg.RetryInterval = 10 * time.Millisecond
+	tm := newTerm()
+	done := startElector(ctx, client, cfg, tm)
+
+	select {
+	case token := <-tm.elected:
+		t.Fatalf("elected with token %d while the lease is held", token)
+	case <-time.After(50 * time.Millisecond):
+	}
+	server.Del(testKey)
+	waitToken(t, tm.elected, "election after the lease is freed")
+
+	cancel()
+	<-done
+}
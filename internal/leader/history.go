@@ -0,0 +1,98 @@
+package leader
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// JobRun is one execution of a singleton job.
+type JobRun struct {
+	Job        string        `json:"job"`
+	LeaderID   string        `json:"leader_id"`
+	Token      int64         `json:"token"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	Duration   time.Duration `json:"duration"`
+	Error      string        `json:"error,omitempty"`
+}
+
+// JobStatus summarises a job for operators.
+type JobStatus struct {
+	Name        string     `json:"name"`
+	Leader      string     `json:"leader"`
+	LastRun     *JobRun    `json:"last_run,omitempty"`
+	LastSuccess *time.Time `json:"last_success,omitempty"`
+	Failures    int        `json:"consecutive_failures"`
+}
+
+// History keeps the latest runs of every job in Redis, so any replica or the
+// CLI sees them regardless of which replica was the leader.
+type History struct {
+	client *redis.Client
+	prefix string
+	size   int
+}
+
+func NewHistory(client *redis.Client, prefix string, size int) *History {
+	return &History{client: client, prefix: prefix, size: size}
+}
+
+func (h *History) jobsKey() string           { return h.prefix + ":jobs" }
+func (h *History) runsKey(job string) string { return h.prefix + ":runs:" + job }
+
+func (h *History) Record(ctx context.Context, run JobRun) error {
+	data, err := json.Marshal(run)
+	if err != nil {
+		return err
+	}
+	pipe := h.client.TxPipeline()
+	pipe.SAdd(ctx, h.jobsKey(), run.Job)
+	pipe.LPush(ctx, h.runsKey(run.Job), data)
+	pipe.LTrim(ctx, h.runsKey(run.Job), 0, int64(h.size-1))
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+// Runs returns up to limit latest runs of job, newest first.
+func (h *History) Runs(ctx context.Context, job string, limit int) ([]JobRun, error) {
+	items, err := h.client.LRange(ctx, h.runsKey(job), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, err
+	}
+	runs := make([]JobRun, 0, len(items))
+	for _, item := range items {
+		var run JobRun
+		if err := json.Unmarshal([]byte(item), &run); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, nil
+}
+
+func (h *History) Jobs(ctx context.Context) ([]string, error) {
+	return h.client.SMembers(ctx, h.jobsKey()).Result()
+}
+
+// Status derives the job status from its stored runs.
+func (h *History) Status(ctx context.Context, job string, leader string) (JobStatus, error) {
+	runs, err := h.Runs(ctx, job, h.size)
+	if err != nil {
+		return JobStatus{}, err
+	}
+	status := JobStatus{Name: job, Leader: leader}
+	for i := range runs {
+		if i == 0 {
+			status.LastRun = &runs[0]
+		}
+		if runs[i].Error == "" {
+			status.LastSuccess = &runs[i].FinishedAt
+			break
+		}
+		status.Failures++
+	}
+	return status, nil
+}
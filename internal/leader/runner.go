@@ -0,0 +1,91 @@
+package leader
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Job is background work that must run on one replica only.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	// Run gets the fencing token of the current leadership term.
+	Run func(ctx context.Context, token int64) error
+}
+
+// Runner runs registered jobs while this replica is the leader. Its OnElected
+// and OnLost methods are the elector callbacks.
+type Runner struct {
+	id      string
+	history *History
+	jobs    []Job
+	// active tracks job loops of the current leadership term.
+	active sync.WaitGroup
+}
+
+func NewRunner(id string, history *History) *Runner {
+	return &Runner{id: id, history: history}
+}
+
+// Register adds a job; call it before the elector starts.
+func (r *Runner) Register(job Job) {
+	r.jobs = append(r.jobs, job)
+}
+
+func (r *Runner) Callbacks() Callbacks {
+	return Callbacks{OnElected: r.OnElected, OnLost: r.OnLost}
+}
+
+func (r *Runner) OnElected(ctx context.Context, token int64) {
+	for _, job := range r.jobs {
+		r.active.Add(1)
+		go func() {
+			defer r.active.Done()
+			r.loop(ctx, job, token)
+		}()
+	}
+}
+
+// OnLost waits for running jobs, whose ctx is already cancelled, to return.
+func (r *Runner) OnLost() {
+	r.active.Wait()
+}
+
+func (r *Runner) loop(ctx context.Context, job Job, token int64) {
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.run(ctx, job, token)
+		}
+	}
+}
+
+func (r *Runner) run(ctx context.Context, job Job, token int64) {
+	run := JobRun{Job: job.Name, LeaderID: r.id, Token: token, StartedAt: time.Now().UTC()}
+	err := job.Run(ctx, token)
+	run.FinishedAt = time.Now().UTC()
+	run.Duration = run.FinishedAt.Sub(run.StartedAt)
+	if err != nil {
+		run.Error = err.Error()
+		logger.FromContext(ctx).Error("Singleton job failed",
+			zap.String("job", job.Name),
+			zap.Int64("token", token),
+			zap.Error(err),
+		)
+	}
+
+	// История пишется и после потери лидерства, чтобы не терять прерванный запуск.
+	recordCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 2*time.Second)
+	defer cancel()
+	if err := r.history.Record(recordCtx, run); err != nil {
+		logger.FromContext(ctx).Warn("Failed to record job run", zap.String("job", job.Name), zap.Error(err))
+	}
+}
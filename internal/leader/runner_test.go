@@ -0,0 +1,101 @@
+package leader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunnerRunsJobsWithTermTokenUntilLost(t *testing.T) {
+	client, _ := newTestClient(t)
+	history := NewHistory(client, "jobs:test", 10)
+	runner := NewRunner("replica-1", history)
+
+	var (
+		mu     sync.Mutex
+		tokens []int64
+	)
+	ran := make(chan struct{}, 100)
+	runner.Register(Job{Name: "job", Interval: 5 * time.Millisecond, Run: func(_ context.Context, token int64) error {
+		mu.Lock()
+		tokens = append(tokens, token)
+		mu.Unlock()
+		ran <- struct{}{}
+		return nil
+	}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runner.OnElected(ctx, 7)
+	waitSignal(t, ran, "a run")
+	waitSignal(t, ran, "a second run")
+	cancel()
+	runner.OnLost()
+
+	mu.Lock()
+	stopped := len(tokens)
+	for _, token := range tokens {
+		if token != 7 {
+			t.Fatalf("job got token %d, want the term's 7", token)
+		}
+	}
+	mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	if len(tokens) != stopped {
+		t.Fatalf("job ran %d times after the leadership was lost", len(tokens)-stopped)
+	}
+
+	runs, err := history.Runs(context.Background(), "job", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(runs) == 0 || runs[0].Token != 7 || runs[0].LeaderID != "replica-1" {
+		t.Fatalf("runs = %+v, want runs of replica-1 with token 7", runs)
+	}
+}
+
+func TestHistoryStatus(t *testing.T) {
+	failed := errors.New("failed")
+	tests := []struct {
+		name     string
+		results  []error
+		failures int
+		success  bool
+	}{
+		{"no runs", nil, 0, false},
+		{"last run succeeded", []error{failed, nil}, 0, true},
+		{"failures since success", []error{nil, failed, failed}, 2, true},
+		{"never succeeded", []error{failed, failed}, 2, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t)
+			history := NewHistory(client, "jobs:test", 10)
+			ctx := context.Background()
+			for i, result := range tt.results {
+				run := JobRun{Job: "job", Token: int64(i + 1), FinishedAt: time.Unix(int64(i), 0).UTC()}
+				if result != nil {
+					run.Error = result.Error()
+				}
+				if err := history.Record(ctx, run); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			status, err := history.Status(ctx, "job", "replica-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Failures != tt.failures || (status.LastSuccess != nil) != tt.success {
+				t.Fatalf("failures %d, last success %v; want %d, %v", status.Failures, status.LastSuccess, tt.failures, tt.success)
+			}
+			if len(tt.results) > 0 && status.LastRun.Token != int64(len(tt.results)) {
+				t.Fatalf("last run token %d, want the newest run", status.LastRun.Token)
+			}
+		})
+	}
+}
@@ -2,17 +2,21 @@ package messaging
 
 import (
 	"context"
-	"log"
 
 	"github.com/Andronzi/credit-origination/pkg/logger"
 	"github.com/IBM/sarama"
-	"github.com/linkedin/goavro/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
 type KafkaAvroConsumer struct {
 	consumer sarama.ConsumerGroup
-	codec    *goavro.Codec
+	decoder  *EventDecoder
+	groupID  string
 	topic    string
 	handlers []MessageHandler
 }
@@ -21,7 +25,7 @@ func NewKafkaAvroConsumer(
 	brokers []string,
 	groupID string,
 	topic string,
-	schema string,
+	decoder *EventDecoder,
 	handlers []MessageHandler,
 ) (*KafkaAvroConsumer, error) {
 	config := sarama.NewConfig()
@@ -33,27 +37,28 @@ func NewKafkaAvroConsumer(
 		return nil, err
 	}
 
-	codec, err := goavro.NewCodec(schema)
-	if err != nil {
-		return nil, err
-	}
-
 	return &KafkaAvroConsumer{
 		consumer: consumer,
-		codec:    codec,
+		decoder:  decoder,
+		groupID:  groupID,
 		topic:    topic,
 		handlers: handlers,
 	}, nil
 }
 
 func (c *KafkaAvroConsumer) Consume(ctx context.Context) error {
-	handler := consumerHandler{codec: c.codec, handlers: c.handlers}
+	handler := consumerHandler{decoder: c.decoder, groupID: c.groupID, handlers: c.handlers}
 
 	return c.consumer.Consume(ctx, []string{c.topic}, &handler)
 }
 
+func (c *KafkaAvroConsumer) Close() error {
+	return c.consumer.Close()
+}
+
 type consumerHandler struct {
-	codec    *goavro.Codec
+	decoder  *EventDecoder
+	groupID  string
 	handlers []MessageHandler
 }
 
@@ -62,37 +67,65 @@ func (h *consumerHandler) Cleanup(sarama.ConsumerGroupSession) error { return ni
 
 func (h *consumerHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
 	for msg := range claim.Messages() {
-		logger.Logger.Info("Received Mssage", zap.ByteString("key", msg.Key), zap.Int64("offset", msg.Offset), zap.Int("length", len(msg.Value)))
-		if len(msg.Value) < 5 {
-			log.Printf("Invalid message length")
-			continue
-		}
-		avroData := msg.Value[5:]
+		h.process(session.Context(), msg)
+		session.MarkMessage(msg, "")
+	}
+	return nil
+}
 
-		native, _, err := h.codec.NativeFromBinary(avroData)
-		if err != nil {
-			log.Printf("Failed to decode Avro: %v", err)
-			continue
-		}
+// process runs the handlers under a consumer span that continues the trace
+// of the producer, taken from the message headers.
+func (h *consumerHandler) process(ctx context.Context, msg *sarama.ConsumerMessage) {
+	ctx = otel.GetTextMapPropagator().Extract(ctx, consumerCarrier{msg: msg})
+	ctx, span := tracer.Start(ctx, msg.Topic+" process",
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationDeliver,
+			semconv.MessagingDestinationName(msg.Topic),
+			semconv.MessagingKafkaConsumerGroup(h.groupID),
+			semconv.MessagingKafkaDestinationPartition(int(msg.Partition)),
+			semconv.MessagingKafkaMessageOffset(int(msg.Offset)),
+			semconv.MessagingKafkaMessageKey(string(msg.Key)),
+			semconv.MessagingMessageBodySize(len(msg.Value)),
+		),
+	)
+	defer span.End()
+
+	logger.FromContext(ctx).Info("Received message", zap.ByteString("key", msg.Key), zap.Int64("offset", msg.Offset), zap.Int("length", len(msg.Value)))
+	event, err := h.decoder.Decode(ctx, msg.Value)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to decode event", zap.Int64("offset", msg.Offset), zap.Error(err))
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "decode failed")
+		return
+	}
+	span.SetAttributes(attribute.String("app.application_id", event.ApplicationID))
+	ctx = logger.WithApplicationID(ctx, event.ApplicationID)
 
-		data, ok := native.(map[string]interface{})
-		if !ok {
-			log.Printf("Unexpected message format")
-			continue
+	for _, handler := range h.handlers {
+		if err := h.handle(ctx, handler, msg); err != nil {
+			logger.FromContext(ctx).Error("Failed to handle message", zap.String("handler", handler.Name()), zap.Error(err))
+			span.SetStatus(codes.Error, "handler failed")
 		}
+	}
 
-		logger.Logger.Info("Before handler", zap.Int("length", len(h.handlers)))
-
-		for _, handlers := range h.handlers {
-			logger.Logger.Info("Inside handlers")
+	logger.FromContext(ctx).Info("Received event", zap.Any("event", event))
+}
 
-			if err := handlers.Handle(msg); err != nil {
-				logger.Logger.Error("Failed to handle message", zap.Error(err))
-			}
-		}
+func (h *consumerHandler) handle(ctx context.Context, handler MessageHandler, msg *sarama.ConsumerMessage) error {
+	ctx, span := tracer.Start(ctx, "handle "+handler.Name(),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingDestinationName(msg.Topic),
+		),
+	)
+	defer span.End()
 
-		logger.Logger.Info("Received event: %+v\n", zap.Any("event", data))
-		session.MarkMessage(msg, "")
+	err := handler.Handle(ctx, msg)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
 	}
-	return nil
+	return err
 }
@@ -0,0 +1,46 @@
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/shopspring/decimal"
+)
+
+// Параметры decimal-полей схем v2 и v3, совпадают с decimal(15,2) в БД.
+const (
+	avroDecimalPrecision = 15
+	avroDecimalScale     = 2
+)
+
+var ErrDecimalPrecisionLoss = errors.New("decimal does not fit avro decimal(15,2)")
+
+var avroDecimalLimit = decimal.New(1, avroDecimalPrecision-avroDecimalScale)
+
+// toAvroDecimal refuses values goavro would silently truncate: more than two
+// fractional digits or more than 13 integer digits.
+func toAvroDecimal(d decimal.Decimal) (*big.Rat, error) {
+	if !d.Equal(d.Truncate(avroDecimalScale)) {
+		return nil, fmt.Errorf("%w: %s has more than %d fractional digits", ErrDecimalPrecisionLoss, d, avroDecimalScale)
+	}
+	if d.Abs().GreaterThanOrEqual(avroDecimalLimit) {
+		return nil, fmt.Errorf("%w: %s exceeds precision %d", ErrDecimalPrecisionLoss, d, avroDecimalPrecision)
+	}
+	return d.Rat(), nil
+}
+
+// fromAvroAmount reads an amount written either by v2 and v3 (decimal,
+// *big.Rat) or by v1 (whole units as long).
+func fromAvroAmount(v interface{}) (decimal.Decimal, error) {
+	switch amount := v.(type) {
+	case *big.Rat:
+		return decimal.NewFromBigRat(amount, avroDecimalScale), nil
+	case int64:
+		return decimal.NewFromInt(amount), nil
+	case int32:
+		return decimal.NewFromInt32(amount), nil
+	default:
+		return decimal.Zero, fmt.Errorf("unexpected amount type %T", v)
+	}
+}
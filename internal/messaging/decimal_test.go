@@ -0,0 +1,171 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	"github.com/shopspring/decimal"
+)
+
+func loadCodec(t *testing.T, version string) *goavro.Codec {
+	t.Helper()
+	schema, err := os.ReadFile("../../schemas/avro/application/" + version + "/ApplicationEvent.avsc")
+	if err != nil {
+		t.Fatalf("read %s schema: %v", version, err)
+	}
+	codec, err := goavro.NewCodec(string(schema))
+	if err != nil {
+		t.Fatalf("compile %s schema: %v", version, err)
+	}
+	return codec
+}
+
+func TestStatusEventRoundTripKeepsDecimals(t *testing.T) {
+	amounts := []string{"0.01", "0.10", "1", "1234.56", "9999999999999.99", "-0.01"}
+
+	for _, version := range []string{"v2", "v3"} {
+		codec := loadCodec(t, version)
+		for _, raw := range amounts {
+			amount := decimal.RequireFromString(raw)
+			event := ApplicationStatusEvent{
+				EventType:     "AGREEMENT_CREATED",
+				ApplicationID: "app-1",
+				AgreementDetails: AgreementDetails{
+					ApplicationID:      "app-1",
+					DisbursementAmount: amount,
+					OriginationAmount:  amount,
+					Interest:           decimal.RequireFromString("15.55"),
+					Currency:           "RUB",
+				},
+			}
+
+			native, err := statusEventNative(event)
+			if err != nil {
+				t.Fatalf("%s %s: encode: %v", version, raw, err)
+			}
+			binary, err := codec.BinaryFromNative(nil, native)
+			if err != nil {
+				t.Fatalf("%s %s: binary: %v", version, raw, err)
+			}
+			decoded, _, err := codec.NativeFromBinary(binary)
+			if err != nil {
+				t.Fatalf("%s %s: decode: %v", version, raw, err)
+			}
+			got, err := eventFromNative(decoded.(map[string]interface{}))
+			if err != nil {
+				t.Fatalf("%s %s: event: %v", version, raw, err)
+			}
+
+			if !got.AgreementDetails.DisbursementAmount.Equal(amount) {
+				t.Errorf("%s: disbursement %s, want %s", version, got.AgreementDetails.DisbursementAmount, amount)
+			}
+			if !got.AgreementDetails.OriginationAmount.Equal(amount) {
+				t.Errorf("%s: origination %s, want %s", version, got.AgreementDetails.OriginationAmount, amount)
+			}
+			if !got.AgreementDetails.Interest.Equal(decimal.RequireFromString("15.55")) {
+				t.Errorf("%s: interest %s, want 15.55", version, got.AgreementDetails.Interest)
+			}
+		}
+	}
+}
+
+func TestToAvroDecimalRejectsPrecisionLoss(t *testing.T) {
+	for _, raw := range []string{"0.001", "10000000000000", "-10000000000000", "1.005"} {
+		if _, err := toAvroDecimal(decimal.RequireFromString(raw)); !errors.Is(err, ErrDecimalPrecisionLoss) {
+			t.Errorf("toAvroDecimal(%s) = %v, want ErrDecimalPrecisionLoss", raw, err)
+		}
+	}
+}
+
+func TestV3EventTypeHasDefault(t *testing.T) {
+	codec := loadCodec(t, "v3")
+
+	native, err := statusEventNative(ApplicationStatusEvent{EventType: "UNKNOWN"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := codec.BinaryFromNative(nil, native); err != nil {
+		t.Fatalf("v3 must accept the default symbol: %v", err)
+	}
+	if _, err := loadCodec(t, "v2").BinaryFromNative(nil, native); err == nil {
+		t.Fatal("v2 is frozen and must not know UNKNOWN")
+	}
+}
+
+// v2Fingerprint is the Rabin fingerprint of v2 as it shipped; a published
+// schema version never changes.
+const v2Fingerprint = 0x55db1dbc7e752d0a
+
+func TestV2SchemaIsFrozen(t *testing.T) {
+	if got := loadCodec(t, "v2").Rabin; got != v2Fingerprint {
+		t.Fatalf("v2 fingerprint = %#x, want %#x: add fields to v3 instead", got, uint64(v2Fingerprint))
+	}
+}
+
+type avroRecord struct {
+	Namespace string      `json:"namespace"`
+	Name      string      `json:"name"`
+	Fields    []avroField `json:"fields"`
+}
+
+type avroField struct {
+	Name    string          `json:"name"`
+	Type    json.RawMessage `json:"type"`
+	Default json.RawMessage `json:"default"`
+}
+
+func readRecord(t *testing.T, version string) avroRecord {
+	t.Helper()
+	data, err := os.ReadFile("../../schemas/avro/application/" + version + "/ApplicationEvent.avsc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var record avroRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatal(err)
+	}
+	return record
+}
+
+// checkEvolution checks that next only adds fields with defaults to prev;
+// the registry checks the same when next is registered in prev's subject.
+func checkEvolution(t *testing.T, path string, prev, next []avroField) {
+	t.Helper()
+	fields := make(map[string]avroField, len(next))
+	for _, field := range next {
+		fields[field.Name] = field
+	}
+	for _, old := range prev {
+		field, ok := fields[old.Name]
+		if !ok {
+			t.Errorf("%s.%s was removed", path, old.Name)
+			continue
+		}
+		delete(fields, old.Name)
+
+		var oldRecord, newRecord avroRecord
+		if json.Unmarshal(old.Type, &oldRecord) == nil && oldRecord.Fields != nil {
+			if err := json.Unmarshal(field.Type, &newRecord); err != nil {
+				t.Errorf("%s.%s is no longer a record", path, old.Name)
+				continue
+			}
+			checkEvolution(t, path+"."+old.Name, oldRecord.Fields, newRecord.Fields)
+		}
+	}
+	for name, field := range fields {
+		if field.Default == nil {
+			t.Errorf("%s.%s was added without a default", path, name)
+		}
+	}
+}
+
+func TestV3EvolvesV2(t *testing.T) {
+	v2, v3 := readRecord(t, "v2"), readRecord(t, "v3")
+	if v2.Namespace != v3.Namespace || v2.Name != v3.Name {
+		t.Fatalf("v3 is %s.%s, a version of v2 keeps %s.%s", v3.Namespace, v3.Name, v2.Namespace, v2.Name)
+	}
+	checkEvolution(t, v2.Name, v2.Fields, v3.Fields)
+}
@@ -0,0 +1,138 @@
+package messaging
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Andronzi/credit-origination/internal/client"
+	"github.com/linkedin/goavro/v2"
+)
+
+var ErrInvalidMessage = errors.New("invalid message")
+
+// EventDecoder decodes Confluent-framed Avro application events. The writer
+// schema is picked by the schema ID from the frame header and fetched from the
+// registry on first use, so events written with v1, v2 and v3 schemas can
+// share a topic.
+type EventDecoder struct {
+	registry *client.SchemaRegistryClient
+	mu       sync.RWMutex
+	codecs   map[int]*goavro.Codec
+}
+
+func NewEventDecoder(registry *client.SchemaRegistryClient) *EventDecoder {
+	return &EventDecoder{
+		registry: registry,
+		codecs:   make(map[int]*goavro.Codec),
+	}
+}
+
+func (d *EventDecoder) codec(ctx context.Context, schemaID int) (*goavro.Codec, error) {
+	d.mu.RLock()
+	codec, ok := d.codecs[schemaID]
+	d.mu.RUnlock()
+	if ok {
+		return codec, nil
+	}
+
+	schema, err := d.registry.GetSchemaByID(ctx, schemaID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch schema %d: %w", schemaID, err)
+	}
+	codec, err = goavro.NewCodec(schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile schema %d: %w", schemaID, err)
+	}
+
+	d.mu.Lock()
+	d.codecs[schemaID] = codec
+	d.mu.Unlock()
+
+	return codec, nil
+}
+
+func (d *EventDecoder) Decode(ctx context.Context, value []byte) (*ApplicationStatusEvent, error) {
+	if len(value) < 5 || value[0] != 0x0 {
+		return nil, fmt.Errorf("%w: missing confluent header", ErrInvalidMessage)
+	}
+	schemaID := int(binary.BigEndian.Uint32(value[1:5]))
+
+	codec, err := d.codec(ctx, schemaID)
+	if err != nil {
+		return nil, err
+	}
+
+	native, _, err := codec.NativeFromBinary(value[5:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode avro: %w", err)
+	}
+
+	data, ok := native.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: unexpected message format", ErrInvalidMessage)
+	}
+
+	return eventFromNative(data)
+}
+
+func eventFromNative(data map[string]interface{}) (*ApplicationStatusEvent, error) {
+	event := &ApplicationStatusEvent{}
+	event.MessageID, _ = data["message_id"].(string)
+	event.EventType, _ = data["event_type"].(string)
+	event.Timestamp, _ = data["timestamp"].(int64)
+
+	var ok bool
+	if event.ApplicationID, ok = data["application_id"].(string); !ok {
+		return nil, fmt.Errorf("%w: invalid application_id", ErrInvalidMessage)
+	}
+
+	event.CancelReason = fromAvroReason(data["cancel_reason"])
+	event.RejectReason = fromAvroReason(data["reject_reason"])
+
+	details, ok := data["agreement_details"].(map[string]interface{})
+	if !ok {
+		return event, nil
+	}
+
+	event.AgreementDetails.ApplicationID, _ = details["application_id"].(string)
+	event.AgreementDetails.ClientID, _ = details["client_id"].(string)
+	event.AgreementDetails.ToBankAccountID, _ = details["to_bank_account_id"].(string)
+	event.AgreementDetails.Term, _ = details["term"].(int32)
+	event.AgreementDetails.ProductCode, _ = details["product_code"].(string)
+	event.AgreementDetails.ProductVersion, _ = details["product_version"].(string)
+	// v1 не содержит валюты, все такие события в рублях.
+	if event.AgreementDetails.Currency, ok = details["currency"].(string); !ok {
+		event.AgreementDetails.Currency = "RUB"
+	}
+
+	var err error
+	if event.AgreementDetails.DisbursementAmount, err = fromAvroAmount(details["disbursement_amount"]); err != nil {
+		return nil, fmt.Errorf("disbursement_amount: %w", err)
+	}
+	if event.AgreementDetails.OriginationAmount, err = fromAvroAmount(details["origination_amount"]); err != nil {
+		return nil, fmt.Errorf("origination_amount: %w", err)
+	}
+	if event.AgreementDetails.Interest, err = fromAvroAmount(details["interest"]); err != nil {
+		return nil, fmt.Errorf("interest: %w", err)
+	}
+
+	if paymentDate, ok := details["payment_date"].(map[string]interface{}); ok {
+		if date, ok := paymentDate["long"].(int64); ok {
+			event.AgreementDetails.PaymentDate = &date
+		}
+	}
+
+	return event, nil
+}
+
+func fromAvroReason(value interface{}) *string {
+	if union, ok := value.(map[string]interface{}); ok {
+		if reason, ok := union["string"].(string); ok {
+			return &reason
+		}
+	}
+	return nil
+}
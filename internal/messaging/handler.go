@@ -1,7 +1,14 @@
 package messaging
 
-import "github.com/IBM/sarama"
+import (
+	"context"
+
+	"github.com/IBM/sarama"
+)
 
 type MessageHandler interface {
-	Handle(message *sarama.ConsumerMessage) error
+	// Name identifies the handler in traces and logs.
+	Name() string
+	// Handle gets the context of the message's consumer span.
+	Handle(ctx context.Context, message *sarama.ConsumerMessage) error
 }
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Andronzi/credit-origination/internal/domain"
 	"github.com/Andronzi/credit-origination/internal/messaging"
@@ -9,62 +10,47 @@ import (
 	"github.com/Andronzi/credit-origination/pkg/logger"
 	"github.com/IBM/sarama"
 	"github.com/google/uuid"
-	"github.com/linkedin/goavro/v2"
 	"go.uber.org/zap"
 )
 
 type AgreementCreatedHandler struct {
 	updateStatusUC *usecase.UpdateStatusUseCase
-	codec          *goavro.Codec
+	decoder        *messaging.EventDecoder
 }
 
-func NewAgreementCreatedHandler(uc *usecase.UpdateStatusUseCase, schema string) (*AgreementCreatedHandler, error) {
-	codec, err := goavro.NewCodec(schema)
-	if err != nil {
-		return nil, err
-	}
+func NewAgreementCreatedHandler(uc *usecase.UpdateStatusUseCase, decoder *messaging.EventDecoder) (*AgreementCreatedHandler, error) {
 	return &AgreementCreatedHandler{
 		updateStatusUC: uc,
-		codec:          codec,
+		decoder:        decoder,
 	}, nil
 }
 
-func (h *AgreementCreatedHandler) Handle(message *sarama.ConsumerMessage) error {
-	logger.Logger.Info("Start handle message in AgreementCreatedHandler")
+func (h *AgreementCreatedHandler) Name() string { return "agreement-created" }
 
-	if len(message.Value) < 5 {
-		logger.Logger.Error("Invalid message length")
-		return nil
-	}
-	avroData := message.Value[5:]
+func (h *AgreementCreatedHandler) Handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	logger.FromContext(ctx).Info("Start handle message in AgreementCreatedHandler")
 
-	native, _, err := h.codec.NativeFromBinary(avroData)
+	event, err := h.decoder.Decode(ctx, message.Value)
 	if err != nil {
-		logger.Logger.Error("Failed to decode avro", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to decode event", zap.Error(err))
 		return err
 	}
-
-	data, ok := native.(map[string]interface{})
-	if !ok {
-		logger.Logger.Error("Unexpected message format")
-		return nil
-	}
-
-	applicationID, ok := data["application_id"].(string)
-	if !ok {
-		logger.Logger.Error("Invalid application_id in message")
-		return nil
-	}
+	applicationID := event.ApplicationID
 
 	appID, err := uuid.Parse(applicationID)
 	if err != nil {
-		logger.Logger.Error("Invalid application ID", zap.String("ID", applicationID))
+		logger.FromContext(ctx).Error("Invalid application ID", zap.String("ID", applicationID))
 		return err
 	}
 
-	ctx := context.Background()
-	if err := h.updateStatusUC.Execute(ctx, appID, domain.SCORING); err != nil {
-		logger.Logger.Error("Failed to update status to SCORING", zap.Error(err))
+	err = h.updateStatusUC.Execute(ctx, appID, domain.SCORING)
+	if errors.Is(err, domain.ErrApplicationCancelled) {
+		// Клиент отозвал заявку, пока шло событие; двигать её дальше нельзя.
+		logger.FromContext(ctx).Info("Skipping event for cancelled application", zap.String("app_id", applicationID))
+		return nil
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to update status to SCORING", zap.Error(err))
 		return err
 	}
 
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 
 	"github.com/Andronzi/credit-origination/internal/domain"
 	"github.com/Andronzi/credit-origination/internal/messaging"
@@ -9,62 +10,66 @@ import (
 	"github.com/Andronzi/credit-origination/pkg/logger"
 	"github.com/IBM/sarama"
 	"github.com/google/uuid"
-	"github.com/linkedin/goavro/v2"
 	"go.uber.org/zap"
 )
 
 type ScoringHandler struct {
 	updateStatusUC *usecase.UpdateStatusUseCase
-	codec          *goavro.Codec
+	// scoreUC is nil when bureau scoring is disabled.
+	scoreUC *usecase.ScoreApplicationUseCase
+	decoder *messaging.EventDecoder
 }
 
-func NewScoringHandler(uc *usecase.UpdateStatusUseCase, schema string) (*ScoringHandler, error) {
-	codec, err := goavro.NewCodec(schema)
-	if err != nil {
-		return nil, err
-	}
+func NewScoringHandler(uc *usecase.UpdateStatusUseCase, scoreUC *usecase.ScoreApplicationUseCase, decoder *messaging.EventDecoder) (*ScoringHandler, error) {
 	return &ScoringHandler{
 		updateStatusUC: uc,
-		codec:          codec,
+		scoreUC:        scoreUC,
+		decoder:        decoder,
 	}, nil
 }
 
-func (h *ScoringHandler) Handle(message *sarama.ConsumerMessage) error {
-	logger.Logger.Info("Start handle message in ScoringHandler")
+func (h *ScoringHandler) Name() string { return "scoring" }
 
-	if len(message.Value) < 5 {
-		logger.Logger.Error("Invalid message length")
-		return nil
+func (h *ScoringHandler) Handle(ctx context.Context, message *sarama.ConsumerMessage) error {
+	logger.FromContext(ctx).Info("Start handle message in ScoringHandler")
+
+	event, err := h.decoder.Decode(ctx, message.Value)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to decode event", zap.Error(err))
+		return err
 	}
-	avroData := message.Value[5:]
+	applicationID := event.ApplicationID
 
-	native, _, err := h.codec.NativeFromBinary(avroData)
+	appID, err := uuid.Parse(applicationID)
 	if err != nil {
-		logger.Logger.Error("Failed to decode avro", zap.Error(err))
+		logger.FromContext(ctx).Error("Invalid application ID", zap.String("ID", applicationID))
 		return err
 	}
 
-	data, ok := native.(map[string]interface{})
-	if !ok {
-		logger.Logger.Error("Unexpected message format")
-		return nil
+	if h.scoreUC != nil {
+		passed, err := h.scoreUC.Execute(ctx, appID)
+		if errors.Is(err, domain.ErrApplicationCancelled) {
+			logger.FromContext(ctx).Info("Skipping event for cancelled application", zap.String("app_id", applicationID))
+			return nil
+		}
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to score application", zap.String("app_id", applicationID), zap.Error(err))
+			return err
+		}
+		if !passed {
+			logger.FromContext(ctx).Info("Application did not pass the scorecard", zap.String("app_id", applicationID))
+			return nil
+		}
 	}
 
-	applicationID, ok := data["application_id"].(string)
-	if !ok {
-		logger.Logger.Error("Invalid application_id in message")
+	err = h.updateStatusUC.Execute(ctx, appID, domain.APPROVED)
+	if errors.Is(err, domain.ErrApplicationCancelled) {
+		// Клиент отозвал заявку, пока шло событие; двигать её дальше нельзя.
+		logger.FromContext(ctx).Info("Skipping event for cancelled application", zap.String("app_id", applicationID))
 		return nil
 	}
-
-	appID, err := uuid.Parse(applicationID)
 	if err != nil {
-		logger.Logger.Error("Invalid application ID", zap.String("ID", applicationID))
-		return err
-	}
-
-	ctx := context.Background()
-	if err := h.updateStatusUC.Execute(ctx, appID, domain.APPROVED); err != nil {
-		logger.Logger.Error("Failed to update status to APPROVED", zap.Error(err))
+		logger.FromContext(ctx).Error("Failed to update status to APPROVED", zap.Error(err))
 		return err
 	}
 
@@ -1,27 +1,38 @@
 package messaging
 
 import (
+	"context"
 	"encoding/binary"
-	"log"
+	"fmt"
 	"time"
 
 	"github.com/Andronzi/credit-origination/internal/client"
+	"github.com/Andronzi/credit-origination/internal/resilience"
+	"github.com/Andronzi/credit-origination/pkg/logger"
 	"github.com/IBM/sarama"
 	"github.com/google/uuid"
 	"github.com/linkedin/goavro/v2"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
 )
 
 type AgreementDetails struct {
-	ApplicationID      string `avro:"application_id"`
-	ClientID           string `avro:"client_id"`
-	DisbursementAmount int64  `avro:"disbursement_amount"`
-	OriginationAmount  int64  `avro:"origination_amount"`
-	ToBankAccountID    string `avro:"to_bank_account_id"`
-	Term               int32  `avro:"term"`
-	Interest           int64  `avro:"interest"`
-	ProductCode        string `avro:"product_code"`
-	ProductVersion     string `avro:"product_version"`
-	PaymentDate        *int64 `avro:"payment_date"` // TODO: Использую пока что указатель для поддержки nil :hmm:
+	ApplicationID      string          `avro:"application_id"`
+	ClientID           string          `avro:"client_id"`
+	DisbursementAmount decimal.Decimal `avro:"disbursement_amount"`
+	OriginationAmount  decimal.Decimal `avro:"origination_amount"`
+	ToBankAccountID    string          `avro:"to_bank_account_id"`
+	Term               int32           `avro:"term"`
+	Interest           decimal.Decimal `avro:"interest"`
+	ProductCode        string          `avro:"product_code"`
+	ProductVersion     string          `avro:"product_version"`
+	Currency           string          `avro:"currency"`
+	PaymentDate        *int64          `avro:"payment_date"` // TODO: Использую пока что указатель для поддержки nil :hmm:
 }
 
 type ApplicationStatusEvent struct {
@@ -29,44 +40,67 @@ type ApplicationStatusEvent struct {
 	EventType        string           `avro:"event_type"`
 	ApplicationID    string           `avro:"application_id"`
 	Timestamp        int64            `avro:"timestamp"`
+	CancelReason     *string          `avro:"cancel_reason"`
+	RejectReason     *string          `avro:"reject_reason"`
 	AgreementDetails AgreementDetails `avro:"agreement_details"`
 }
 
 type KafkaProducer struct {
 	producer sarama.SyncProducer
 	codec    *goavro.Codec
-	registry *client.SchemaRegistryClient
+	dep      *resilience.Dependency
 	topic    string
 	schemaID int
 }
 
-func NewKafkaProducer(brokers []string, topic string, schema string) (*KafkaProducer, error) {
+// NewKafkaProducer sends through dep's breaker and bulkhead. Retries stay in
+// sarama, which with an idempotent producer cannot duplicate a message, and
+// follow dep's retry policy.
+func NewKafkaProducer(brokers []string, topic string, subject string, schema string, registry *client.SchemaRegistryClient, dep *resilience.Dependency) (*KafkaProducer, error) {
+	retry := dep.RetryPolicy()
 	config := sarama.NewConfig()
+	config.Version = sarama.V2_5_0_0
 	config.Producer.RequiredAcks = sarama.WaitForAll
-	config.Producer.Retry.Max = 5
+	config.Producer.Idempotent = true
+	config.Net.MaxOpenRequests = 1
+	config.Producer.Retry.Max = max(retry.Attempts-1, 1)
+	config.Producer.Retry.BackoffFunc = func(retries, _ int) time.Duration {
+		return retry.Backoff(retries)
+	}
+	config.Producer.Timeout = dep.Timeout()
+	config.Net.DialTimeout = dep.Timeout()
+	config.Net.ReadTimeout = dep.Timeout()
+	config.Net.WriteTimeout = dep.Timeout()
 	config.Producer.Return.Successes = true
 
+	schemaID, err := registry.GetSchemaID(context.Background(), subject, schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema ID: %w", err)
+	}
+
 	producer, err := sarama.NewSyncProducer(brokers, config)
 	if err != nil {
 		return nil, err
 	}
-
-	codec, err := goavro.NewCodec(schema)
+	p, err := NewKafkaProducerFromSync(producer, topic, schema, schemaID, dep)
 	if err != nil {
+		producer.Close()
 		return nil, err
 	}
+	return p, nil
+}
 
-	registry := client.NewSchemaRegistryClient("http://host.docker.internal:8081")
-	schemaID, err := registry.GetSchemaID(topic, schema)
+// NewKafkaProducerFromSync sends through an existing sarama producer with a
+// schema registered under schemaID, e.g. sarama's mocks in tests.
+func NewKafkaProducerFromSync(producer sarama.SyncProducer, topic string, schema string, schemaID int, dep *resilience.Dependency) (*KafkaProducer, error) {
+	codec, err := goavro.NewCodec(schema)
 	if err != nil {
-		log.Printf("Ошбика получения SchemaID: %v", err)
 		return nil, err
 	}
-
 	return &KafkaProducer{
 		producer: producer,
 		codec:    codec,
-		registry: registry,
+		dep:      dep,
 		topic:    topic,
 		schemaID: schemaID,
 	}, nil
@@ -81,28 +115,83 @@ func createAvroPaymentDate(paymentDate *int64) interface{} {
 	}
 }
 
-func (p *KafkaProducer) SendStatusEvent(event ApplicationStatusEvent) error {
-	header := createConfluentHeader(p.schemaID)
-	avroData, err := p.codec.BinaryFromNative(nil, map[string]interface{}{
+func createAvroReason(reason *string) interface{} {
+	if reason == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"string": *reason,
+	}
+}
+
+// statusEventNative converts the event to goavro's native form of the v2 and
+// v3 schemas.
+func statusEventNative(event ApplicationStatusEvent) (map[string]interface{}, error) {
+	disbursementAmount, err := toAvroDecimal(event.AgreementDetails.DisbursementAmount)
+	if err != nil {
+		return nil, fmt.Errorf("disbursement_amount: %w", err)
+	}
+	originationAmount, err := toAvroDecimal(event.AgreementDetails.OriginationAmount)
+	if err != nil {
+		return nil, fmt.Errorf("origination_amount: %w", err)
+	}
+	interest, err := toAvroDecimal(event.AgreementDetails.Interest)
+	if err != nil {
+		return nil, fmt.Errorf("interest: %w", err)
+	}
+
+	return map[string]interface{}{
 		"message_id":     uuid.New().String(),
 		"event_type":     event.EventType,
 		"timestamp":      time.Now().UnixMilli(),
 		"application_id": event.ApplicationID,
+		"cancel_reason":  createAvroReason(event.CancelReason),
+		"reject_reason":  createAvroReason(event.RejectReason),
 		"agreement_details": map[string]interface{}{
 			"application_id":      event.ApplicationID,
 			"client_id":           event.AgreementDetails.ClientID,
-			"disbursement_amount": event.AgreementDetails.DisbursementAmount,
-			"origination_amount":  event.AgreementDetails.OriginationAmount,
+			"disbursement_amount": disbursementAmount,
+			"origination_amount":  originationAmount,
 			"to_bank_account_id":  event.AgreementDetails.ToBankAccountID,
 			"term":                event.AgreementDetails.Term,
-			"interest":            event.AgreementDetails.Interest,
+			"interest":            interest,
 			"product_code":        event.AgreementDetails.ProductCode,
 			"product_version":     event.AgreementDetails.ProductVersion,
+			"currency":            event.AgreementDetails.Currency,
 			"payment_date":        createAvroPaymentDate(event.AgreementDetails.PaymentDate),
 		},
-	})
+	}, nil
+}
+
+// SendStatusEvent publishes the event under a producer span and passes the
+// trace on to consumers in the record headers.
+func (p *KafkaProducer) SendStatusEvent(ctx context.Context, event ApplicationStatusEvent) (err error) {
+	ctx, span := tracer.Start(ctx, p.topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationPublish,
+			semconv.MessagingDestinationName(p.topic),
+			semconv.MessagingKafkaMessageKey(event.ApplicationID),
+			attribute.String("app.event_type", event.EventType),
+		),
+	)
+	defer func() {
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+	}()
+
+	native, err := statusEventNative(event)
+	if err != nil {
+		return err
+	}
+	header := createConfluentHeader(p.schemaID)
+	avroData, err := p.codec.BinaryFromNative(nil, native)
 	if err != nil {
-		log.Printf("Ошибка сериализации Avro: %v", err)
+		logger.FromContext(ctx).Error("Ошибка сериализации Avro", zap.Error(err))
 		return err
 	}
 
@@ -112,19 +201,34 @@ func (p *KafkaProducer) SendStatusEvent(event ApplicationStatusEvent) error {
 		Key:   sarama.StringEncoder(event.ApplicationID),
 		Value: sarama.ByteEncoder(payload),
 	}
+	otel.GetTextMapPropagator().Inject(ctx, producerCarrier{msg: msg})
 
-	partition, offset, err := p.producer.SendMessage(msg)
+	var partition int32
+	var offset int64
+	err = p.dep.Call(ctx, false, func(context.Context) error {
+		var err error
+		partition, offset, err = p.producer.SendMessage(msg)
+		return err
+	})
 
 	if err != nil {
-		log.Printf("Ошибка отправки сообщения в Kafka: %v", err)
+		logger.FromContext(ctx).Error("Ошибка отправки сообщения в Kafka", zap.Error(err))
 		return err
 	}
 
-	log.Printf("Отправлено сообщение: partition=%d, offset=%d", partition, offset)
+	span.SetAttributes(
+		semconv.MessagingKafkaDestinationPartition(int(partition)),
+		semconv.MessagingKafkaMessageOffset(int(offset)),
+	)
+	logger.FromContext(ctx).Info("Отправлено сообщение", zap.Int32("partition", partition), zap.Int64("offset", offset))
 
 	return nil
 }
 
+func (p *KafkaProducer) Close() error {
+	return p.producer.Close()
+}
+
 func createConfluentHeader(schemaID int) []byte {
 	header := make([]byte, 5)
 	header[0] = 0x0 // Magic byte
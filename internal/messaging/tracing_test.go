@@ -0,0 +1,215 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/client"
+	"github.com/Andronzi/credit-origination/internal/resilience"
+	"github.com/IBM/sarama"
+	"github.com/shopspring/decimal"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const testSchemaID = 7
+
+// capturingProducer keeps the messages it is asked to send.
+type capturingProducer struct {
+	sarama.SyncProducer
+	sent []*sarama.ProducerMessage
+}
+
+func (p *capturingProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.sent = append(p.sent, msg)
+	return 0, int64(len(p.sent)), nil
+}
+
+type spanHandler struct {
+	err  error
+	seen trace.SpanContext
+}
+
+func (h *spanHandler) Name() string { return "test" }
+
+func (h *spanHandler) Handle(ctx context.Context, _ *sarama.ConsumerMessage) error {
+	h.seen = trace.SpanContextFromContext(ctx)
+	return h.err
+}
+
+var (
+	tracingOnce    sync.Once
+	tracedRecorder = tracetest.NewSpanRecorder()
+)
+
+// useTestTracing installs the recording provider once: the package tracer is
+// bound to the first global provider and does not follow later ones. The
+// returned function lists the spans ended since the call.
+func useTestTracing(t *testing.T) func() []sdktrace.ReadOnlySpan {
+	t.Helper()
+	tracingOnce.Do(func() {
+		otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tracedRecorder)))
+		otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	})
+	before := len(tracedRecorder.Ended())
+	return func() []sdktrace.ReadOnlySpan {
+		return tracedRecorder.Ended()[before:]
+	}
+}
+
+func newTracingTestPipeline(t *testing.T) (*KafkaProducer, *capturingProducer, *EventDecoder) {
+	t.Helper()
+	schema, err := os.ReadFile("../../schemas/avro/application/v3/ApplicationEvent.avsc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"schema": string(schema)})
+	}))
+	t.Cleanup(registry.Close)
+
+	dependencies, err := resilience.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	settings := resilience.Settings{Retry: resilience.RetryPolicy{Attempts: 1}}
+	capture := &capturingProducer{}
+	producer, err := NewKafkaProducerFromSync(capture, "application", string(schema), testSchemaID,
+		dependencies.Register("kafka", settings))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoder := NewEventDecoder(client.NewSchemaRegistryClient(registry.URL,
+		resilience.NewHTTPClient(dependencies.Register("schema-registry", settings))))
+	return producer, capture, decoder
+}
+
+func testStatusEvent() ApplicationStatusEvent {
+	return ApplicationStatusEvent{
+		ApplicationID: "550e8400-e29b-41d4-a716-446655440000",
+		EventType:     "SCORING",
+		AgreementDetails: AgreementDetails{
+			ApplicationID:      "550e8400-e29b-41d4-a716-446655440000",
+			DisbursementAmount: decimal.NewFromInt(1000),
+			OriginationAmount:  decimal.NewFromInt(1000),
+			Interest:           decimal.NewFromInt(12),
+		},
+	}
+}
+
+// deliver turns a produced message into the one a consumer receives.
+func deliver(msg *sarama.ProducerMessage) *sarama.ConsumerMessage {
+	key, _ := msg.Key.Encode()
+	value, _ := msg.Value.Encode()
+	consumed := &sarama.ConsumerMessage{Topic: msg.Topic, Key: key, Value: value}
+	for _, header := range msg.Headers {
+		consumed.Headers = append(consumed.Headers, &sarama.RecordHeader{Key: header.Key, Value: header.Value})
+	}
+	return consumed
+}
+
+func endedSpan(t *testing.T, ended func() []sdktrace.ReadOnlySpan, name string) sdktrace.ReadOnlySpan {
+	t.Helper()
+	for _, span := range ended() {
+		if span.Name() == name {
+			return span
+		}
+	}
+	t.Fatalf("no ended span %q", name)
+	return nil
+}
+
+func TestTraceContextCrossesKafka(t *testing.T) {
+	tests := []struct {
+		name       string
+		handlerErr error
+		wantStatus codes.Code
+	}{
+		{"handler succeeds", nil, codes.Unset},
+		{"handler fails", errors.New("handler failed"), codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ended := useTestTracing(t)
+			producer, capture, decoder := newTracingTestPipeline(t)
+
+			ctx, rpc := otel.Tracer("test").Start(context.Background(), "rpc")
+			if err := producer.SendStatusEvent(ctx, testStatusEvent()); err != nil {
+				t.Fatal(err)
+			}
+			rpc.End()
+			if len(capture.sent) != 1 {
+				t.Fatalf("sent %d messages, want 1", len(capture.sent))
+			}
+			msg := capture.sent[0]
+			if carrier := (producerCarrier{msg: msg}); carrier.Get("traceparent") == "" {
+				t.Fatalf("message headers %v carry no traceparent", carrier.Keys())
+			}
+
+			handler := &spanHandler{err: tt.handlerErr}
+			consumer := consumerHandler{decoder: decoder, groupID: "group", handlers: []MessageHandler{handler}}
+			// Контекст сессии не несёт трассу: она приходит только из заголовков.
+			consumer.process(context.Background(), deliver(msg))
+
+			publish := endedSpan(t, ended, "application publish")
+			process := endedSpan(t, ended, "application process")
+			handle := endedSpan(t, ended, "handle test")
+
+			traceID := rpc.SpanContext().TraceID()
+			for _, span := range []sdktrace.ReadOnlySpan{publish, process, handle} {
+				if span.SpanContext().TraceID() != traceID {
+					t.Fatalf("span %q is in trace %s, want %s", span.Name(), span.SpanContext().TraceID(), traceID)
+				}
+			}
+			if publish.SpanKind() != trace.SpanKindProducer || process.SpanKind() != trace.SpanKindConsumer {
+				t.Fatalf("span kinds = %s, %s; want producer, consumer", publish.SpanKind(), process.SpanKind())
+			}
+			if publish.Parent().SpanID() != rpc.SpanContext().SpanID() {
+				t.Fatal("publish span is not a child of the RPC span")
+			}
+			if process.Parent().SpanID() != publish.SpanContext().SpanID() || !process.Parent().IsRemote() {
+				t.Fatal("process span does not continue the publish span from the headers")
+			}
+			if handle.Parent().SpanID() != process.SpanContext().SpanID() {
+				t.Fatal("handler span is not a child of the process span")
+			}
+			if handler.seen.SpanID() != handle.SpanContext().SpanID() {
+				t.Fatal("the handler must see its span in the context")
+			}
+			if handle.Status().Code != tt.wantStatus || process.Status().Code != tt.wantStatus {
+				t.Fatalf("statuses = %s, %s; want %s", handle.Status().Code, process.Status().Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestMessageWithoutTraceStartsNewTrace(t *testing.T) {
+	ended := useTestTracing(t)
+	producer, capture, decoder := newTracingTestPipeline(t)
+	if err := producer.SendStatusEvent(context.Background(), testStatusEvent()); err != nil {
+		t.Fatal(err)
+	}
+	msg := deliver(capture.sent[0])
+	msg.Headers = nil
+
+	consumer := consumerHandler{decoder: decoder, groupID: "group", handlers: []MessageHandler{&spanHandler{}}}
+	consumer.process(context.Background(), msg)
+
+	process := endedSpan(t, ended, "application process")
+	if process.Parent().IsValid() {
+		t.Fatalf("process span has parent %s, want a root span", process.Parent().SpanID())
+	}
+	if process.SpanContext().TraceID() == endedSpan(t, ended, "application publish").SpanContext().TraceID() {
+		t.Fatal("a message without headers must not join the producer's trace")
+	}
+}
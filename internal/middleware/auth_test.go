@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const testIssuer = "credit-origination"
+
+var testSecret = []byte("test-secret")
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestAuthInterceptorTakesCallerFromVerifiedToken(t *testing.T) {
+	verifier, err := NewTokenVerifier(testSecret, testIssuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := jwt.RegisteredClaims{
+		Subject:   "alice",
+		Issuer:    testIssuer,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	expired := valid
+	expired.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	foreign := valid
+	foreign.Issuer = "someone-else"
+	noSubject := valid
+	noSubject.Subject = ""
+	noExpiry := valid
+	noExpiry.ExpiresAt = nil
+
+	tests := []struct {
+		name   string
+		md     metadata.MD
+		caller string
+		code   codes.Code
+	}{
+		{"valid token", metadata.Pairs("authorization", "Bearer "+signTestToken(t, jwt.SigningMethodHS256, testSecret, valid)), "alice", codes.OK},
+		{"no token is anonymous", metadata.MD{}, "", codes.OK},
+		{"client id header is ignored", metadata.Pairs("x-client-id", "alice"), "", codes.OK},
+		{"not a bearer token", metadata.Pairs("authorization", "Basic YWxpY2U6"), "", codes.Unauthenticated},
+		{"wrong key", metadata.Pairs("authorization", "Bearer "+signTestToken(t, jwt.SigningMethodHS256, []byte("other"), valid)), "", codes.Unauthenticated},
+		{"unsigned", metadata.Pairs("authorization", "Bearer "+signTestToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid)), "", codes.Unauthenticated},
+		{"expired", metadata.Pairs("authorization", "Bearer "+signTestToken(t, jwt.SigningMethodHS256, testSecret, expired)), "", codes.Unauthenticated},
+		{"no expiry", metadata.Pairs("authorization", "Bearer "+signTestToken(t, jwt.SigningMethodHS256, testSecret, noExpiry)), "", codes.Unauthenticated},
+		{"other issuer", metadata.Pairs("authorization", "Bearer "+signTestToken(t, jwt.SigningMethodHS256, testSecret, foreign)), "", codes.Unauthenticated},
+		{"no subject", metadata.Pairs("authorization", "Bearer "+signTestToken(t, jwt.SigningMethodHS256, testSecret, noSubject)), "", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var caller string
+			handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+				caller, _ = Principal(ctx)
+				return nil, nil
+			}
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			_, err := AuthInterceptor(verifier)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testMethod}, handler)
+			if status.Code(err) != tt.code {
+				t.Fatalf("code = %s, want %s (%v)", status.Code(err), tt.code, err)
+			}
+			if caller != tt.caller {
+				t.Fatalf("caller = %q, want %q", caller, tt.caller)
+			}
+		})
+	}
+}
+
+func TestStreamAuthInterceptorRejectsInvalidToken(t *testing.T) {
+	verifier, err := NewTokenVerifier(testSecret, testIssuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer garbage"))
+	called := false
+	err = StreamAuthInterceptor(verifier)(nil, &testServerStream{ctx: ctx}, &grpc.StreamServerInfo{},
+		func(interface{}, grpc.ServerStream) error {
+			called = true
+			return nil
+		})
+	if status.Code(err) != codes.Unauthenticated || called {
+		t.Fatalf("err = %v, handler called = %v; want Unauthenticated before the handler", err, called)
+	}
+}
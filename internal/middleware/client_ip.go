@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+type clientIPKey struct{}
+
+// ClientIP returns the address of the client found by ClientIPInterceptor,
+// "" if unknown.
+func ClientIP(ctx context.Context) string {
+	ip, _ := ctx.Value(clientIPKey{}).(string)
+	return ip
+}
+
+// ClientIPInterceptor finds the client address of the request. It is the
+// peer address unless the peer is one of trusted proxies; then
+// x-forwarded-for is read from the right, each trusted hop vouching for the
+// one before it, up to the first address that is not a trusted proxy.
+// Entries to the left of it were written by the client and are ignored.
+func ClientIPInterceptor(trusted []netip.Prefix) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return handler(context.WithValue(ctx, clientIPKey{}, clientIP(ctx, trusted)), req)
+	}
+}
+
+func clientIP(ctx context.Context, trusted []netip.Prefix) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return host
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	var hops []string
+	for _, value := range md.Get("x-forwarded-for") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0 && isTrusted(ip, trusted); i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			// Доверенный прокси такое не запишет, значит запись сделал клиент.
+			break
+		}
+		ip = hop
+	}
+	return ip.Unmap().String()
+}
+
+func isTrusted(ip netip.Addr, trusted []netip.Prefix) bool {
+	ip = ip.Unmap()
+	for _, prefix := range trusted {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"testing"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIPTrustsForwardedHeadersOnlyFromProxies(t *testing.T) {
+	trusted := []netip.Prefix{netip.MustParsePrefix("127.0.0.0/8"), netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name      string
+		peer      string
+		forwarded []string
+		want      string
+	}{
+		{"direct client", "203.0.113.7:5000", nil, "203.0.113.7"},
+		{"direct client forges header", "203.0.113.7:5000", []string{"198.51.100.1"}, "203.0.113.7"},
+		{"gateway appends client", "127.0.0.1:40000", []string{"203.0.113.7"}, "203.0.113.7"},
+		{"client forges header behind gateway", "127.0.0.1:40000", []string{"198.51.100.1, 203.0.113.7"}, "203.0.113.7"},
+		{"chain of trusted proxies", "127.0.0.1:40000", []string{"198.51.100.1, 203.0.113.7, 10.1.2.3"}, "203.0.113.7"},
+		{"separate header values", "127.0.0.1:40000", []string{"198.51.100.1", "203.0.113.7"}, "203.0.113.7"},
+		{"garbage from client", "127.0.0.1:40000", []string{"not-an-ip"}, "127.0.0.1"},
+		{"only trusted hops", "127.0.0.1:40000", []string{"10.1.2.3"}, "10.1.2.3"},
+		{"mapped ipv4 peer", "[::ffff:203.0.113.7]:5000", []string{"198.51.100.1"}, "203.0.113.7"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := net.ResolveTCPAddr("tcp", tt.peer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+			md := metadata.MD{}
+			for _, value := range tt.forwarded {
+				md.Append("x-forwarded-for", value)
+			}
+			ctx = metadata.NewIncomingContext(ctx, md)
+
+			if got := clientIP(ctx, trusted); got != tt.want {
+				t.Fatalf("client IP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
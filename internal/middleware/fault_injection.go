@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/Andronzi/credit-origination/internal/chaos"
+	"google.golang.org/grpc"
+)
+
+func FaultInjectionInterceptor(injector *chaos.Injector) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := injector.Inject(ctx, chaos.TargetGRPC, info.FullMethod, chaos.IncomingHeaders(ctx)); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
@@ -2,11 +2,14 @@ package middleware
 
 import (
 	"context"
-	"os"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/Andronzi/credit-origination/pkg/logger"
-	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -16,87 +19,211 @@ import (
 	"google.golang.org/protobuf/types/known/anypb"
 )
 
-type RedisCache struct {
-	client *redis.Client
-}
+const (
+	idempotencyHeader = "Idempotency-key"
+	anonymousCaller   = "anonymous"
+)
 
-func NewRedisCache(addr string) *RedisCache {
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     addr,
-		Password: os.Getenv("REDIS_PASSWORD"),
-	})
-	return &RedisCache{client: rdb}
+type IdempotencyConfig struct {
+	// TTL of a recorded result when the method has no entry in MethodTTL.
+	DefaultTTL time.Duration
+	MethodTTL  map[string]time.Duration
+	// LockTTL bounds how long an in-progress marker lives if the replica
+	// executing the request dies before completing it.
+	LockTTL time.Duration
+	// WaitTimeout is how long a concurrent duplicate waits for the first
+	// request before it is rejected with codes.Aborted.
+	WaitTimeout  time.Duration
+	PollInterval time.Duration
 }
 
-func (c *RedisCache) GetBytes(key string) ([]byte, bool) {
-	val, err := c.client.Get(context.Background(), key).Bytes()
-	if err == redis.Nil {
-		return nil, false
-	} else if err != nil {
-		logger.Logger.Error("Redis get error", zap.Error(err))
-		return nil, false
+func (c IdempotencyConfig) ttlFor(method string) time.Duration {
+	if ttl, ok := c.MethodTTL[method]; ok {
+		return ttl
 	}
-	return val, true
+	return c.DefaultTTL
 }
 
-func (c *RedisCache) SetBytes(key string, value []byte, ttl time.Duration) {
-	err := c.client.Set(context.Background(), key, value, ttl).Err()
-	if err != nil {
-		logger.Logger.Error("Redis set error", zap.Error(err))
-	}
+// Ошибки с этими кодами детерминированы: повтор того же запроса приведёт к тому же
+// результату, поэтому их сохраняем. Остальные (Internal, Unavailable, ...) считаем
+// временными и отпускаем ключ, чтобы клиент мог повторить запрос.
+var recordableCodes = map[codes.Code]bool{
+	codes.InvalidArgument:    true,
+	codes.NotFound:           true,
+	codes.AlreadyExists:      true,
+	codes.PermissionDenied:   true,
+	codes.FailedPrecondition: true,
+	codes.OutOfRange:         true,
+	codes.Unimplemented:      true,
 }
 
-var idempotencyCache = NewRedisCache(os.Getenv("REDIS_ADDR"))
+func NewIdempotencyInterceptor(store IdempotencyStore, cfg IdempotencyConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, ok := metadata.FromIncomingContext(ctx)
+		if !ok {
+			return nil, status.Error(codes.InvalidArgument, "metadata is required")
+		}
 
-func IdempotencyInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return nil, status.Error(codes.InvalidArgument, "metadata is required")
-	}
+		keys := md.Get(idempotencyHeader)
+		if len(keys) == 0 || keys[0] == "" {
+			return handler(ctx, req)
+		}
 
-	keys := md.Get("Idempotency-key")
-	if len(keys) == 0 {
-		return handler(ctx, req)
-	}
-	key := keys[0]
+		caller := anonymousCaller
+		if principal, ok := Principal(ctx); ok {
+			caller = principal
+		}
+		key := idempotencyStoreKey(info.FullMethod, caller, keys[0])
+
+		fingerprint, err := requestFingerprint(req)
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to fingerprint request", zap.String("key", key), zap.Error(err))
+			return nil, status.Error(codes.Internal, "failed to process idempotency key")
+		}
 
-	logger.Logger.Info("Get idempotency key", zap.String("key", key))
+		token := uuid.NewString()
+		locked, err := store.Lock(ctx, key, &IdempotencyRecord{
+			State:       IdempotencyInProgress,
+			Token:       token,
+			Fingerprint: fingerprint,
+		}, cfg.LockTTL)
+		if err != nil {
+			logger.FromContext(ctx).Error("Idempotency store lock error", zap.String("key", key), zap.Error(err))
+			return nil, status.Error(codes.Unavailable, "idempotency store unavailable")
+		}
 
-	if cached, ok := idempotencyCache.GetBytes(key); ok {
-		logger.Logger.Info("Returning cached response", zap.String("key", key))
+		if !locked {
+			record, err := waitForIdempotencyRecord(ctx, store, key, cfg)
+			if err != nil {
+				return nil, err
+			}
+			if record.Fingerprint != fingerprint {
+				return nil, status.Error(codes.InvalidArgument, "idempotency key was already used with a different request")
+			}
+
+			logger.FromContext(ctx).Info("Returning recorded response", zap.String("key", key))
+			return replayIdempotencyRecord(ctx, record)
+		}
 
-		var anyResp anypb.Any
-		if err := proto.Unmarshal(cached, &anyResp); err != nil {
-			logger.Logger.Error("Failed to unmarshal Any response", zap.Error(err))
-			return handler(ctx, req)
+		res, handlerErr := handler(ctx, req)
+
+		record := &IdempotencyRecord{
+			State:       IdempotencyCompleted,
+			Token:       token,
+			Fingerprint: fingerprint,
+		}
+		if handlerErr != nil {
+			st := status.Convert(handlerErr)
+			if !recordableCodes[st.Code()] {
+				if err := store.Release(context.WithoutCancel(ctx), key, token); err != nil {
+					logger.FromContext(ctx).Error("Failed to release idempotency key", zap.String("key", key), zap.Error(err))
+				}
+				return res, handlerErr
+			}
+			record.Code = uint32(st.Code())
+			record.Message = st.Message()
+		} else {
+			data, err := marshalIdempotencyResponse(res)
+			if err != nil {
+				logger.FromContext(ctx).Error("Failed to marshal response", zap.String("key", key), zap.Error(err))
+				if err := store.Release(context.WithoutCancel(ctx), key, token); err != nil {
+					logger.FromContext(ctx).Error("Failed to release idempotency key", zap.String("key", key), zap.Error(err))
+				}
+				return res, nil
+			}
+			record.Response = data
 		}
 
-		resp, err := anyResp.UnmarshalNew()
-		if err != nil {
-			logger.Logger.Error("Failed to unpack Any response", zap.Error(err))
-			return handler(ctx, req)
+		err = store.Complete(context.WithoutCancel(ctx), key, record, cfg.ttlFor(info.FullMethod))
+		switch {
+		case errors.Is(err, ErrIdempotencyLockLost):
+			// Запрос шёл дольше LockTTL, ключ мог занять повтор; его запись не трогаем.
+			logger.FromContext(ctx).Warn("Idempotency lock expired before the result was recorded",
+				zap.String("key", key),
+				zap.Duration("lock_ttl", cfg.LockTTL),
+			)
+		case err != nil:
+			logger.FromContext(ctx).Error("Failed to record idempotency result", zap.String("key", key), zap.Error(err))
+		default:
+			logger.FromContext(ctx).Info("Successfully set idempotency data by key", zap.String("key", key))
 		}
 
-		return resp, nil
+		return res, handlerErr
 	}
+}
 
-	res, err := handler(ctx, req)
-	if err == nil {
-		anyRes, err := anypb.New(res.(proto.Message))
-		if err != nil {
-			logger.Logger.Error("Failed to pack response to Any", zap.Error(err))
-			return res, nil
+func idempotencyStoreKey(method, caller, key string) string {
+	return fmt.Sprintf("idempotency:%s:%s:%s", method, caller, key)
+}
+
+func requestFingerprint(req interface{}) (string, error) {
+	msg, ok := req.(proto.Message)
+	if !ok {
+		return "", fmt.Errorf("unexpected request type %T", req)
+	}
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func waitForIdempotencyRecord(ctx context.Context, store IdempotencyStore, key string, cfg IdempotencyConfig) (*IdempotencyRecord, error) {
+	deadline := time.Now().Add(cfg.WaitTimeout)
+	for {
+		record, err := store.Get(ctx, key)
+		switch {
+		case errors.Is(err, ErrIdempotencyRecordNotFound):
+			// Первый запрос завершился временной ошибкой и отпустил ключ.
+			return nil, status.Error(codes.Aborted, "concurrent request with the same idempotency key failed, retry")
+		case err != nil:
+			logger.FromContext(ctx).Error("Idempotency store get error", zap.String("key", key), zap.Error(err))
+			return nil, status.Error(codes.Unavailable, "idempotency store unavailable")
+		case record.State == IdempotencyCompleted:
+			return record, nil
 		}
 
-		data, err := proto.Marshal(anyRes)
-		if err != nil {
-			logger.Logger.Error("Failed to marshal Any response", zap.Error(err))
-			return res, nil
+		if time.Now().After(deadline) {
+			return nil, status.Error(codes.Aborted, "request with the same idempotency key is in progress")
 		}
 
-		idempotencyCache.SetBytes(key, data, 24*time.Hour)
-		logger.Logger.Info("Successfully set idempotency data by key", zap.String("key", key))
+		select {
+		case <-ctx.Done():
+			return nil, status.FromContextError(ctx.Err()).Err()
+		case <-time.After(cfg.PollInterval):
+		}
+	}
+}
+
+func replayIdempotencyRecord(ctx context.Context, record *IdempotencyRecord) (interface{}, error) {
+	if codes.Code(record.Code) != codes.OK {
+		return nil, status.Error(codes.Code(record.Code), record.Message)
+	}
+
+	var anyResp anypb.Any
+	if err := proto.Unmarshal(record.Response, &anyResp); err != nil {
+		logger.FromContext(ctx).Error("Failed to unmarshal Any response", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to restore recorded response")
+	}
+
+	resp, err := anyResp.UnmarshalNew()
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to unpack Any response", zap.Error(err))
+		return nil, status.Error(codes.Internal, "failed to restore recorded response")
 	}
 
-	return res, err
+	return resp, nil
+}
+
+func marshalIdempotencyResponse(res interface{}) ([]byte, error) {
+	msg, ok := res.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("unexpected response type %T", res)
+	}
+	anyRes, err := anypb.New(msg)
+	if err != nil {
+		return nil, err
+	}
+	return proto.Marshal(anyRes)
 }
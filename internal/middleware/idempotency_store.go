@@ -0,0 +1,219 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+type IdempotencyState string
+
+const (
+	IdempotencyInProgress IdempotencyState = "IN_PROGRESS"
+	IdempotencyCompleted  IdempotencyState = "COMPLETED"
+)
+
+// IdempotencyRecord is what is stored under an idempotency key. While the
+// handler is running only State, Token and Fingerprint are set; once it
+// finishes either Response (packed anypb.Any) or the error Code/Message is
+// recorded so that retries get exactly the same outcome.
+type IdempotencyRecord struct {
+	State       IdempotencyState `json:"state"`
+	Token       string           `json:"token"`
+	Fingerprint string           `json:"fingerprint"`
+	Response    []byte           `json:"response,omitempty"`
+	Code        uint32           `json:"code"`
+	Message     string           `json:"message,omitempty"`
+}
+
+// IdempotencyStore keeps idempotency records. Lock must be atomic: only one
+// caller can acquire an absent key, everyone else gets the current record.
+// Complete and Release act only while the key is still locked with the
+// record's token, so a request whose lock expired cannot touch the record of
+// the request that took the key over.
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) (*IdempotencyRecord, error)
+	Lock(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (bool, error)
+	Complete(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error
+	Release(ctx context.Context, key string, token string) error
+}
+
+var (
+	ErrIdempotencyRecordNotFound = errors.New("idempotency record not found")
+	// ErrIdempotencyLockLost is returned by Complete when the lock expired
+	// and the key is now absent or owned by another request.
+	ErrIdempotencyLockLost = errors.New("idempotency lock lost")
+)
+
+type RedisIdempotencyStore struct {
+	client *redis.Client
+}
+
+var _ IdempotencyStore = (*RedisIdempotencyStore)(nil)
+
+func NewRedisIdempotencyStore(client *redis.Client) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{client: client}
+}
+
+func (s *RedisIdempotencyStore) Get(ctx context.Context, key string) (*IdempotencyRecord, error) {
+	val, err := s.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, ErrIdempotencyRecordNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var record IdempotencyRecord
+	if err := json.Unmarshal(val, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (s *RedisIdempotencyStore) Lock(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (bool, error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+	return s.client.SetNX(ctx, key, data, ttl).Result()
+}
+
+// completeScript replaces the in-progress record only while it holds the
+// token of the request that completes it.
+var completeScript = redis.NewScript(`
+local val = redis.call("GET", KEYS[1])
+if not val then
+	return 0
+end
+local record = cjson.decode(val)
+if record["state"] == "IN_PROGRESS" and record["token"] == ARGV[1] then
+	redis.call("SET", KEYS[1], ARGV[2], "PX", ARGV[3])
+	return 1
+end
+return 0
+`)
+
+func (s *RedisIdempotencyStore) Complete(ctx context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	completed, err := completeScript.Run(ctx, s.client, []string{key}, record.Token, data, ttl.Milliseconds()).Int()
+	if err != nil {
+		return err
+	}
+	if completed == 0 {
+		return ErrIdempotencyLockLost
+	}
+	return nil
+}
+
+// releaseScript deletes the key only if it is still held by the given token,
+// so a slow request whose lock already expired cannot drop somebody else's lock.
+var releaseScript = redis.NewScript(`
+local val = redis.call("GET", KEYS[1])
+if not val then
+	return 0
+end
+local record = cjson.decode(val)
+if record["state"] == "IN_PROGRESS" and record["token"] == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+func (s *RedisIdempotencyStore) Release(ctx context.Context, key string, token string) error {
+	return releaseScript.Run(ctx, s.client, []string{key}, token).Err()
+}
+
+// InMemoryIdempotencyStore is a process-local store for tests and local runs.
+type InMemoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]inMemoryIdempotencyEntry
+	now     func() time.Time
+}
+
+type inMemoryIdempotencyEntry struct {
+	record    IdempotencyRecord
+	expiresAt time.Time
+}
+
+var _ IdempotencyStore = (*InMemoryIdempotencyStore)(nil)
+
+func NewInMemoryIdempotencyStore() *InMemoryIdempotencyStore {
+	return &InMemoryIdempotencyStore{
+		records: make(map[string]inMemoryIdempotencyEntry),
+		now:     time.Now,
+	}
+}
+
+func (s *InMemoryIdempotencyStore) get(key string) (inMemoryIdempotencyEntry, bool) {
+	entry, ok := s.records[key]
+	if !ok {
+		return entry, false
+	}
+	if !entry.expiresAt.IsZero() && !s.now().Before(entry.expiresAt) {
+		delete(s.records, key)
+		return entry, false
+	}
+	return entry, true
+}
+
+func (s *InMemoryIdempotencyStore) set(key string, record *IdempotencyRecord, ttl time.Duration) {
+	entry := inMemoryIdempotencyEntry{record: *record}
+	if ttl > 0 {
+		entry.expiresAt = s.now().Add(ttl)
+	}
+	s.records[key] = entry
+}
+
+func (s *InMemoryIdempotencyStore) Get(_ context.Context, key string) (*IdempotencyRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.get(key)
+	if !ok {
+		return nil, ErrIdempotencyRecordNotFound
+	}
+	record := entry.record
+	return &record, nil
+}
+
+func (s *InMemoryIdempotencyStore) Lock(_ context.Context, key string, record *IdempotencyRecord, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.get(key); ok {
+		return false, nil
+	}
+	s.set(key, record, ttl)
+	return true, nil
+}
+
+func (s *InMemoryIdempotencyStore) Complete(_ context.Context, key string, record *IdempotencyRecord, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.get(key)
+	if !ok || entry.record.State != IdempotencyInProgress || entry.record.Token != record.Token {
+		return ErrIdempotencyLockLost
+	}
+	s.set(key, record, ttl)
+	return nil
+}
+
+func (s *InMemoryIdempotencyStore) Release(_ context.Context, key string, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.get(key)
+	if ok && entry.record.State == IdempotencyInProgress && entry.record.Token == token {
+		delete(s.records, key)
+	}
+	return nil
+}
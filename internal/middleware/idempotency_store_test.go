@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestStore() (*InMemoryIdempotencyStore, *time.Time) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	store := NewInMemoryIdempotencyStore()
+	store.now = func() time.Time { return now }
+	return store, &now
+}
+
+func inProgress(token string) *IdempotencyRecord {
+	return &IdempotencyRecord{State: IdempotencyInProgress, Token: token, Fingerprint: "fp"}
+}
+
+func completed(token string) *IdempotencyRecord {
+	return &IdempotencyRecord{State: IdempotencyCompleted, Token: token, Fingerprint: "fp", Response: []byte(token)}
+}
+
+func TestInMemoryIdempotencyStoreLockIsExclusive(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestStore()
+
+	locked, err := store.Lock(ctx, "key", inProgress("a"), time.Minute)
+	if err != nil || !locked {
+		t.Fatalf("first Lock = %v, %v, want true, nil", locked, err)
+	}
+	locked, err = store.Lock(ctx, "key", inProgress("b"), time.Minute)
+	if err != nil || locked {
+		t.Fatalf("second Lock = %v, %v, want false, nil", locked, err)
+	}
+
+	record, err := store.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.Token != "a" {
+		t.Errorf("token = %q, want a", record.Token)
+	}
+}
+
+func TestInMemoryIdempotencyStoreExpiry(t *testing.T) {
+	ctx := context.Background()
+	store, now := newTestStore()
+
+	if _, err := store.Lock(ctx, "key", inProgress("a"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(time.Minute)
+
+	if _, err := store.Get(ctx, "key"); !errors.Is(err, ErrIdempotencyRecordNotFound) {
+		t.Fatalf("Get after TTL: err = %v, want ErrIdempotencyRecordNotFound", err)
+	}
+	locked, err := store.Lock(ctx, "key", inProgress("b"), time.Minute)
+	if err != nil || !locked {
+		t.Fatalf("Lock after TTL = %v, %v, want true, nil", locked, err)
+	}
+}
+
+func TestInMemoryIdempotencyStoreComplete(t *testing.T) {
+	ctx := context.Background()
+	store, now := newTestStore()
+
+	if _, err := store.Lock(ctx, "key", inProgress("a"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Complete(ctx, "key", completed("a"), time.Hour); err != nil {
+		t.Fatalf("Complete by owner: %v", err)
+	}
+
+	// Запись живёт по TTL результата, а не блокировки.
+	*now = now.Add(30 * time.Minute)
+	record, err := store.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.State != IdempotencyCompleted || string(record.Response) != "a" {
+		t.Errorf("record = %+v, want the completed record of a", record)
+	}
+
+	// Повторный Complete не перезаписывает завершённую запись.
+	if err := store.Complete(ctx, "key", completed("a"), time.Hour); !errors.Is(err, ErrIdempotencyLockLost) {
+		t.Errorf("second Complete: err = %v, want ErrIdempotencyLockLost", err)
+	}
+}
+
+func TestInMemoryIdempotencyStoreCompleteAfterLockExpired(t *testing.T) {
+	ctx := context.Background()
+	store, now := newTestStore()
+
+	if _, err := store.Lock(ctx, "key", inProgress("slow"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(2 * time.Minute)
+	if _, err := store.Lock(ctx, "key", inProgress("retry"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Complete(ctx, "key", completed("slow"), time.Hour); !errors.Is(err, ErrIdempotencyLockLost) {
+		t.Fatalf("Complete by expired owner: err = %v, want ErrIdempotencyLockLost", err)
+	}
+	record, err := store.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if record.State != IdempotencyInProgress || record.Token != "retry" {
+		t.Errorf("record = %+v, want the lock of retry", record)
+	}
+
+	if err := store.Complete(ctx, "key", completed("retry"), time.Hour); err != nil {
+		t.Errorf("Complete by current owner: %v", err)
+	}
+}
+
+func TestInMemoryIdempotencyStoreRelease(t *testing.T) {
+	ctx := context.Background()
+	store, _ := newTestStore()
+
+	if _, err := store.Lock(ctx, "key", inProgress("a"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Release(ctx, "key", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "key"); err != nil {
+		t.Fatalf("Release by another token dropped the lock: %v", err)
+	}
+
+	if err := store.Release(ctx, "key", "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "key"); !errors.Is(err, ErrIdempotencyRecordNotFound) {
+		t.Fatalf("Get after Release: err = %v, want ErrIdempotencyRecordNotFound", err)
+	}
+
+	// Завершённую запись Release не удаляет.
+	if _, err := store.Lock(ctx, "done", inProgress("c"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Complete(ctx, "done", completed("c"), time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Release(ctx, "done", "c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Get(ctx, "done"); err != nil {
+		t.Errorf("Release dropped a completed record: %v", err)
+	}
+}
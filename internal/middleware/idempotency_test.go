@@ -0,0 +1,99 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const testMethod = "/credit.v1.ApplicationService/Create"
+
+func testIdempotencyConfig() IdempotencyConfig {
+	return IdempotencyConfig{
+		DefaultTTL:   time.Hour,
+		MethodTTL:    map[string]time.Duration{testMethod: 72 * time.Hour},
+		LockTTL:      time.Minute,
+		WaitTimeout:  10 * time.Millisecond,
+		PollInterval: time.Millisecond,
+	}
+}
+
+func idempotentCall(t *testing.T, interceptor grpc.UnaryServerInterceptor, key string, req proto.Message, handler grpc.UnaryHandler) (interface{}, error) {
+	t.Helper()
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(idempotencyHeader, key))
+	return interceptor(WithPrincipal(ctx, "svc"), req, &grpc.UnaryServerInfo{FullMethod: testMethod}, handler)
+}
+
+func TestIdempotencyInterceptorReplaysResult(t *testing.T) {
+	store, now := newTestStore()
+	interceptor := NewIdempotencyInterceptor(store, testIdempotencyConfig())
+
+	calls := 0
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		calls++
+		return wrapperspb.String("created"), nil
+	}
+
+	for i := 0; i < 2; i++ {
+		res, err := idempotentCall(t, interceptor, "k1", wrapperspb.String("req"), handler)
+		if err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+		if got := res.(*wrapperspb.StringValue).GetValue(); got != "created" {
+			t.Fatalf("call %d: response %q, want created", i, got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+
+	// Запись метода живёт по его TTL, а не по DefaultTTL.
+	*now = now.Add(2 * time.Hour)
+	if _, err := store.Get(context.Background(), idempotencyStoreKey(testMethod, "svc", "k1")); err != nil {
+		t.Errorf("record expired before the method TTL: %v", err)
+	}
+}
+
+func TestIdempotencyInterceptorRejectsDifferentRequest(t *testing.T) {
+	store, _ := newTestStore()
+	interceptor := NewIdempotencyInterceptor(store, testIdempotencyConfig())
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		return wrapperspb.String("created"), nil
+	}
+
+	if _, err := idempotentCall(t, interceptor, "k1", wrapperspb.String("req"), handler); err != nil {
+		t.Fatal(err)
+	}
+	_, err := idempotentCall(t, interceptor, "k1", wrapperspb.String("other"), handler)
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("code = %v, want InvalidArgument", status.Code(err))
+	}
+}
+
+func TestIdempotencyInterceptorReleasesTransientErrors(t *testing.T) {
+	store, _ := newTestStore()
+	interceptor := NewIdempotencyInterceptor(store, testIdempotencyConfig())
+
+	fail := true
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		if fail {
+			return nil, status.Error(codes.Unavailable, "try later")
+		}
+		return wrapperspb.String("created"), nil
+	}
+
+	if _, err := idempotentCall(t, interceptor, "k1", wrapperspb.String("req"), handler); status.Code(err) != codes.Unavailable {
+		t.Fatalf("code = %v, want Unavailable", status.Code(err))
+	}
+	fail = false
+	if _, err := idempotentCall(t, interceptor, "k1", wrapperspb.String("req"), handler); err != nil {
+		t.Fatalf("retry after a transient error: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+const applicationServicePrefix = "/credit.v1.ApplicationService/"
+
+// LoggingInterceptor attaches log to the request context together with the
+// authenticated caller and the application ID of the request, so
+// logger.FromContext tags every log line of the request. It goes first in
+// the chain.
+func LoggingInterceptor(log *zap.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = requestLogContext(ctx, log)
+		if id := requestApplicationID(info.FullMethod, req); id != "" {
+			ctx = logger.WithApplicationID(ctx, id)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamLoggingInterceptor is LoggingInterceptor for streaming RPCs; the
+// request is read by the handler, so only the caller is known here.
+func StreamLoggingInterceptor(log *zap.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &contextStream{ServerStream: ss, ctx: requestLogContext(ss.Context(), log)})
+	}
+}
+
+// contextStream serves a handler the request context built by an interceptor.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func requestLogContext(ctx context.Context, log *zap.Logger) context.Context {
+	ctx = logger.WithLogger(ctx, log)
+	if caller, ok := Principal(ctx); ok {
+		ctx = logger.WithPrincipal(ctx, caller)
+	}
+	return ctx
+}
+
+// requestApplicationID reads application_id, or id for ApplicationService
+// methods where id is the application.
+func requestApplicationID(method string, req interface{}) string {
+	if r, ok := req.(interface{ GetApplicationId() string }); ok {
+		return r.GetApplicationId()
+	}
+	if r, ok := req.(interface{ GetId() string }); ok && strings.HasPrefix(method, applicationServicePrefix) {
+		return r.GetId()
+	}
+	return ""
+}
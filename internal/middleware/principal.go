@@ -0,0 +1,18 @@
+package middleware
+
+import "context"
+
+type principalKey struct{}
+
+// Principal returns the caller authenticated by AuthInterceptor: the
+// subject of the verified bearer token. Unlike names in request bodies or
+// plain headers it cannot be chosen by the client.
+func Principal(ctx context.Context) (string, bool) {
+	principal, ok := ctx.Value(principalKey{}).(string)
+	return principal, ok && principal != ""
+}
+
+// WithPrincipal returns ctx authenticated as principal.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
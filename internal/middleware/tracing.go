@@ -2,13 +2,18 @@ package middleware
 
 import (
 	"context"
+	"strconv"
 	"time"
 
+	"github.com/Andronzi/credit-origination/config"
+
 	"github.com/Andronzi/credit-origination/pkg/logger"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	otelCodes "go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
@@ -21,25 +26,31 @@ import (
 	"google.golang.org/grpc/status"
 )
 
-func InitTracer() (*sdktrace.TracerProvider, error) {
-	exporter, err := otlptracegrpc.New(context.Background(),
-		otlptracegrpc.WithEndpoint("host.docker.internal:4317"),
-		otlptracegrpc.WithInsecure(),
-	)
+func InitTracer(ctx context.Context, cfg *config.TelemetryConfig) (*sdktrace.TracerProvider, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	attrs := []attribute.KeyValue{semconv.ServiceNameKey.String(cfg.ServiceName)}
+	for key, value := range cfg.ResourceAttributes {
+		attrs = append(attrs, attribute.String(key, value))
+	}
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(newSampler(cfg)),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, attrs...)),
+	}
+
+	exporter, err := newExporter(ctx, cfg)
 	if err != nil {
 		return nil, err
 	}
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter,
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter,
 			sdktrace.WithBatchTimeout(5*time.Second),
 			sdktrace.WithMaxExportBatchSize(10),
-		),
-		sdktrace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String("credit-origination-service"),
-			attribute.String("environment", "development"),
-		)),
-	)
+		))
+	}
+	tp := sdktrace.NewTracerProvider(options...)
 
 	otel.SetTracerProvider(tp)
 	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
@@ -47,32 +58,116 @@ func InitTracer() (*sdktrace.TracerProvider, error) {
 		propagation.Baggage{},
 	))
 
-	logger.Logger.Info("Tracer provider initialized")
+	logger.FromContext(ctx).Info("Tracer provider initialized",
+		zap.String("exporter", cfg.Exporter),
+		zap.String("sampler", cfg.Sampler),
+		zap.String("sampler_arg", cfg.SamplerArg),
+	)
 	return tp, nil
 }
 
-func TracingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-	ctx = extractTraceContext(ctx)
+// newExporter returns nil for the none exporter: spans are still created,
+// so trace IDs reach logs and Kafka headers, but they are not exported.
+func newExporter(ctx context.Context, cfg *config.TelemetryConfig) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case "otlp-grpc":
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		return otlptracegrpc.New(ctx, opts...)
+	case "otlp-http":
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	case "stdout":
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	default:
+		return nil, nil
+	}
+}
 
-	ctx, span := otel.Tracer("credit-origination-service").Start(ctx, info.FullMethod,
-		trace.WithSpanKind(trace.SpanKindServer),
-		trace.WithAttributes(
-			semconv.RPCSystemGRPC,
-			semconv.RPCServiceKey.String("credit.origination.v1"),
-			semconv.RPCMethodKey.String(info.FullMethod),
-		))
+func newSampler(cfg *config.TelemetryConfig) sdktrace.Sampler {
+	ratio, _ := strconv.ParseFloat(cfg.SamplerArg, 64)
+	switch cfg.Sampler {
+	case "always_on":
+		return sdktrace.AlwaysSample()
+	case "always_off":
+		return sdktrace.NeverSample()
+	case "traceidratio":
+		return sdktrace.TraceIDRatioBased(ratio)
+	default:
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))
+	}
+}
+
+func TracingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := startServerSpan(ctx, info.FullMethod)
 	defer span.End()
 
 	startTime := time.Now()
-	logger.Logger.Debug("Starting request",
+	logger.FromContext(ctx).Debug("Starting request",
 		zap.String("method", info.FullMethod),
-		zap.String("trace_id", span.SpanContext().TraceID().String()),
-		zap.String("span_id", span.SpanContext().SpanID().String()),
 	)
 
 	res, err := handler(ctx, req)
 	duration := time.Since(startTime)
+	statusCode := finishServerSpan(span, err)
+
+	logger.FromContext(ctx).Info("Request completed",
+		zap.String("method", info.FullMethod),
+		zap.Duration("duration", duration),
+		zap.String("status", statusCode.String()),
+		zap.Error(err),
+	)
 
+	return res, err
+}
+
+// StreamTracingInterceptor is TracingInterceptor for streaming RPCs: the span
+// covers the whole stream and the handler sees it through Context.
+func StreamTracingInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := startServerSpan(ss.Context(), info.FullMethod)
+	defer span.End()
+
+	startTime := time.Now()
+	err := handler(srv, &tracingStream{ServerStream: ss, ctx: ctx})
+	statusCode := finishServerSpan(span, err)
+
+	logger.FromContext(ctx).Info("Stream completed",
+		zap.String("method", info.FullMethod),
+		zap.Duration("duration", time.Since(startTime)),
+		zap.String("status", statusCode.String()),
+		zap.Error(err),
+	)
+	return err
+}
+
+type tracingStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *tracingStream) Context() context.Context {
+	return s.ctx
+}
+
+func startServerSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	ctx = extractTraceContext(ctx)
+
+	return otel.Tracer("credit-origination-service").Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			semconv.RPCServiceKey.String("credit.origination.v1"),
+			semconv.RPCMethodKey.String(method),
+		))
+}
+
+// finishServerSpan records the call result on span and returns its gRPC code.
+func finishServerSpan(span trace.Span, err error) codes.Code {
 	statusCode := codes.OK
 	otelStatus := otelCodes.Ok
 	var statusMessage string
@@ -96,23 +191,13 @@ func TracingInterceptor(ctx context.Context, req interface{}, info *grpc.UnarySe
 	span.SetAttributes(
 		semconv.RPCGRPCStatusCodeKey.Int64(int64(statusCode)),
 	)
-
-	logger.Logger.Info("Request completed",
-		zap.String("method", info.FullMethod),
-		zap.Duration("duration", duration),
-		zap.String("trace_id", span.SpanContext().TraceID().String()),
-		zap.String("span_id", span.SpanContext().SpanID().String()),
-		zap.String("status", statusCode.String()),
-		zap.Error(err),
-	)
-
-	return res, err
+	return statusCode
 }
 
 func extractTraceContext(ctx context.Context) context.Context {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		logger.Logger.Debug("No metadata in context")
+		logger.FromContext(ctx).Debug("No metadata in context")
 		return ctx
 	}
 
@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamTracingInterceptorTracesStream(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	const method = "/credit.v1.ApplicationService/Watch"
+	var handlerSpan trace.SpanContext
+	handler := func(_ interface{}, ss grpc.ServerStream) error {
+		handlerSpan = trace.SpanContextFromContext(ss.Context())
+		return status.Error(codes.Unavailable, "hub closed")
+	}
+
+	err := StreamTracingInterceptor(nil, &testServerStream{ctx: context.Background()},
+		&grpc.StreamServerInfo{FullMethod: method, IsServerStream: true}, handler)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("err = %v, want the handler error", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("ended spans = %d, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != method || span.SpanKind() != trace.SpanKindServer {
+		t.Fatalf("span = %s %s, want server span %s", span.SpanKind(), span.Name(), method)
+	}
+	if handlerSpan.SpanID() != span.SpanContext().SpanID() {
+		t.Fatal("the handler must see the stream span in its context")
+	}
+	var code int64 = -1
+	for _, attr := range span.Attributes() {
+		if attr.Key == "rpc.grpc.status_code" {
+			code = attr.Value.AsInt64()
+		}
+	}
+	if code != int64(codes.Unavailable) {
+		t.Fatalf("rpc.grpc.status_code = %d, want %d", code, codes.Unavailable)
+	}
+}
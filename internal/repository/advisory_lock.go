@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"gorm.io/gorm"
+)
+
+// AdvisoryLock is a domain.Locker on a Postgres advisory lock.
+type AdvisoryLock struct {
+	db  *gorm.DB
+	key int64
+}
+
+var _ domain.Locker = (*AdvisoryLock)(nil)
+
+func NewAdvisoryLock(db *gorm.DB, key int64) *AdvisoryLock {
+	return &AdvisoryLock{db: db, key: key}
+}
+
+func (l *AdvisoryLock) TryRun(ctx context.Context, fn func(ctx context.Context) error) (bool, error) {
+	acquired := false
+	// Блокировка уровня транзакции снимается сама при rollback или обрыве соединения.
+	err := l.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", l.key).Scan(&acquired).Error; err != nil {
+			return err
+		}
+		if !acquired {
+			return nil
+		}
+		return fn(ctx)
+	})
+	return acquired, err
+}
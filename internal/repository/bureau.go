@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"gorm.io/gorm"
+)
+
+type BureauReportRepo struct {
+	db *gorm.DB
+}
+
+var _ domain.BureauReportRepository = (*BureauReportRepo)(nil)
+
+func NewBureauReportRepo(db *gorm.DB) *BureauReportRepo {
+	return &BureauReportRepo{db: db}
+}
+
+func (r *BureauReportRepo) Save(ctx context.Context, report *domain.BureauReport) error {
+	return conn(ctx, r.db).Create(report).Error
+}
+
+func (r *BureauReportRepo) FindByApplication(ctx context.Context, applicationID string) (*domain.BureauReport, error) {
+	var report domain.BureauReport
+	err := conn(ctx, r.db).First(&report, "application_id = ?", applicationID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrBureauReportNotFound, applicationID)
+	}
+	return &report, err
+}
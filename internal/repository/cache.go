@@ -0,0 +1,313 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"golang.org/x/sync/singleflight"
+)
+
+// CachedCreditRepo is a read-through Redis cache in front of another
+// CreditRepository. Applications are cached by ID; per-user list pages are
+// cached under a per-user version that every write bumps, so stale pages
+// simply stop being addressed instead of being deleted one by one.
+//
+// Reads inside a transaction bypass the cache: they must see the
+// transaction's own writes, and rows they load may still be rolled back.
+type CachedCreditRepo struct {
+	next    domain.CreditRepository
+	client  *redis.Client
+	appTTL  time.Duration
+	listTTL time.Duration
+	group   singleflight.Group
+	hits    metric.Int64Counter
+	misses  metric.Int64Counter
+}
+
+var _ domain.CreditRepository = (*CachedCreditRepo)(nil)
+
+func NewCachedCreditRepo(next domain.CreditRepository, client *redis.Client, appTTL, listTTL time.Duration) *CachedCreditRepo {
+	meter := otel.Meter("credit-origination-service")
+	hits, _ := meter.Int64Counter("repository.cache.hits", metric.WithDescription("Application cache hits"))
+	misses, _ := meter.Int64Counter("repository.cache.misses", metric.WithDescription("Application cache misses"))
+
+	return &CachedCreditRepo{
+		next:    next,
+		client:  client,
+		appTTL:  appTTL,
+		listTTL: listTTL,
+		hits:    hits,
+		misses:  misses,
+	}
+}
+
+type cachedList struct {
+	Applications []*domain.CreditApplication `json:"applications"`
+	TotalCount   int                         `json:"total_count"`
+}
+
+func applicationCacheKey(id string) string {
+	return "applications:id:" + id
+}
+
+func listVersionCacheKey(userID string) string {
+	return "applications:list-version:" + userID
+}
+
+func listCacheKey(userID string, version int64, statuses []domain.ApplicationStatus, offset, limit int) string {
+	names := make([]string, len(statuses))
+	for i, status := range statuses {
+		names[i] = string(status)
+	}
+	slices.Sort(names)
+	return fmt.Sprintf("applications:list:%s:%d:%s:%d:%d", userID, version, strings.Join(names, ","), offset, limit)
+}
+
+func (r *CachedCreditRepo) FindByID(ctx context.Context, id string) (*domain.CreditApplication, error) {
+	if inTx(ctx) {
+		return r.next.FindByID(ctx, id)
+	}
+	key := applicationCacheKey(id)
+
+	var app domain.CreditApplication
+	if r.get(ctx, key, "application", &app) {
+		return &app, nil
+	}
+
+	v, err, _ := r.group.Do(key, func() (interface{}, error) {
+		app, err := r.next.FindByID(ctx, id)
+		if err != nil {
+			return app, err
+		}
+		r.set(ctx, key, app, r.appTTL)
+		return app, nil
+	})
+	found, _ := v.(*domain.CreditApplication)
+	if found == nil {
+		found = &domain.CreditApplication{}
+	}
+	return found, err
+}
+
+// FindByIDs serves what it can from the cache and loads the rest in one query.
+func (r *CachedCreditRepo) FindByIDs(ctx context.Context, ids []string) ([]*domain.CreditApplication, error) {
+	if inTx(ctx) {
+		return r.next.FindByIDs(ctx, ids)
+	}
+	applications := make([]*domain.CreditApplication, 0, len(ids))
+	var missing []string
+	for _, id := range ids {
+		var app domain.CreditApplication
+		if r.get(ctx, applicationCacheKey(id), "application", &app) {
+			applications = append(applications, &app)
+			continue
+		}
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return applications, nil
+	}
+
+	loaded, err := r.next.FindByIDs(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for _, app := range loaded {
+		r.set(ctx, applicationCacheKey(app.ID.String()), app, r.appTTL)
+	}
+	return append(applications, loaded...), nil
+}
+
+func (r *CachedCreditRepo) FindByUserID(ctx context.Context, userID string) (*domain.CreditApplication, error) {
+	return r.next.FindByUserID(ctx, userID)
+}
+
+func (r *CachedCreditRepo) List(ctx context.Context, statuses []domain.ApplicationStatus, offset int, limit int, userID string) ([]*domain.CreditApplication, int, error) {
+	if userID == "" || inTx(ctx) {
+		return r.next.List(ctx, statuses, offset, limit, userID)
+	}
+
+	version, err := r.client.Get(ctx, listVersionCacheKey(userID)).Int64()
+	if err != nil && err != redis.Nil {
+		logger.FromContext(ctx).Error("Redis get list version error", zap.Error(err))
+		return r.next.List(ctx, statuses, offset, limit, userID)
+	}
+	key := listCacheKey(userID, version, statuses, offset, limit)
+
+	var cached cachedList
+	if r.get(ctx, key, "list", &cached) {
+		return cached.Applications, cached.TotalCount, nil
+	}
+
+	v, err, _ := r.group.Do(key, func() (interface{}, error) {
+		apps, total, err := r.next.List(ctx, statuses, offset, limit, userID)
+		if err != nil {
+			return nil, err
+		}
+		list := &cachedList{Applications: apps, TotalCount: total}
+		r.set(ctx, key, list, r.listTTL)
+		return list, nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	list := v.(*cachedList)
+	return list.Applications, list.TotalCount, nil
+}
+
+func (r *CachedCreditRepo) ListCreatedBetween(ctx context.Context, from time.Time, to time.Time, offset int, limit int) ([]*domain.CreditApplication, error) {
+	return r.next.ListCreatedBetween(ctx, from, to, offset, limit)
+}
+
+func (r *CachedCreditRepo) ListByUserOrBankAccount(ctx context.Context, userID string, bankAccountID string, since time.Time, limit int) ([]*domain.CreditApplication, error) {
+	return r.next.ListByUserOrBankAccount(ctx, userID, bankAccountID, since, limit)
+}
+
+func (r *CachedCreditRepo) ListStale(ctx context.Context, filter domain.StaleFilter, limit int) ([]*domain.CreditApplication, error) {
+	return r.next.ListStale(ctx, filter, limit)
+}
+
+func (r *CachedCreditRepo) Export(ctx context.Context, filter domain.ApplicationFilter, batchSize int, fn func([]*domain.CreditApplication) error) error {
+	return r.next.Export(ctx, filter, batchSize, fn)
+}
+
+func (r *CachedCreditRepo) Save(ctx context.Context, app *domain.CreditApplication) error {
+	if err := r.next.Save(ctx, app); err != nil {
+		return err
+	}
+	r.invalidate(ctx, app.ID.String(), app.UserID.String())
+	return nil
+}
+
+func (r *CachedCreditRepo) SaveAll(ctx context.Context, apps []*domain.CreditApplication) error {
+	if err := r.next.SaveAll(ctx, apps); err != nil {
+		return err
+	}
+	for _, app := range apps {
+		r.invalidate(ctx, app.ID.String(), app.UserID.String())
+	}
+	return nil
+}
+
+func (r *CachedCreditRepo) UpdateAll(ctx context.Context, apps []*domain.CreditApplication) error {
+	if err := r.next.UpdateAll(ctx, apps); err != nil {
+		return err
+	}
+	for _, app := range apps {
+		r.invalidate(ctx, app.ID.String(), app.UserID.String())
+	}
+	return nil
+}
+
+func (r *CachedCreditRepo) Update(ctx context.Context, app *domain.CreditApplication) error {
+	if err := r.next.Update(ctx, app); err != nil {
+		return err
+	}
+	r.invalidate(ctx, app.ID.String(), app.UserID.String())
+	return nil
+}
+
+func (r *CachedCreditRepo) UpdateIfCurrent(ctx context.Context, app *domain.CreditApplication, expectedStatus domain.ApplicationStatus, expectedVersion int64) error {
+	if err := r.next.UpdateIfCurrent(ctx, app, expectedStatus, expectedVersion); err != nil {
+		return err
+	}
+	r.invalidate(ctx, app.ID.String(), app.UserID.String())
+	return nil
+}
+
+func (r *CachedCreditRepo) ClearPendingStatus(ctx context.Context, id string) error {
+	userID := r.ownerOf(ctx, id)
+	if err := r.next.ClearPendingStatus(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id, userID)
+	return nil
+}
+
+func (r *CachedCreditRepo) UpdateStatus(ctx context.Context, id string, status domain.ApplicationStatus) error {
+	userID := r.ownerOf(ctx, id)
+	if err := r.next.UpdateStatus(ctx, id, status); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id, userID)
+	return nil
+}
+
+func (r *CachedCreditRepo) Delete(ctx context.Context, id string) error {
+	userID := r.ownerOf(ctx, id)
+	if err := r.next.Delete(ctx, id); err != nil {
+		return err
+	}
+	r.invalidate(ctx, id, userID)
+	return nil
+}
+
+// ownerOf resolves the user of an application for list invalidation when a
+// write only carries the application ID.
+func (r *CachedCreditRepo) ownerOf(ctx context.Context, id string) string {
+	app, err := r.FindByID(ctx, id)
+	if err != nil {
+		return ""
+	}
+	return app.UserID.String()
+}
+
+// invalidate waits for the transaction of ctx to commit: a read between the
+// write and the commit would otherwise cache the old row again.
+func (r *CachedCreditRepo) invalidate(ctx context.Context, id string, userID string) {
+	afterCommit(ctx, func() {
+		pipe := r.client.TxPipeline()
+		pipe.Del(ctx, applicationCacheKey(id))
+		if userID != "" {
+			pipe.Incr(ctx, listVersionCacheKey(userID))
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			logger.FromContext(ctx).Error("Redis cache invalidation error",
+				zap.String("app_id", id),
+				zap.Error(err),
+			)
+		}
+	})
+}
+
+func (r *CachedCreditRepo) get(ctx context.Context, key string, kind string, dst interface{}) bool {
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err != nil {
+		if err != redis.Nil {
+			logger.FromContext(ctx).Error("Redis get error", zap.String("key", key), zap.Error(err))
+		}
+		r.misses.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind)))
+		return false
+	}
+
+	if err := json.Unmarshal(data, dst); err != nil {
+		logger.FromContext(ctx).Error("Failed to decode cached value", zap.String("key", key), zap.Error(err))
+		r.misses.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind)))
+		return false
+	}
+
+	r.hits.Add(ctx, 1, metric.WithAttributes(attribute.String("kind", kind)))
+	return true
+}
+
+func (r *CachedCreditRepo) set(ctx context.Context, key string, value interface{}, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to encode cache value", zap.String("key", key), zap.Error(err))
+		return
+	}
+	if err := r.client.Set(ctx, key, data, ttl).Err(); err != nil {
+		logger.FromContext(ctx).Error("Redis set error", zap.String("key", key), zap.Error(err))
+	}
+}
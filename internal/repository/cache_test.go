@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+// countingRepo serves copies of stored applications and counts the reads
+// that reach it; methods the tests do not use panic on the nil interface.
+type countingRepo struct {
+	domain.CreditRepository
+	apps  map[string]domain.CreditApplication
+	reads int
+}
+
+func newCountingRepo(apps ...*domain.CreditApplication) *countingRepo {
+	r := &countingRepo{apps: make(map[string]domain.CreditApplication)}
+	for _, app := range apps {
+		r.apps[app.ID.String()] = *app
+	}
+	return r
+}
+
+func (r *countingRepo) FindByID(_ context.Context, id string) (*domain.CreditApplication, error) {
+	r.reads++
+	app, ok := r.apps[id]
+	if !ok {
+		return nil, domain.ErrApplicationNotFound
+	}
+	return &app, nil
+}
+
+func (r *countingRepo) List(_ context.Context, _ []domain.ApplicationStatus, _ int, _ int, userID string) ([]*domain.CreditApplication, int, error) {
+	r.reads++
+	var apps []*domain.CreditApplication
+	for _, app := range r.apps {
+		if app.UserID.String() == userID {
+			app := app
+			apps = append(apps, &app)
+		}
+	}
+	return apps, len(apps), nil
+}
+
+func (r *countingRepo) Update(_ context.Context, app *domain.CreditApplication) error {
+	r.apps[app.ID.String()] = *app
+	return nil
+}
+
+func newTestCache(t *testing.T, next domain.CreditRepository) (*CachedCreditRepo, *miniredis.Miniredis) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return NewCachedCreditRepo(next, client, time.Minute, time.Minute), server
+}
+
+// withTestTx marks ctx as inside a transaction without a database; the
+// returned commit runs the hooks registered by afterCommit.
+func withTestTx(ctx context.Context) (context.Context, func()) {
+	state := &txState{}
+	return context.WithValue(ctx, txKey{}, state), func() {
+		for _, hook := range state.afterCommit {
+			hook()
+		}
+	}
+}
+
+func TestCachedFindByIDReadsThrough(t *testing.T) {
+	app := newTestApplication()
+	next := newCountingRepo(app)
+	cache, server := newTestCache(t, next)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		got, err := cache.FindByID(ctx, app.ID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ID != app.ID || got.Status != app.Status {
+			t.Fatalf("FindByID = %s %s, want %s %s", got.ID, got.Status, app.ID, app.Status)
+		}
+	}
+	if next.reads != 1 {
+		t.Fatalf("reads = %d, want one read before the cache is filled", next.reads)
+	}
+	if !server.Exists(applicationCacheKey(app.ID.String())) {
+		t.Fatal("application is not cached")
+	}
+	server.FastForward(2 * time.Minute)
+	if _, err := cache.FindByID(ctx, app.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if next.reads != 2 {
+		t.Fatalf("reads = %d, want a read after the TTL", next.reads)
+	}
+}
+
+func TestCachedFindByIDBypassesCacheInTransaction(t *testing.T) {
+	app := newTestApplication()
+	next := newCountingRepo(app)
+	cache, server := newTestCache(t, next)
+
+	if _, err := cache.FindByID(context.Background(), app.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	changed := *app
+	changed.Status = domain.APPLICATION_CREATED
+	next.apps[app.ID.String()] = changed
+
+	txCtx, _ := withTestTx(context.Background())
+	got, err := cache.FindByID(txCtx, app.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != domain.APPLICATION_CREATED {
+		t.Fatalf("status = %s, a transaction must not read the cached row", got.Status)
+	}
+	server.Del(applicationCacheKey(app.ID.String()))
+
+	if _, err := cache.FindByID(txCtx, app.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if server.Exists(applicationCacheKey(app.ID.String())) {
+		t.Fatal("a read inside a transaction filled the cache")
+	}
+}
+
+func TestCachedUpdateInvalidatesAfterCommit(t *testing.T) {
+	app := newTestApplication()
+	next := newCountingRepo(app)
+	cache, server := newTestCache(t, next)
+	key := applicationCacheKey(app.ID.String())
+
+	if _, err := cache.FindByID(context.Background(), app.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+
+	txCtx, commit := withTestTx(context.Background())
+	changed := *app
+	changed.Status = domain.APPLICATION_CREATED
+	if err := cache.Update(txCtx, &changed); err != nil {
+		t.Fatal(err)
+	}
+	if !server.Exists(key) {
+		t.Fatal("invalidated before commit")
+	}
+	commit()
+	if server.Exists(key) {
+		t.Fatal("still cached after commit")
+	}
+
+	got, err := cache.FindByID(context.Background(), app.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != domain.APPLICATION_CREATED {
+		t.Fatalf("status = %s, want the committed status", got.Status)
+	}
+}
+
+func TestCachedListFollowsUserVersion(t *testing.T) {
+	app := newTestApplication()
+	next := newCountingRepo(app)
+	cache, _ := newTestCache(t, next)
+	ctx := context.Background()
+	userID := app.UserID.String()
+
+	tests := []struct {
+		name  string
+		act   func()
+		reads int
+	}{
+		{"first page loads", func() {}, 1},
+		{"second page is cached", func() {}, 1},
+		{"update bumps the version", func() {
+			if err := cache.Update(ctx, app); err != nil {
+				t.Fatal(err)
+			}
+		}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.act()
+			if _, _, err := cache.List(ctx, nil, 0, 10, userID); err != nil {
+				t.Fatal(err)
+			}
+			if next.reads != tt.reads {
+				t.Fatalf("reads = %d, want %d", next.reads, tt.reads)
+			}
+		})
+	}
+
+	txCtx, _ := withTestTx(ctx)
+	if _, _, err := cache.List(txCtx, nil, 0, 10, userID); err != nil {
+		t.Fatal(err)
+	}
+	if next.reads != 3 {
+		t.Fatalf("reads = %d, a list inside a transaction must read through", next.reads)
+	}
+}
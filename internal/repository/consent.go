@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ConsentRepo struct {
+	db *gorm.DB
+}
+
+var _ domain.ConsentRepository = (*ConsentRepo)(nil)
+
+func NewConsentRepo(db *gorm.DB) *ConsentRepo {
+	return &ConsentRepo{db: db}
+}
+
+func (r *ConsentRepo) Create(ctx context.Context, consent *domain.Consent) error {
+	return conn(ctx, r.db).Create(consent).Error
+}
+
+func (r *ConsentRepo) ListByApplications(ctx context.Context, applicationIDs []string) ([]*domain.Consent, error) {
+	var consents []*domain.Consent
+	if len(applicationIDs) == 0 {
+		return consents, nil
+	}
+	err := conn(ctx, r.db).
+		Where("application_id IN ?", applicationIDs).
+		Order("given_at").
+		Find(&consents).Error
+	return consents, err
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"gorm.io/gorm"
+)
+
+// JobFence is a domain.Fence on the job_fences table. Call it within the
+// transaction of the guarded writes: the row lock serialises leaders.
+type JobFence struct {
+	db *gorm.DB
+}
+
+var _ domain.Fence = (*JobFence)(nil)
+
+func NewJobFence(db *gorm.DB) *JobFence {
+	return &JobFence{db: db}
+}
+
+func (f *JobFence) Check(ctx context.Context, name string, token int64) error {
+	result := conn(ctx, f.db).Exec(`
+		INSERT INTO job_fences (name, token) VALUES (?, ?)
+		ON CONFLICT (name) DO UPDATE SET token = EXCLUDED.token
+		WHERE job_fences.token <= EXCLUDED.token`,
+		name, token,
+	)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s token %d", domain.ErrStaleFencingToken, name, token)
+	}
+	return nil
+}
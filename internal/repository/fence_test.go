@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+)
+
+func TestJobFenceRejectsStaleTokens(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	fence := NewJobFence(db)
+
+	tests := []struct {
+		token int64
+		want  error
+	}{
+		{3, nil},
+		{3, nil},
+		{2, domain.ErrStaleFencingToken},
+		{5, nil},
+		{4, domain.ErrStaleFencingToken},
+	}
+	for _, tt := range tests {
+		if err := fence.Check(ctx, "job", tt.token); !errors.Is(err, tt.want) {
+			t.Fatalf("Check(%d) = %v, want %v", tt.token, err, tt.want)
+		}
+	}
+	if err := fence.Check(ctx, "other-job", 1); err != nil {
+		t.Fatalf("fences of different jobs must not interfere: %v", err)
+	}
+}
+
+func TestJobFenceHoldsNewerLeaderUntilCommit(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	fence, tx := NewJobFence(db), NewTransactor(db)
+
+	checked := make(chan struct{})
+	commit := make(chan struct{})
+	oldDone := make(chan error, 1)
+	go func() {
+		oldDone <- tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := fence.Check(ctx, "job", 1); err != nil {
+				return err
+			}
+			close(checked)
+			<-commit
+			return nil
+		})
+	}()
+	<-checked
+
+	// Новый лидер ждёт, пока старый закончит начатую запись.
+	newDone := make(chan error, 1)
+	go func() {
+		newDone <- tx.WithinTx(ctx, func(ctx context.Context) error {
+			return fence.Check(ctx, "job", 2)
+		})
+	}()
+	select {
+	case err := <-newDone:
+		t.Fatalf("newer leader did not wait for the fenced write: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(commit)
+	if err := <-oldDone; err != nil {
+		t.Fatal(err)
+	}
+	if err := <-newDone; err != nil {
+		t.Fatal(err)
+	}
+
+	if err := fence.Check(ctx, "job", 1); !errors.Is(err, domain.ErrStaleFencingToken) {
+		t.Fatalf("old leader after takeover: %v, want ErrStaleFencingToken", err)
+	}
+}
@@ -0,0 +1,17 @@
+package repository
+
+import "github.com/Andronzi/credit-origination/internal/domain"
+
+// Models lists the gorm models stored by this package, for schema drift
+// checks against the migrations.
+func Models() []interface{} {
+	return []interface{}{
+		&domain.CreditApplication{},
+		&domain.Review{},
+		&domain.ReviewNote{},
+		&domain.ReviewAttachment{},
+		&domain.CounterOffer{},
+		&domain.Consent{},
+		&domain.BureauReport{},
+	}
+}
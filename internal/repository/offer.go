@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"gorm.io/gorm"
+)
+
+type OfferRepo struct {
+	db *gorm.DB
+}
+
+var _ domain.OfferRepository = (*OfferRepo)(nil)
+
+func NewOfferRepo(db *gorm.DB) *OfferRepo {
+	return &OfferRepo{db: db}
+}
+
+func (r *OfferRepo) CreateAll(ctx context.Context, offers []*domain.CounterOffer) error {
+	if len(offers) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Create(offers).Error
+}
+
+func (r *OfferRepo) FindByID(ctx context.Context, id string) (*domain.CounterOffer, error) {
+	var offer domain.CounterOffer
+	err := conn(ctx, r.db).First(&offer, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrOfferNotFound, id)
+	}
+	return &offer, err
+}
+
+func (r *OfferRepo) ListByApplication(ctx context.Context, applicationID string) ([]*domain.CounterOffer, error) {
+	var offers []*domain.CounterOffer
+	err := conn(ctx, r.db).
+		Where("application_id = ?", applicationID).
+		Order("created_at, kind").
+		Find(&offers).Error
+	return offers, err
+}
+
+// MarkAccepted fails with ErrOfferNotAcceptable when another offer of the
+// same application was accepted first. It locks the application row until
+// the caller's transaction ends, so accepts of different offers of one
+// application run one after another and the check sees the earlier accept.
+func (r *OfferRepo) MarkAccepted(ctx context.Context, offer *domain.CounterOffer) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT 1 FROM credit_applications WHERE id = ? FOR UPDATE", offer.ApplicationID).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&domain.CounterOffer{}).
+			Where("id = ? AND NOT EXISTS (?)", offer.ID,
+				tx.Model(&domain.CounterOffer{}).
+					Select("1").
+					Where("application_id = ? AND accepted_at IS NOT NULL", offer.ApplicationID),
+			).
+			Update("accepted_at", offer.AcceptedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return fmt.Errorf("%w: another offer was accepted", domain.ErrOfferNotAcceptable)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestMarkAcceptedSerializesOffersOfOneApplication(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	app := newTestApplication()
+	if err := NewCreditRepo(db).Save(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	offers := NewOfferRepo(db)
+	now := time.Now().UTC()
+	first, second := testOffer(app, domain.OfferLowerAmount, now), testOffer(app, domain.OfferLongerTerm, now)
+	if err := offers.CreateAll(ctx, []*domain.CounterOffer{first, second}); err != nil {
+		t.Fatal(err)
+	}
+	first.AcceptedAt = sql.NullTime{Time: now, Valid: true}
+	second.AcceptedAt = sql.NullTime{Time: now, Valid: true}
+
+	tx := NewTransactor(db)
+	accepted := make(chan struct{})
+	commit := make(chan struct{})
+	firstDone := make(chan error, 1)
+	go func() {
+		firstDone <- tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := offers.MarkAccepted(ctx, first); err != nil {
+				return err
+			}
+			close(accepted)
+			<-commit
+			return nil
+		})
+	}()
+	<-accepted
+
+	// Второе принятие ждёт блокировку заявки и видит первое после коммита.
+	secondDone := make(chan error, 1)
+	go func() {
+		secondDone <- tx.WithinTx(ctx, func(ctx context.Context) error {
+			return offers.MarkAccepted(ctx, second)
+		})
+	}()
+	select {
+	case err := <-secondDone:
+		t.Fatalf("second accept did not wait for the first: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	close(commit)
+
+	if err := <-firstDone; err != nil {
+		t.Fatalf("first accept: %v", err)
+	}
+	if err := <-secondDone; !errors.Is(err, domain.ErrOfferNotAcceptable) {
+		t.Fatalf("second accept error = %v, want ErrOfferNotAcceptable", err)
+	}
+}
+
+func testOffer(app *domain.CreditApplication, kind domain.OfferKind, now time.Time) *domain.CounterOffer {
+	return &domain.CounterOffer{
+		ID:                 uuid.New(),
+		ApplicationID:      app.ID,
+		Kind:               kind,
+		DisbursementAmount: app.DisbursementAmount,
+		OriginationAmount:  app.OriginationAmount,
+		Term:               app.Term,
+		Interest:           app.Interest,
+		MonthlyPayment:     decimal.NewFromInt(9000),
+		DTI:                decimal.RequireFromString("0.45"),
+		CreatedAt:          now,
+	}
+}
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"time"
 
 	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
 type CreditRepo struct {
@@ -17,51 +20,105 @@ type CreditRepo struct {
 var _ domain.CreditRepository = (*CreditRepo)(nil)
 
 func NewCreditRepo(db *gorm.DB) *CreditRepo {
-	db.Logger = db.Logger.LogMode(logger.Info)
 	return &CreditRepo{db: db}
 }
 
 func (r *CreditRepo) Save(ctx context.Context, app *domain.CreditApplication) error {
-	log.Printf("Attempting to save application: %+v", app)
-	return r.db.WithContext(ctx).Create(app).Error
+	logger.FromContext(ctx).Debug("Saving application", zap.String("status", string(app.Status)))
+	return conn(ctx, r.db).Create(app).Error
 }
 
 func (r *CreditRepo) FindByID(ctx context.Context, id string) (*domain.CreditApplication, error) {
 	var app domain.CreditApplication
-	err := r.db.WithContext(ctx).First(&app, "id = ?", id).Error
+	err := conn(ctx, r.db).First(&app, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrApplicationNotFound, id)
+	}
 	if err != nil {
-		log.Printf("Error saving application: %v", err)
+		logger.FromContext(ctx).Error("Failed to find application", zap.String("app_id", id), zap.Error(err))
 	}
 	return &app, err
 }
 
+func (r *CreditRepo) FindByIDs(ctx context.Context, ids []string) ([]*domain.CreditApplication, error) {
+	var applications []*domain.CreditApplication
+	err := conn(ctx, r.db).Where("id IN ?", ids).Find(&applications).Error
+	return applications, err
+}
+
 func (r *CreditRepo) FindByUserID(ctx context.Context, userID string) (*domain.CreditApplication, error) {
 	var app domain.CreditApplication
-	err := r.db.WithContext(ctx).First(&app, "userID = ?", userID).Error
+	err := conn(ctx, r.db).First(&app, "userID = ?", userID).Error
 	return &app, err
 }
 
 func (r *CreditRepo) UpdateStatus(ctx context.Context, id string, status domain.ApplicationStatus) error {
-	return r.db.WithContext(ctx).
+	return conn(ctx, r.db).
 		Model(&domain.CreditApplication{}).
 		Where("id = ?", id).
-		Update("status", status).Error
+		Updates(map[string]interface{}{
+			"status":  status,
+			"version": gorm.Expr("version + 1"),
+		}).Error
 }
 
 func (r *CreditRepo) Update(ctx context.Context, app *domain.CreditApplication) error {
-	return r.db.WithContext(ctx).Model(&domain.CreditApplication{}).
+	return conn(ctx, r.db).Model(&domain.CreditApplication{}).
 		Where("id = ?", app.ID).
 		Updates(app).Error
 }
 
+func (r *CreditRepo) UpdateIfCurrent(ctx context.Context, app *domain.CreditApplication, expectedStatus domain.ApplicationStatus, expectedVersion int64) error {
+	result := conn(ctx, r.db).Model(&domain.CreditApplication{}).
+		Where("id = ? AND status = ? AND version = ?", app.ID, expectedStatus, expectedVersion).
+		Updates(app)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrApplicationConflict, app.ID)
+	}
+	return nil
+}
+
+func (r *CreditRepo) ClearPendingStatus(ctx context.Context, id string) error {
+	return conn(ctx, r.db).Model(&domain.CreditApplication{}).
+		Where("id = ?", id).
+		Update("pending_status", nil).Error
+}
+
+func (r *CreditRepo) SaveAll(ctx context.Context, apps []*domain.CreditApplication) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		return tx.Create(apps).Error
+	})
+}
+
+func (r *CreditRepo) UpdateAll(ctx context.Context, apps []*domain.CreditApplication) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, app := range apps {
+			if err := tx.Model(&domain.CreditApplication{}).Where("id = ?", app.ID).Updates(app).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (r *CreditRepo) Delete(ctx context.Context, appID string) error {
-	return r.db.WithContext(ctx).Where("id = ?", appID).Delete(&domain.CreditApplication{}).Error
+	result := conn(ctx, r.db).Where("id = ?", appID).Delete(&domain.CreditApplication{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrApplicationNotFound, appID)
+	}
+	return nil
 }
 
 func (r *CreditRepo) List(ctx context.Context, statuses []domain.ApplicationStatus, offset int, limit int, userID string) ([]*domain.CreditApplication, int, error) {
 	var applications []*domain.CreditApplication
 
-	query := r.db.WithContext(ctx).Model(&domain.CreditApplication{})
+	query := conn(ctx, r.db).Model(&domain.CreditApplication{})
 	if len(statuses) > 0 {
 		query = query.Where("status IN ?", statuses)
 	}
@@ -81,3 +138,65 @@ func (r *CreditRepo) List(ctx context.Context, statuses []domain.ApplicationStat
 
 	return applications, int(totalCount), nil
 }
+
+func (r *CreditRepo) Export(ctx context.Context, filter domain.ApplicationFilter, batchSize int, fn func([]*domain.CreditApplication) error) error {
+	query := conn(ctx, r.db).Model(&domain.CreditApplication{})
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.UserID != "" {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if !filter.CreatedFrom.IsZero() {
+		query = query.Where("created_at >= ?", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		query = query.Where("created_at < ?", filter.CreatedTo)
+	}
+
+	// FindInBatches идёт по первичному ключу (id > последний), без OFFSET.
+	var batch []*domain.CreditApplication
+	return query.FindInBatches(&batch, batchSize, func(tx *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
+
+func (r *CreditRepo) ListByUserOrBankAccount(ctx context.Context, userID string, bankAccountID string, since time.Time, limit int) ([]*domain.CreditApplication, error) {
+	var applications []*domain.CreditApplication
+
+	err := conn(ctx, r.db).
+		Where("(user_id = ? OR to_bank_account_id = ?) AND created_at >= ?", userID, bankAccountID, since).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&applications).Error
+	return applications, err
+}
+
+func (r *CreditRepo) ListStale(ctx context.Context, filter domain.StaleFilter, limit int) ([]*domain.CreditApplication, error) {
+	var applications []*domain.CreditApplication
+
+	query := conn(ctx, r.db).
+		Where("status = ? AND updated_at < ?", filter.Status, filter.UpdatedBefore)
+	if len(filter.ProductCodes) > 0 {
+		query = query.Where("product_code IN ?", filter.ProductCodes)
+	}
+	if len(filter.ExcludeProductCodes) > 0 {
+		query = query.Where("product_code NOT IN ?", filter.ExcludeProductCodes)
+	}
+
+	err := query.Order("updated_at, id").Limit(limit).Find(&applications).Error
+	return applications, err
+}
+
+func (r *CreditRepo) ListCreatedBetween(ctx context.Context, from time.Time, to time.Time, offset int, limit int) ([]*domain.CreditApplication, error) {
+	var applications []*domain.CreditApplication
+
+	err := conn(ctx, r.db).
+		Where("created_at >= ? AND created_at < ?", from, to).
+		Order("created_at, id").
+		Offset(offset).
+		Limit(limit).
+		Find(&applications).Error
+
+	return applications, err
+}
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+)
+
+func TestClearPendingStatus(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewCreditRepo(db)
+
+	app := newTestApplication()
+	app.AwaitConsents(domain.SCORING)
+	if err := repo.Save(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	// Update пропускает нулевые поля: обнулённый pending_status он не пишет.
+	app.PendingStatus = sql.NullString{}
+	if err := repo.Update(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+	stored, err := repo.FindByID(ctx, app.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.PendingStatus.String != string(domain.SCORING) {
+		t.Fatalf("pending_status = %v after Update, want it untouched", stored.PendingStatus)
+	}
+
+	if err := repo.ClearPendingStatus(ctx, app.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	stored, err = repo.FindByID(ctx, app.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.PendingStatus.Valid {
+		t.Fatalf("pending_status = %q, want NULL", stored.PendingStatus.String)
+	}
+}
+
+func TestResumeClearsPendingStatusInTransaction(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewCreditRepo(db)
+
+	app := newTestApplication()
+	app.Status = domain.APPLICATION_AGREEMENT_CREATED
+	app.AwaitConsents(domain.SCORING)
+	if err := repo.Save(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := app.ResumePending(); err != nil {
+		t.Fatal(err)
+	}
+	err := NewTransactor(db).WithinTx(ctx, func(ctx context.Context) error {
+		if err := repo.Update(ctx, app); err != nil {
+			return err
+		}
+		return repo.ClearPendingStatus(ctx, app.ID.String())
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stored, err := repo.FindByID(ctx, app.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != domain.SCORING || stored.PendingStatus.Valid {
+		t.Fatalf("status = %s pending_status = %v, want SCORING and NULL", stored.Status, stored.PendingStatus)
+	}
+}
+
+func TestUpdateIfCurrentRejectsChangedApplication(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewCreditRepo(db)
+
+	app := newTestApplication()
+	if err := repo.Save(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+
+	// Две копии, прочитанные до записи: первая проходит, вторая опоздала.
+	first, second := *app, *app
+	if err := first.ChangeStatus(domain.APPLICATION_CREATED); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateIfCurrent(ctx, &first, app.Status, app.Version); err != nil {
+		t.Fatal(err)
+	}
+	if err := second.Cancel("changed my mind"); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.UpdateIfCurrent(ctx, &second, app.Status, app.Version); !errors.Is(err, domain.ErrApplicationConflict) {
+		t.Fatalf("stale UpdateIfCurrent error = %v, want ErrApplicationConflict", err)
+	}
+
+	stored, err := repo.FindByID(ctx, app.ID.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Status != domain.APPLICATION_CREATED || stored.Version != first.Version {
+		t.Fatalf("stored %s v%d, want %s v%d", stored.Status, stored.Version, domain.APPLICATION_CREATED, first.Version)
+	}
+}
+
+func TestDeleteUnknownApplicationIsNotFound(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	repo := NewCreditRepo(db)
+
+	app := newTestApplication()
+	if err := repo.Save(ctx, app); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(ctx, app.ID.String()); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Delete(ctx, app.ID.String()); !errors.Is(err, domain.ErrApplicationNotFound) {
+		t.Fatalf("second Delete error = %v, want ErrApplicationNotFound", err)
+	}
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"gorm.io/gorm"
+)
+
+type ReviewRepo struct {
+	db *gorm.DB
+}
+
+var _ domain.ReviewRepository = (*ReviewRepo)(nil)
+
+func NewReviewRepo(db *gorm.DB) *ReviewRepo {
+	return &ReviewRepo{db: db}
+}
+
+func (r *ReviewRepo) Create(ctx context.Context, review *domain.Review) error {
+	return conn(ctx, r.db).Omit("Notes", "Attachments", "Application").Create(review).Error
+}
+
+func (r *ReviewRepo) FindByID(ctx context.Context, id string) (*domain.Review, error) {
+	var review domain.Review
+	err := conn(ctx, r.db).
+		Preload("Notes", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Preload("Attachments", func(db *gorm.DB) *gorm.DB { return db.Order("created_at") }).
+		Preload("Application").
+		First(&review, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("%w: %s", domain.ErrReviewNotFound, id)
+	}
+	return &review, err
+}
+
+func (r *ReviewRepo) ListQueue(ctx context.Context, filter domain.ReviewQueueFilter, offset int, limit int) ([]*domain.Review, int, error) {
+	states := filter.States
+	if len(states) == 0 {
+		states = []domain.ReviewState{domain.REVIEW_OPEN, domain.REVIEW_CLAIMED}
+	}
+
+	// Отменённые и истёкшие заявки выпадают из очереди по статусу заявки.
+	query := conn(ctx, r.db).Model(&domain.Review{}).
+		Joins("JOIN credit_applications ON credit_applications.id = reviews.application_id").
+		Where("reviews.state IN ? AND credit_applications.status = ?", states, domain.MANUAL_REVIEW)
+	if filter.Assignee != "" {
+		query = query.Where("reviews.assignee = ?", filter.Assignee)
+	}
+	if filter.ProductCode != "" {
+		query = query.Where("credit_applications.product_code = ?", filter.ProductCode)
+	}
+	if !filter.QueuedBefore.IsZero() {
+		query = query.Where("reviews.queued_at < ?", filter.QueuedBefore)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var reviews []*domain.Review
+	err := query.Preload("Application").
+		Order("reviews.queued_at, reviews.id").
+		Offset(offset).
+		Limit(limit).
+		Find(&reviews).Error
+	return reviews, int(total), err
+}
+
+func (r *ReviewRepo) Update(ctx context.Context, review *domain.Review, expectedVersion int64) error {
+	// Select("*") пишет и обнулённые поля, например Assignee после release.
+	result := conn(ctx, r.db).Model(&domain.Review{}).
+		Where("id = ? AND version = ?", review.ID, expectedVersion).
+		Select("*").
+		Omit("id", "Notes", "Attachments", "Application").
+		Updates(&domain.Review{
+			ID:             review.ID,
+			ApplicationID:  review.ApplicationID,
+			State:          review.State,
+			Assignee:       review.Assignee,
+			ClaimedAt:      review.ClaimedAt,
+			FirstApprover:  review.FirstApprover,
+			Decision:       review.Decision,
+			DecisionReason: review.DecisionReason,
+			DecidedBy:      review.DecidedBy,
+			QueuedAt:       review.QueuedAt,
+			DecidedAt:      review.DecidedAt,
+			Version:        expectedVersion + 1,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domain.ErrReviewConflict
+	}
+	review.Version = expectedVersion + 1
+	return nil
+}
+
+func (r *ReviewRepo) AddNote(ctx context.Context, note *domain.ReviewNote) error {
+	return conn(ctx, r.db).Create(note).Error
+}
+
+func (r *ReviewRepo) AddAttachment(ctx context.Context, attachment *domain.ReviewAttachment) error {
+	return conn(ctx, r.db).Create(attachment).Error
+}
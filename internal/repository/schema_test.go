@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/Andronzi/credit-origination/pkg/database"
+)
+
+func TestModelsMatchMigratedSchema(t *testing.T) {
+	db := openTestDB(t)
+
+	drift, err := database.CheckSchemaDrift(db, Models()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, line := range drift {
+		t.Error(line)
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// txState is the transaction a context belongs to and the work deferred
+// until it commits.
+type txState struct {
+	db          *gorm.DB
+	afterCommit []func()
+}
+
+// Transactor is a domain.Transactor on gorm transactions.
+type Transactor struct {
+	db *gorm.DB
+}
+
+var _ domain.Transactor = (*Transactor)(nil)
+
+func NewTransactor(db *gorm.DB) *Transactor {
+	return &Transactor{db: db}
+}
+
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*txState); ok {
+		return fn(ctx)
+	}
+	state := &txState{}
+	err := t.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		state.db = tx
+		return fn(context.WithValue(ctx, txKey{}, state))
+	})
+	if err != nil {
+		return err
+	}
+	for _, hook := range state.afterCommit {
+		hook()
+	}
+	return nil
+}
+
+// conn returns the transaction ctx belongs to, or db outside of one.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.db.WithContext(ctx)
+	}
+	return db.WithContext(ctx)
+}
+
+// inTx reports whether ctx belongs to a transaction.
+func inTx(ctx context.Context) bool {
+	_, ok := ctx.Value(txKey{}).(*txState)
+	return ok
+}
+
+// afterCommit runs fn once the transaction of ctx commits, or right away
+// outside of one. Rolled back transactions drop fn.
+func afterCommit(ctx context.Context, fn func()) {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		state.afterCommit = append(state.afterCommit, fn)
+		return
+	}
+	fn()
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+)
+
+func TestAfterCommitOutsideTransactionRunsAtOnce(t *testing.T) {
+	ran := false
+	afterCommit(context.Background(), func() { ran = true })
+	if !ran {
+		t.Fatal("hook must run right away outside of a transaction")
+	}
+}
+
+func TestTransactorRollsBackAllWrites(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	apps, reviews := NewCreditRepo(db), NewReviewRepo(db)
+	app := newTestApplication()
+
+	errFailed := errors.New("failed after writes")
+	hooked := false
+	err := NewTransactor(db).WithinTx(ctx, func(ctx context.Context) error {
+		if err := apps.Save(ctx, app); err != nil {
+			return err
+		}
+		if err := reviews.Create(ctx, domain.NewReview(app.ID, app.UpdatedAt)); err != nil {
+			return err
+		}
+		afterCommit(ctx, func() { hooked = true })
+		return errFailed
+	})
+	if !errors.Is(err, errFailed) {
+		t.Fatalf("WithinTx error = %v, want %v", err, errFailed)
+	}
+	if hooked {
+		t.Error("after-commit hook ran for a rolled back transaction")
+	}
+	if _, err := apps.FindByID(ctx, app.ID.String()); !errors.Is(err, domain.ErrApplicationNotFound) {
+		t.Errorf("application survived the rollback: %v", err)
+	}
+	var count int64
+	db.Model(&domain.Review{}).Where("application_id = ?", app.ID).Count(&count)
+	if count != 0 {
+		t.Errorf("%d reviews survived the rollback", count)
+	}
+}
+
+func TestTransactorCommitsAndRunsHooks(t *testing.T) {
+	db := openTestDB(t)
+	ctx := context.Background()
+	apps := NewCreditRepo(db)
+	app := newTestApplication()
+
+	hooked := false
+	err := NewTransactor(db).WithinTx(ctx, func(ctx context.Context) error {
+		if err := apps.Save(ctx, app); err != nil {
+			return err
+		}
+		afterCommit(ctx, func() { hooked = true })
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !hooked {
+		t.Error("after-commit hook did not run")
+	}
+	if _, err := apps.FindByID(ctx, app.ID.String()); err != nil {
+		t.Errorf("application was not committed: %v", err)
+	}
+}
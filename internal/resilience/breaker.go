@@ -0,0 +1,97 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var ErrBreakerOpen = errors.New("circuit breaker is open")
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "CLOSED"
+	BreakerOpen     BreakerState = "OPEN"
+	BreakerHalfOpen BreakerState = "HALF_OPEN"
+)
+
+// Breaker opens after Threshold consecutive failures and rejects calls for
+// Cooldown; then a single probe call decides whether it closes again.
+type Breaker struct {
+	name      string
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewBreaker(name string, threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{name: name, threshold: threshold, cooldown: cooldown, state: BreakerClosed}
+}
+
+func (b *Breaker) Name() string { return b.name }
+
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state == BreakerOpen && time.Since(b.openedAt) >= b.cooldown {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+// Do fails fast with a permanent ErrBreakerOpen while the breaker is open, so
+// retries stop too.
+func (b *Breaker) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := b.acquire(); err != nil {
+		return err
+	}
+	err := fn(ctx)
+	// Отмена вызывающим и ошибки клиента не говорят о здоровье зависимости.
+	b.release(err == nil || IsPermanent(err) || errors.Is(err, context.Canceled))
+	return err
+}
+
+func (b *Breaker) acquire() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return Permanent(fmt.Errorf("%s: %w", b.name, ErrBreakerOpen))
+		}
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return Permanent(fmt.Errorf("%s: %w", b.name, ErrBreakerOpen))
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+func (b *Breaker) release(ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if ok {
+		b.state = BreakerClosed
+		b.failures = 0
+		b.probing = false
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+		b.probing = false
+	}
+}
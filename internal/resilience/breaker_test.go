@@ -0,0 +1,104 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errDependency = errors.New("dependency failed")
+
+func fail(context.Context) error    { return errDependency }
+func succeed(context.Context) error { return nil }
+
+func TestBreakerOpensAfterThreshold(t *testing.T) {
+	tests := []struct {
+		name  string
+		calls []func(context.Context) error
+		want  BreakerState
+	}{
+		{"below threshold", []func(context.Context) error{fail, fail}, BreakerClosed},
+		{"at threshold", []func(context.Context) error{fail, fail, fail}, BreakerOpen},
+		{"success resets the count", []func(context.Context) error{fail, fail, succeed, fail, fail}, BreakerClosed},
+		{"permanent errors do not count", []func(context.Context) error{
+			func(context.Context) error { return Permanent(errDependency) },
+			func(context.Context) error { return Permanent(errDependency) },
+			func(context.Context) error { return Permanent(errDependency) },
+		}, BreakerClosed},
+		{"cancellation does not count", []func(context.Context) error{
+			func(context.Context) error { return context.Canceled },
+			func(context.Context) error { return context.Canceled },
+			func(context.Context) error { return context.Canceled },
+		}, BreakerClosed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker("dep", 3, time.Hour)
+			for _, call := range tt.calls {
+				_ = b.Do(context.Background(), call)
+			}
+			if got := b.State(); got != tt.want {
+				t.Fatalf("State() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenBreakerRejectsPermanently(t *testing.T) {
+	b := NewBreaker("dep", 1, time.Hour)
+	_ = b.Do(context.Background(), fail)
+
+	called := false
+	err := b.Do(context.Background(), func(context.Context) error {
+		called = true
+		return nil
+	})
+	if called {
+		t.Fatal("open breaker let the call through")
+	}
+	if !errors.Is(err, ErrBreakerOpen) || !IsPermanent(err) {
+		t.Fatalf("Do() = %v, want permanent ErrBreakerOpen", err)
+	}
+}
+
+func TestBreakerHalfOpen(t *testing.T) {
+	tests := []struct {
+		name  string
+		probe func(context.Context) error
+		want  BreakerState
+	}{
+		{"successful probe closes", succeed, BreakerClosed},
+		{"failed probe reopens", fail, BreakerOpen},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBreaker("dep", 1, 10*time.Millisecond)
+			_ = b.Do(context.Background(), fail)
+			time.Sleep(20 * time.Millisecond)
+			if got := b.State(); got != BreakerHalfOpen {
+				t.Fatalf("State() after cooldown = %s, want %s", got, BreakerHalfOpen)
+			}
+
+			probing, release := make(chan struct{}), make(chan struct{})
+			done := make(chan error)
+			go func() {
+				done <- b.Do(context.Background(), func(ctx context.Context) error {
+					close(probing)
+					<-release
+					return tt.probe(ctx)
+				})
+			}()
+			<-probing
+			// Пока идёт пробный вызов, остальные отклоняются.
+			if err := b.Do(context.Background(), succeed); !errors.Is(err, ErrBreakerOpen) {
+				t.Fatalf("call during the probe = %v, want ErrBreakerOpen", err)
+			}
+			close(release)
+			<-done
+			if got := b.State(); got != tt.want {
+				t.Fatalf("State() after probe = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrBulkheadFull = errors.New("too many concurrent calls")
+
+// Bulkhead limits concurrent calls to a dependency, so a slow one cannot take
+// every goroutine of the service with it.
+type Bulkhead struct {
+	name  string
+	slots chan struct{}
+	// wait is how long a call may queue for a free slot.
+	wait time.Duration
+}
+
+// NewBulkhead returns nil, an unlimited bulkhead, when limit is not positive.
+func NewBulkhead(name string, limit int, wait time.Duration) *Bulkhead {
+	if limit <= 0 {
+		return nil
+	}
+	return &Bulkhead{name: name, slots: make(chan struct{}, limit), wait: wait}
+}
+
+func (b *Bulkhead) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if b == nil {
+		return fn(ctx)
+	}
+	if err := b.acquire(ctx); err != nil {
+		return err
+	}
+	defer func() { <-b.slots }()
+	return fn(ctx)
+}
+
+func (b *Bulkhead) acquire(ctx context.Context) error {
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	if b.wait <= 0 {
+		return Permanent(fmt.Errorf("%s: %w", b.name, ErrBulkheadFull))
+	}
+
+	timer := time.NewTimer(b.wait)
+	defer timer.Stop()
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-timer.C:
+		return Permanent(fmt.Errorf("%s: %w", b.name, ErrBulkheadFull))
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// InFlight returns the number of calls holding a slot.
+func (b *Bulkhead) InFlight() int {
+	if b == nil {
+		return 0
+	}
+	return len(b.slots)
+}
@@ -0,0 +1,89 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestBulkheadWhenFull(t *testing.T) {
+	tests := []struct {
+		name    string
+		wait    time.Duration
+		timeout time.Duration
+		wantErr error
+	}{
+		{"rejects without waiting", 0, time.Second, ErrBulkheadFull},
+		{"rejects after waiting", 10 * time.Millisecond, time.Second, ErrBulkheadFull},
+		{"caller deadline wins", time.Hour, 10 * time.Millisecond, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBulkhead("dep", 1, tt.wait)
+			entered, release := make(chan struct{}), make(chan struct{})
+			done := make(chan error)
+			go func() {
+				done <- b.Do(context.Background(), func(context.Context) error {
+					close(entered)
+					<-release
+					return nil
+				})
+			}()
+			<-entered
+			defer func() {
+				close(release)
+				<-done
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+			err := b.Do(ctx, succeed)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Do() = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrBulkheadFull) && !IsPermanent(err) {
+				t.Fatalf("ErrBulkheadFull is not permanent: %v", err)
+			}
+			if got := b.InFlight(); got != 1 {
+				t.Fatalf("InFlight() = %d, want 1", got)
+			}
+		})
+	}
+}
+
+func TestBulkheadWaitsForFreeSlot(t *testing.T) {
+	b := NewBulkhead("dep", 1, time.Second)
+	entered := make(chan struct{})
+	done := make(chan error)
+	go func() {
+		done <- b.Do(context.Background(), func(context.Context) error {
+			close(entered)
+			time.Sleep(10 * time.Millisecond)
+			return nil
+		})
+	}()
+	<-entered
+	if err := b.Do(context.Background(), succeed); err != nil {
+		t.Fatalf("Do() = %v, want the slot after the first call", err)
+	}
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if got := b.InFlight(); got != 0 {
+		t.Fatalf("InFlight() = %d, want 0", got)
+	}
+}
+
+func TestUnlimitedBulkhead(t *testing.T) {
+	b := NewBulkhead("dep", 0, 0)
+	if b != nil {
+		t.Fatalf("NewBulkhead(limit 0) = %v, want nil", b)
+	}
+	if err := b.Do(context.Background(), succeed); err != nil {
+		t.Fatalf("Do() = %v", err)
+	}
+	if got := b.InFlight(); got != 0 {
+		t.Fatalf("InFlight() = %d, want 0", got)
+	}
+}
@@ -0,0 +1,83 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Settings configures the calls to one dependency.
+type Settings struct {
+	// Timeout bounds a single attempt.
+	Timeout time.Duration
+	Retry   RetryPolicy
+	// BreakerFailures consecutive failed attempts open the breaker for
+	// BreakerCooldown.
+	BreakerFailures int
+	BreakerCooldown time.Duration
+	// MaxConcurrent calls may be in flight, waiting up to MaxWait for a slot;
+	// 0 means unlimited.
+	MaxConcurrent int
+	MaxWait       time.Duration
+}
+
+// Dependency is an outbound dependency with its own timeout, retries,
+// circuit breaker and bulkhead. Create it with Registry.Register.
+type Dependency struct {
+	name     string
+	timeout  time.Duration
+	retry    RetryPolicy
+	breaker  *Breaker
+	bulkhead *Bulkhead
+	calls    metric.Int64Counter
+}
+
+func (d *Dependency) Name() string { return d.name }
+
+func (d *Dependency) Breaker() *Breaker { return d.breaker }
+
+func (d *Dependency) Timeout() time.Duration { return d.timeout }
+
+func (d *Dependency) RetryPolicy() RetryPolicy { return d.retry }
+
+// Call runs fn under the bulkhead and breaker with a per-attempt timeout.
+// Only idempotent calls are retried: repeating anything else could apply it
+// twice.
+func (d *Dependency) Call(ctx context.Context, idempotent bool, fn func(ctx context.Context) error) error {
+	retry := d.retry
+	if !idempotent {
+		retry.Attempts = 1
+	}
+
+	err := d.bulkhead.Do(ctx, func(ctx context.Context) error {
+		return retry.Do(ctx, func(ctx context.Context) error {
+			return d.breaker.Do(ctx, func(ctx context.Context) error {
+				if d.timeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, d.timeout)
+					defer cancel()
+				}
+				return fn(ctx)
+			})
+		})
+	})
+	d.record(ctx, err)
+	return err
+}
+
+func (d *Dependency) record(ctx context.Context, err error) {
+	outcome := "success"
+	switch {
+	case errors.Is(err, ErrBreakerOpen) || errors.Is(err, ErrBulkheadFull):
+		outcome = "rejected"
+	case err != nil:
+		outcome = "failure"
+	}
+	d.calls.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("dependency", d.name),
+		attribute.String("outcome", outcome),
+	))
+}
@@ -0,0 +1,39 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor sends unary calls through dep. idempotent tells
+// which calls may be retried; Unavailable, DeadlineExceeded, ResourceExhausted
+// and Internal are retried and count as breaker failures, other codes are
+// the server's answer.
+func UnaryClientInterceptor(dep *Dependency, idempotent func(ctx context.Context, method string) bool) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := dep.Call(ctx, idempotent(ctx, method), func(ctx context.Context) error {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			switch status.Code(err) {
+			case codes.OK:
+				return nil
+			case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Internal:
+				return err
+			default:
+				return Permanent(err)
+			}
+		})
+		if errors.Is(err, ErrBreakerOpen) || errors.Is(err, ErrBulkheadFull) {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		// Клиенту нужен исходный статус сервера, без обёртки Permanent.
+		var p *permanentError
+		if errors.As(err, &p) {
+			return p.err
+		}
+		return err
+	}
+}
@@ -0,0 +1,95 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPStatusError is a non-2xx response. 5xx and 429 are retried and count
+// as breaker failures; other statuses are permanent.
+type HTTPStatusError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPStatusError) Error() string {
+	if e.Body == "" {
+		return fmt.Sprintf("unexpected status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+func IsHTTPStatus(err error, code int) bool {
+	var statusErr *HTTPStatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode == code
+}
+
+type idempotentKey struct{}
+
+// WithIdempotent marks requests made with ctx as safe to retry regardless of
+// their method, e.g. a POST that registers a schema.
+func WithIdempotent(ctx context.Context) context.Context {
+	return context.WithValue(ctx, idempotentKey{}, true)
+}
+
+// HTTPClient sends requests to one dependency. GET, HEAD, OPTIONS, PUT and
+// DELETE requests are retried, others only when marked WithIdempotent.
+type HTTPClient struct {
+	dep    *Dependency
+	client *http.Client
+}
+
+func NewHTTPClient(dep *Dependency) *HTTPClient {
+	// Таймаут задаёт Dependency на каждую попытку, а не http.Client.
+	return &HTTPClient{dep: dep, client: &http.Client{}}
+}
+
+// Do sends req and passes 2xx responses to handle, which must consume the
+// body before returning: the attempt's context is cancelled afterwards.
+func (c *HTTPClient) Do(req *http.Request, handle func(*http.Response) error) error {
+	if req.Body != nil && req.GetBody == nil {
+		return Permanent(errors.New("request body cannot be replayed"))
+	}
+
+	return c.dep.Call(req.Context(), isIdempotent(req), func(ctx context.Context) error {
+		attempt := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return Permanent(err)
+			}
+			attempt.Body = body
+		}
+
+		resp, err := c.client.Do(attempt)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+			statusErr := &HTTPStatusError{StatusCode: resp.StatusCode, Body: string(body)}
+			if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+				return statusErr
+			}
+			return Permanent(statusErr)
+		}
+		return handle(resp)
+	})
+}
+
+func isIdempotent(req *http.Request) bool {
+	if marked, _ := req.Context().Value(idempotentKey{}).(bool); marked {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,116 @@
+package resilience
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Registry holds the outbound dependencies of the service and reports their
+// breaker states to health checks and metrics.
+type Registry struct {
+	mu           sync.RWMutex
+	dependencies map[string]*Dependency
+	calls        metric.Int64Counter
+}
+
+func NewRegistry() (*Registry, error) {
+	meter := otel.Meter("credit-origination-service/resilience")
+	r := &Registry{dependencies: make(map[string]*Dependency)}
+
+	var err error
+	r.calls, err = meter.Int64Counter("resilience.calls",
+		metric.WithDescription("Outbound calls by dependency and outcome: success, failure or rejected"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	state, err := meter.Int64ObservableGauge("resilience.breaker.state",
+		metric.WithDescription("Circuit breaker state: 0 closed, 1 half-open, 2 open"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	inFlight, err := meter.Int64ObservableGauge("resilience.bulkhead.in_flight",
+		metric.WithDescription("Calls holding a bulkhead slot"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		for name, dep := range r.dependencies {
+			attrs := metric.WithAttributes(attribute.String("dependency", name))
+			o.ObserveInt64(state, breakerStateValue(dep.breaker.State()), attrs)
+			o.ObserveInt64(inFlight, int64(dep.bulkhead.InFlight()), attrs)
+		}
+		return nil
+	}, state, inFlight)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Register creates the dependency name; registering a name twice returns the
+// first dependency, so clients of one dependency share its breaker.
+func (r *Registry) Register(name string, s Settings) *Dependency {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if dep, ok := r.dependencies[name]; ok {
+		return dep
+	}
+	dep := &Dependency{
+		name:     name,
+		timeout:  s.Timeout,
+		retry:    s.Retry,
+		breaker:  NewBreaker(name, max(s.BreakerFailures, 1), s.BreakerCooldown),
+		bulkhead: NewBulkhead(name, s.MaxConcurrent, s.MaxWait),
+		calls:    r.calls,
+	}
+	r.dependencies[name] = dep
+	return dep
+}
+
+// DependencyState is a snapshot of a dependency for health checks.
+type DependencyState struct {
+	Name     string       `json:"name"`
+	Breaker  BreakerState `json:"breaker"`
+	InFlight int          `json:"in_flight"`
+}
+
+func (s DependencyState) Healthy() bool {
+	return s.Breaker != BreakerOpen
+}
+
+// States returns the dependencies sorted by name.
+func (r *Registry) States() []DependencyState {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	states := make([]DependencyState, 0, len(r.dependencies))
+	for name, dep := range r.dependencies {
+		states = append(states, DependencyState{
+			Name:     name,
+			Breaker:  dep.breaker.State(),
+			InFlight: dep.bulkhead.InFlight(),
+		})
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Name < states[j].Name })
+	return states
+}
+
+func breakerStateValue(state BreakerState) int64 {
+	switch state {
+	case BreakerHalfOpen:
+		return 1
+	case BreakerOpen:
+		return 2
+	default:
+		return 0
+	}
+}
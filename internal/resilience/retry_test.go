@@ -0,0 +1,83 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDo(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		results  []error
+		wantErr  error
+		wantRuns int
+	}{
+		{"success first time", 3, []error{nil}, nil, 1},
+		{"success after failures", 3, []error{errDependency, errDependency, nil}, nil, 3},
+		{"gives up after attempts", 3, []error{errDependency, errDependency, errDependency, nil}, errDependency, 3},
+		{"permanent error stops", 3, []error{Permanent(errDependency), nil}, errDependency, 1},
+		{"zero attempts still calls once", 0, []error{errDependency, nil}, errDependency, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := RetryPolicy{Attempts: tt.attempts, BaseDelay: time.Microsecond, MaxDelay: time.Millisecond}
+			runs := 0
+			err := p.Do(context.Background(), func(context.Context) error {
+				err := tt.results[runs]
+				runs++
+				return err
+			})
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("Do() = %v, want %v", err, tt.wantErr)
+			}
+			if runs != tt.wantRuns {
+				t.Fatalf("runs = %d, want %d", runs, tt.wantRuns)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := RetryPolicy{Attempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour}
+	runs := 0
+	err := p.Do(ctx, func(context.Context) error {
+		runs++
+		cancel()
+		return errDependency
+	})
+	if runs != 1 {
+		t.Fatalf("runs = %d, want 1", runs)
+	}
+	if !errors.Is(err, errDependency) {
+		t.Fatalf("Do() = %v, want %v", err, errDependency)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		max     time.Duration
+	}{
+		{"first retry", RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, 1, 100 * time.Millisecond},
+		{"grows exponentially", RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, 3, 400 * time.Millisecond},
+		{"capped by max delay", RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}, 10, time.Second},
+		{"overflow falls back to max delay", RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}, 80, 5 * time.Second},
+		{"no delays configured", RetryPolicy{}, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				got := tt.policy.Backoff(tt.attempt)
+				if got < 0 || got > tt.max || (tt.max > 0 && got == 0) {
+					t.Fatalf("Backoff(%d) = %s, want in (0, %s]", tt.attempt, got, tt.max)
+				}
+			}
+		})
+	}
+}
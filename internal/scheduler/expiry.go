@@ -0,0 +1,47 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/leader"
+	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ExpiryScheduler expires stale applications. It runs as a singleton job on
+// the leader; the locker still guards each run, so a leader whose lease
+// lapsed mid-run cannot overlap with the next one, and the fencing token
+// makes the database reject writes of a leader that has been replaced.
+type ExpiryScheduler struct {
+	expireUC *usecase.ExpireApplicationsUseCase
+	locker   domain.Locker
+}
+
+func NewExpiryScheduler(expireUC *usecase.ExpireApplicationsUseCase, locker domain.Locker) *ExpiryScheduler {
+	return &ExpiryScheduler{expireUC: expireUC, locker: locker}
+}
+
+// Job returns the scheduler as a singleton job run every interval.
+func (s *ExpiryScheduler) Job(interval time.Duration) leader.Job {
+	return leader.Job{Name: usecase.ExpiryFenceName, Interval: interval, Run: s.run}
+}
+
+func (s *ExpiryScheduler) run(ctx context.Context, token int64) error {
+	var expired int
+	acquired, err := s.locker.TryRun(ctx, func(ctx context.Context) error {
+		var err error
+		expired, err = s.expireUC.Execute(ctx, time.Now().UTC(), token)
+		return err
+	})
+	if !acquired && err == nil {
+		logger.FromContext(ctx).Warn("Expiry run skipped, the previous leader still holds the lock", zap.Int64("token", token))
+		return nil
+	}
+	if expired > 0 {
+		logger.FromContext(ctx).Info("Stale applications expired", zap.Int("expired", expired), zap.Int64("token", token))
+	}
+	return err
+}
@@ -0,0 +1,88 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/Andronzi/credit-origination/api/openapi"
+	"github.com/Andronzi/credit-origination/internal/resilience"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// forwardedHeaders are passed to gRPC as metadata under their own names, so
+// interceptors see the same keys for REST and gRPC clients. The caller is
+// never taken from a header: Authorization reaches the server as
+// authorization metadata and is verified there.
+var forwardedHeaders = map[string]bool{
+	"idempotency-key": true,
+	"x-request-id":    true,
+	"traceparent":     true,
+	"tracestate":      true,
+	"baggage":         true,
+}
+
+// NewHandler returns the REST/JSON gateway to the gRPC API listening on
+// grpcAddr, plus the OpenAPI document at /openapi.json and dependency health
+// at /healthz. Calls to the API go through dep.
+func NewHandler(ctx context.Context, grpcAddr string, dep *resilience.Dependency, registry *resilience.Registry) (http.Handler, func() error, error) {
+	conn, err := grpc.NewClient(grpcAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(resilience.UnaryClientInterceptor(dep, idempotentCall)),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mux := runtime.NewServeMux(
+		runtime.WithIncomingHeaderMatcher(headerMatcher),
+		runtime.WithErrorHandler(errorHandler),
+		runtime.WithMarshalerOption(runtime.MIMEWildcard, &runtime.JSONPb{
+			MarshalOptions: protojson.MarshalOptions{
+				UseProtoNames:   true,
+				EmitUnpopulated: true,
+			},
+			UnmarshalOptions: protojson.UnmarshalOptions{
+				DiscardUnknown: true,
+			},
+		}),
+	)
+	if err := credit.RegisterApplicationServiceHandler(ctx, mux, conn); err != nil {
+		conn.Close()
+		return nil, nil, err
+	}
+
+	handler := http.NewServeMux()
+	handler.Handle("/", mux)
+	handler.HandleFunc("GET /openapi.json", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(openapi.Spec)
+	})
+	handler.HandleFunc("GET /healthz", healthHandler(registry))
+
+	return handler, conn.Close, nil
+}
+
+func headerMatcher(key string) (string, bool) {
+	if forwardedHeaders[strings.ToLower(key)] {
+		return strings.ToLower(key), true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+// errorHandler keeps the gateway's code to HTTP status table
+// (runtime.HTTPStatusFromCode, e.g. InvalidArgument -> 400, Aborted -> 409,
+// Unavailable -> 503) and tells clients when a retry may succeed.
+func errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		w.Header().Set("Retry-After", "1")
+	}
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
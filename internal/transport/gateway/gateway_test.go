@@ -0,0 +1,136 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/resilience"
+	grpcserver "github.com/Andronzi/credit-origination/internal/transport/grpc"
+	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/Andronzi/credit-origination/pkg/money"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc"
+)
+
+// oneAppRepo stores a single application.
+type oneAppRepo struct {
+	domain.CreditRepository
+	app domain.CreditApplication
+}
+
+func (r *oneAppRepo) FindByID(_ context.Context, id string) (*domain.CreditApplication, error) {
+	if id != r.app.ID.String() {
+		return nil, fmt.Errorf("%w: %s", domain.ErrApplicationNotFound, id)
+	}
+	app := r.app
+	return &app, nil
+}
+
+func (r *oneAppRepo) UpdateIfCurrent(context.Context, *domain.CreditApplication, domain.ApplicationStatus, int64) error {
+	return nil
+}
+
+func (r *oneAppRepo) Delete(_ context.Context, id string) error {
+	if id != r.app.ID.String() {
+		return fmt.Errorf("%w: %s", domain.ErrApplicationNotFound, id)
+	}
+	return nil
+}
+
+// newTestGateway serves the gateway in front of a gRPC server over repo.
+func newTestGateway(t *testing.T, repo domain.CreditRepository) http.Handler {
+	t.Helper()
+	rub, err := money.ParseCurrency("RUB")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpcserver.NewCreateApplicationServer(
+		usecase.NewGetApplicationUseCase(repo), nil, nil,
+		usecase.NewUpdateApplicationUseCase(repo), nil,
+		usecase.NewDeleteApplicationUseCase(repo),
+		nil, nil, nil, nil, nil, nil, nil, nil,
+		domain.NewProductCurrencies(rub, nil), nil, 0,
+	)
+	grpcServer := grpc.NewServer()
+	credit.RegisterApplicationServiceServer(grpcServer, server)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	registry, err := resilience.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dep := registry.Register("gateway-client", resilience.Settings{Retry: resilience.RetryPolicy{Attempts: 1}})
+	handler, closeConn, err := NewHandler(context.Background(), lis.Addr().String(), dep, registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { closeConn() })
+	return handler
+}
+
+func TestGatewayMapsErrorsToHTTPStatus(t *testing.T) {
+	app := domain.CreditApplication{
+		ID:                 uuid.New(),
+		UserID:             uuid.New(),
+		ToBankAccountID:    uuid.New(),
+		DisbursementAmount: decimal.NewFromInt(100000),
+		OriginationAmount:  decimal.NewFromInt(100000),
+		Interest:           decimal.NewFromInt(15),
+		Term:               12,
+		ProductCode:        "code-1",
+		Currency:           "RUB",
+		Status:             domain.DRAFT,
+		Version:            1,
+	}
+	handler := newTestGateway(t, &oneAppRepo{app: app})
+
+	known, unknown := "/v1/applications/"+app.ID.String(), "/v1/applications/"+uuid.NewString()
+	update := func(status string) string {
+		return fmt.Sprintf(`{"user_id":%q,"to_bank_account_id":%q,"disbursement_amount":{"unscaled":"100000"},`+
+			`"origination_amount":{"unscaled":"100000"},"interest":{"unscaled":"15"},"term":12,`+
+			`"product_code":"code-1","product_version":"v1","status":%q}`,
+			app.UserID, app.ToBankAccountID, status)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{"get", http.MethodGet, known, "", http.StatusOK},
+		{"get malformed id", http.MethodGet, "/v1/applications/not-a-uuid", "", http.StatusBadRequest},
+		{"get unknown", http.MethodGet, unknown, "", http.StatusNotFound},
+		{"update", http.MethodPut, known, update("DRAFT"), http.StatusOK},
+		{"update malformed id", http.MethodPut, "/v1/applications/not-a-uuid", update("DRAFT"), http.StatusBadRequest},
+		{"update unknown", http.MethodPut, unknown, update("DRAFT"), http.StatusNotFound},
+		{"update status", http.MethodPut, known, update("APPROVED"), http.StatusBadRequest},
+		{"delete", http.MethodDelete, known, "", http.StatusOK},
+		{"delete malformed id", http.MethodDelete, "/v1/applications/not-a-uuid", "", http.StatusBadRequest},
+		{"delete unknown", http.MethodDelete, unknown, "", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("HTTP %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+		})
+	}
+}
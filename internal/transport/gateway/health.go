@@ -0,0 +1,47 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/Andronzi/credit-origination/internal/resilience"
+	"google.golang.org/grpc/metadata"
+)
+
+// healthHandler reports DEGRADED while a dependency's breaker is open, with
+// the state of every dependency in the body. It still answers 200: the
+// service works without the failing dependency.
+func healthHandler(registry *resilience.Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		states := registry.States()
+		status := "SERVING"
+		for _, state := range states {
+			if !state.Healthy() {
+				status = "DEGRADED"
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Status       string                       `json:"status"`
+			Dependencies []resilience.DependencyState `json:"dependencies"`
+		}{status, states})
+	}
+}
+
+// idempotentCall tells which gateway calls may be retried: reads, and writes
+// sent with an Idempotency-Key that the server deduplicates.
+func idempotentCall(ctx context.Context, method string) bool {
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get("idempotency-key")) > 0 {
+		return true
+	}
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range []string{"Get", "List", "BatchGet"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
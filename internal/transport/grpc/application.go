@@ -2,14 +2,17 @@ package grpc
 
 import (
 	"context"
-	"math"
+	"errors"
 	"time"
 
 	"github.com/Andronzi/credit-origination/internal/domain"
 	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/internal/middleware"
 	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/internal/watch"
 	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
 	"github.com/Andronzi/credit-origination/pkg/logger"
+	"github.com/Andronzi/credit-origination/pkg/money"
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
@@ -21,30 +24,163 @@ import (
 
 type ApplicationServiceServer struct {
 	credit.UnimplementedApplicationServiceServer
-	getUC          *usecase.GetApplicationUseCase
-	createUC       *usecase.CreateApplicationUseCase
-	listUC         *usecase.ListApplicationUseCase
-	updateUC       *usecase.UpdateApplicationUseCase
-	updateStatusUC *usecase.UpdateStatusUseCase
-	deleteUC       *usecase.DeleteApplicationUseCase
-	producer       *messaging.KafkaProducer
+	getUC            *usecase.GetApplicationUseCase
+	createUC         *usecase.CreateApplicationUseCase
+	listUC           *usecase.ListApplicationUseCase
+	updateUC         *usecase.UpdateApplicationUseCase
+	updateStatusUC   *usecase.UpdateStatusUseCase
+	deleteUC         *usecase.DeleteApplicationUseCase
+	cancelUC         *usecase.CancelApplicationUseCase
+	batchGetUC       *usecase.BatchGetApplicationsUseCase
+	batchCreateUC    *usecase.BatchCreateApplicationsUseCase
+	bulkTransitionUC *usecase.BulkTransitionUseCase
+	exportUC         *usecase.ExportApplicationsUseCase
+	offerUC          *usecase.CounterOfferUseCase
+	consentUC        *usecase.ConsentUseCase
+	producer         *messaging.KafkaProducer
+	currencies       *domain.ProductCurrencies
+	hub              *watch.Hub
+	// heartbeatInterval is how often idle watch streams get a heartbeat.
+	heartbeatInterval time.Duration
 }
 
-func ToDomainDecimal(d *credit.Decimal) decimal.Decimal {
-	return decimal.New(d.Unscaled, -d.Scale)
+// ToDomainDecimal validates a request decimal, field names the request field in the error.
+func ToDomainDecimal(field string, d *credit.Decimal) (decimal.Decimal, error) {
+	value, err := money.FromProto(d)
+	if err != nil {
+		return decimal.Zero, status.Errorf(codes.InvalidArgument, "%s: %v", field, err)
+	}
+	return value, nil
+}
+
+func ToProtoDecimal(field string, d decimal.Decimal) (*credit.Decimal, error) {
+	value, err := money.ToProto(d)
+	if err != nil {
+		// Сообщение попадёт в лог запроса вместе с кодом Internal.
+		return nil, status.Errorf(codes.Internal, "failed to encode application: %s: %v", field, err)
+	}
+	return value, nil
+}
+
+// ToProtoRatio encodes shares such as DTI, which carry four fractional digits.
+func ToProtoRatio(field string, d decimal.Decimal) (*credit.Decimal, error) {
+	value, err := money.RatioToProto(d)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode application: %s: %v", field, err)
+	}
+	return value, nil
+}
+
+func ToApplicationResponse(app *domain.CreditApplication) (*credit.ApplicationResponse, error) {
+	disbursementAmount, err := ToProtoDecimal("disbursement_amount", app.DisbursementAmount)
+	if err != nil {
+		return nil, err
+	}
+	originationAmount, err := ToProtoDecimal("origination_amount", app.OriginationAmount)
+	if err != nil {
+		return nil, err
+	}
+	interest, err := ToProtoDecimal("interest", app.Interest)
+	if err != nil {
+		return nil, err
+	}
+	disbursementAmount.CurrencyCode = app.Currency
+	originationAmount.CurrencyCode = app.Currency
+	financials, err := ToProtoFinancials(app)
+	if err != nil {
+		return nil, err
+	}
+	affordability, err := ToProtoAffordability(app)
+	if err != nil {
+		return nil, err
+	}
+
+	return &credit.ApplicationResponse{
+		Id:                 app.ID.String(),
+		UserId:             app.UserID.String(),
+		DisbursementAmount: disbursementAmount,
+		OriginationAmount:  originationAmount,
+		ToBankAccountId:    app.ToBankAccountID.String(),
+		Term:               uint32(app.Term),
+		Interest:           interest,
+		Status:             MapDomainStatusToGRPC(app.Status),
+		ProductCode:        app.ProductCode,
+		ProductVersion:     app.ProductVersion,
+		Currency:           app.Currency,
+		Version:            app.Version,
+		CancelReason:       app.CancelReason.String,
+		RiskDecision:       string(app.RiskDecision),
+		RiskScore:          int32(app.RiskScore),
+		RiskFlags:          ToProtoRiskFlags(app.RiskFlags),
+		Financials:         financials,
+		Affordability:      affordability,
+		PendingStatus:      app.PendingStatus.String,
+		CreditScore:        app.CreditScore.Int32,
+		CreatedAt:          timestamppb.New(app.CreatedAt),
+		UpdatedAt:          timestamppb.New(app.UpdatedAt),
+	}, nil
+}
+
+// ToProtoFinancials returns nil for applications created without financials.
+func ToProtoFinancials(app *domain.CreditApplication) (*credit.ApplicantFinancials, error) {
+	if !app.HasFinancials() {
+		return nil, nil
+	}
+	income, err := ToProtoDecimal("monthly_income", app.MonthlyIncome.Decimal)
+	if err != nil {
+		return nil, err
+	}
+	obligations, err := ToProtoDecimal("monthly_obligations", app.MonthlyObligations.Decimal)
+	if err != nil {
+		return nil, err
+	}
+	income.CurrencyCode = app.Currency
+	obligations.CurrencyCode = app.Currency
+	return &credit.ApplicantFinancials{
+		MonthlyIncome:      income,
+		MonthlyObligations: obligations,
+		Dependants:         uint32(app.Dependants.Int32),
+	}, nil
+}
+
+// ToProtoAffordability returns nil for applications that were not assessed.
+func ToProtoAffordability(app *domain.CreditApplication) (*credit.Affordability, error) {
+	if app.AffordabilityDecision == "" {
+		return nil, nil
+	}
+	payment, err := ToProtoDecimal("monthly_payment", app.MonthlyPayment.Decimal)
+	if err != nil {
+		return nil, err
+	}
+	dti, err := ToProtoRatio("dti", app.DTI.Decimal)
+	if err != nil {
+		return nil, err
+	}
+	maxDTI, err := ToProtoRatio("max_dti", app.MaxDTI.Decimal)
+	if err != nil {
+		return nil, err
+	}
+	payment.CurrencyCode = app.Currency
+	return &credit.Affordability{
+		MonthlyPayment: payment,
+		Dti:            dti,
+		MaxDti:         maxDTI,
+		Decision:       string(app.AffordabilityDecision),
+	}, nil
 }
 
-func ToProtoDecimal(d decimal.Decimal) *credit.Decimal {
-	return &credit.Decimal{
-		Unscaled: d.CoefficientInt64(),
-		Scale:    int32(math.Abs(float64(d.Exponent()))),
+func ToProtoRiskFlags(flags domain.RiskFlags) []*credit.RiskFlag {
+	result := make([]*credit.RiskFlag, 0, len(flags))
+	for _, flag := range flags {
+		result = append(result, &credit.RiskFlag{Rule: flag.Rule, Score: int32(flag.Score), Detail: flag.Detail})
 	}
+	return result
 }
 
 func StringToUUID(idStr string) (uuid.UUID, error) {
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		return uuid.UUID{}, status.Error(codes.Internal, "id has wrong format")
+		return uuid.UUID{}, status.Error(codes.InvalidArgument, "id has wrong format")
 	}
 	return id, nil
 }
@@ -65,6 +201,12 @@ func MapGRPCStatusToDomain(grpcStatus credit.ApplicationStatus) domain.Applicati
 		return domain.APPROVED
 	case credit.ApplicationStatus_REJECTED:
 		return domain.REJECTED
+	case credit.ApplicationStatus_CANCELLED:
+		return domain.CANCELLED
+	case credit.ApplicationStatus_MANUAL_REVIEW:
+		return domain.MANUAL_REVIEW
+	case credit.ApplicationStatus_COUNTER_OFFERED:
+		return domain.COUNTER_OFFERED
 	default:
 		return domain.DRAFT
 	}
@@ -86,6 +228,12 @@ func MapDomainStatusToGRPC(domainStatus domain.ApplicationStatus) credit.Applica
 		return credit.ApplicationStatus_APPROVED
 	case domain.REJECTED:
 		return credit.ApplicationStatus_REJECTED
+	case domain.CANCELLED:
+		return credit.ApplicationStatus_CANCELLED
+	case domain.MANUAL_REVIEW:
+		return credit.ApplicationStatus_MANUAL_REVIEW
+	case domain.COUNTER_OFFERED:
+		return credit.ApplicationStatus_COUNTER_OFFERED
 	default:
 		return credit.ApplicationStatus_DRAFT
 	}
@@ -98,90 +246,181 @@ func NewCreateApplicationServer(
 	updateUC *usecase.UpdateApplicationUseCase,
 	updateStatusUC *usecase.UpdateStatusUseCase,
 	deleteUC *usecase.DeleteApplicationUseCase,
+	cancelUC *usecase.CancelApplicationUseCase,
+	batchGetUC *usecase.BatchGetApplicationsUseCase,
+	batchCreateUC *usecase.BatchCreateApplicationsUseCase,
+	bulkTransitionUC *usecase.BulkTransitionUseCase,
+	exportUC *usecase.ExportApplicationsUseCase,
+	offerUC *usecase.CounterOfferUseCase,
+	consentUC *usecase.ConsentUseCase,
 	producer *messaging.KafkaProducer,
+	currencies *domain.ProductCurrencies,
+	hub *watch.Hub,
+	heartbeatInterval time.Duration,
 ) *ApplicationServiceServer {
 	return &ApplicationServiceServer{
-		getUC:          getUC,
-		createUC:       createUC,
-		listUC:         listUC,
-		updateUC:       updateUC,
-		updateStatusUC: updateStatusUC,
-		deleteUC:       deleteUC,
-		producer:       producer,
+		getUC:             getUC,
+		createUC:          createUC,
+		listUC:            listUC,
+		updateUC:          updateUC,
+		updateStatusUC:    updateStatusUC,
+		deleteUC:          deleteUC,
+		cancelUC:          cancelUC,
+		batchGetUC:        batchGetUC,
+		batchCreateUC:     batchCreateUC,
+		bulkTransitionUC:  bulkTransitionUC,
+		exportUC:          exportUC,
+		offerUC:           offerUC,
+		consentUC:         consentUC,
+		producer:          producer,
+		currencies:        currencies,
+		hub:               hub,
+		heartbeatInterval: heartbeatInterval,
 	}
 }
 
-func (s *ApplicationServiceServer) Create(ctx context.Context, req *credit.CreateApplicationRequest) (*credit.ApplicationResponse, error) {
-	logger.Logger.Info("Received create application request",
-		zap.String("service", "ApplicationServiceServer.Create"),
-		zap.String("user_id", req.UserId),
-		zap.String("to_bank_account_id", req.ToBankAccountId),
-		zap.Any("request", req),
-	)
+// resolveCurrency returns the currency of the product and rejects requests
+// whose application currency or amount currencies differ from it.
+func (s *ApplicationServiceServer) resolveCurrency(productCode string, requested string, interest *credit.Decimal, amounts ...*credit.Decimal) (money.Currency, error) {
+	if interest != nil && interest.CurrencyCode != "" {
+		return money.Currency{}, status.Error(codes.InvalidArgument, "interest: rate must not carry a currency")
+	}
+
+	currency := s.currencies.CurrencyOf(productCode)
+	currencyCodes := []string{requested}
+	for _, amount := range amounts {
+		if amount != nil {
+			currencyCodes = append(currencyCodes, amount.CurrencyCode)
+		}
+	}
+	if err := currency.SameCurrency(currencyCodes...); err != nil {
+		return money.Currency{}, status.Errorf(codes.InvalidArgument, "product %q: %v", productCode, err)
+	}
+	return currency, nil
+}
 
+// newApplication validates a create request and builds the application;
+// errors are gRPC statuses.
+func (s *ApplicationServiceServer) newApplication(ctx context.Context, req *credit.CreateApplicationRequest) (*domain.CreditApplication, error) {
 	userID, err := StringToUUID(req.UserId)
 	if err != nil {
 		return nil, err
 	}
+	toBankAccountID, err := StringToUUID(req.ToBankAccountId)
+	if err != nil {
+		return nil, err
+	}
+	amounts := []*credit.Decimal{req.DisbursementAmount, req.OriginationAmount}
+	if req.Financials != nil {
+		amounts = append(amounts, req.Financials.MonthlyIncome, req.Financials.MonthlyObligations)
+	}
+	currency, err := s.resolveCurrency(req.ProductCode, req.Currency, req.Interest, amounts...)
+	if err != nil {
+		return nil, err
+	}
+	disbursementAmount, err := ToDomainDecimal("disbursement_amount", req.DisbursementAmount)
+	if err != nil {
+		return nil, err
+	}
+	originationAmount, err := ToDomainDecimal("origination_amount", req.OriginationAmount)
+	if err != nil {
+		return nil, err
+	}
+	interest, err := ToDomainDecimal("interest", req.Interest)
+	if err != nil {
+		return nil, err
+	}
 	app, err := domain.NewCreditApplication(
-		ToDomainDecimal(req.DisbursementAmount),
-		ToDomainDecimal(req.OriginationAmount),
-		uuid.MustParse(req.ToBankAccountId),
+		disbursementAmount,
+		originationAmount,
+		toBankAccountID,
 		uint32(req.Term),
-		ToDomainDecimal(req.Interest),
+		interest,
 		req.ProductCode,
 		req.ProductVersion,
+		currency,
 		userID,
 		MapGRPCStatusToDomain(req.Status),
 	)
 	if err != nil {
-		logger.Logger.Error("Failed to create new credit application",
+		logger.FromContext(ctx).Error("Failed to create new credit application",
 			zap.String("user_id", req.UserId),
 			zap.Error(err),
 		)
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-	logger.Logger.Info("Successfully created credit application",
+	if req.Financials != nil {
+		if err := setFinancials(app, currency, req.Financials); err != nil {
+			return nil, err
+		}
+	}
+	return app, nil
+}
+
+func setFinancials(app *domain.CreditApplication, currency money.Currency, financials *credit.ApplicantFinancials) error {
+	income, err := ToDomainDecimal("financials.monthly_income", financials.MonthlyIncome)
+	if err != nil {
+		return err
+	}
+	obligations := decimal.Zero
+	if financials.MonthlyObligations != nil {
+		obligations, err = ToDomainDecimal("financials.monthly_obligations", financials.MonthlyObligations)
+		if err != nil {
+			return err
+		}
+	}
+	if err := app.SetFinancials(currency, income, obligations, int(financials.Dependants)); err != nil {
+		return status.Errorf(codes.InvalidArgument, "financials: %v", err)
+	}
+	return nil
+}
+
+func (s *ApplicationServiceServer) Create(ctx context.Context, req *credit.CreateApplicationRequest) (*credit.ApplicationResponse, error) {
+	logger.FromContext(ctx).Info("Received create application request",
+		zap.String("service", "ApplicationServiceServer.Create"),
+		zap.String("user_id", req.UserId),
+		zap.String("to_bank_account_id", req.ToBankAccountId),
+		zap.Any("request", req),
+	)
+
+	app, err := s.newApplication(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("Successfully created credit application",
 		zap.String("app_id", app.ID.String()),
 	)
 
 	if err := s.createUC.Execute(ctx, app); err != nil {
-		logger.Logger.Error("createUC execution failed",
+		logger.FromContext(ctx).Error("createUC execution failed",
 			zap.String("app_id", app.ID.String()),
 			zap.Error(err),
 		)
 		return nil, status.Error(codes.Internal, "failed to create application")
 	}
-	logger.Logger.Info("createUC executed successfully",
+	logger.FromContext(ctx).Info("createUC executed successfully",
 		zap.String("app_id", app.ID.String()),
 	)
 
-	if err := s.updateStatusUC.Execute(ctx, app.ID, domain.APPLICATION_AGREEMENT_CREATED); err != nil {
-		logger.Logger.Error("updateStatusUC execution failed",
+	// Заявки, задержанные или отклонённые антифродом или по долговой нагрузке, дальше не двигаем.
+	if app.PassedChecks() {
+		if err := s.updateStatusUC.Execute(ctx, app.ID, domain.APPLICATION_AGREEMENT_CREATED); err != nil {
+			logger.FromContext(ctx).Error("updateStatusUC execution failed",
+				zap.String("app_id", app.ID.String()),
+				zap.Error(err),
+			)
+			return nil, status.Error(codes.Internal, "status update failed")
+		}
+		logger.FromContext(ctx).Info("Application status updated",
 			zap.String("app_id", app.ID.String()),
-			zap.Error(err),
 		)
-		return nil, status.Error(codes.Internal, "status update failed")
 	}
-	logger.Logger.Info("Application status updated",
-		zap.String("app_id", app.ID.String()),
-	)
 
-	resp := &credit.ApplicationResponse{
-		Id:                 app.ID.String(),
-		UserId:             app.UserID.String(),
-		DisbursementAmount: ToProtoDecimal(app.DisbursementAmount),
-		OriginationAmount:  ToProtoDecimal(app.OriginationAmount),
-		ToBankAccountId:    app.ToBankAccountID.String(),
-		Term:               uint32(app.Term),
-		Interest:           ToProtoDecimal(app.Interest),
-		Status:             MapDomainStatusToGRPC(app.Status),
-		ProductCode:        app.ProductCode,
-		ProductVersion:     app.ProductVersion,
-		CreatedAt:          timestamppb.New(app.CreatedAt),
-		UpdatedAt:          timestamppb.New(app.UpdatedAt),
+	resp, err := ToApplicationResponse(app)
+	if err != nil {
+		return nil, err
 	}
-	logger.Logger.Info("Sending response for create application",
+	logger.FromContext(ctx).Info("Sending response for create application",
 		zap.String("app_id", app.ID.String()),
 	)
 
@@ -206,20 +445,11 @@ func (s *ApplicationServiceServer) List(ctx context.Context, req *credit.ListApp
 	var listApplicationResponses []*credit.ApplicationResponse
 
 	for _, app := range result.Applications {
-		listApplicationResponses = append(listApplicationResponses, &credit.ApplicationResponse{
-			Id:                 app.ID.String(),
-			UserId:             app.UserID.String(),
-			DisbursementAmount: ToProtoDecimal(app.DisbursementAmount),
-			OriginationAmount:  ToProtoDecimal(app.OriginationAmount),
-			ToBankAccountId:    app.ToBankAccountID.String(),
-			Term:               uint32(app.Term),
-			Interest:           ToProtoDecimal(app.Interest),
-			Status:             MapDomainStatusToGRPC(app.Status),
-			ProductCode:        app.ProductCode,
-			ProductVersion:     app.ProductVersion,
-			CreatedAt:          timestamppb.New(app.CreatedAt),
-			UpdatedAt:          timestamppb.New(app.UpdatedAt),
-		})
+		resp, err := ToApplicationResponse(app)
+		if err != nil {
+			return nil, err
+		}
+		listApplicationResponses = append(listApplicationResponses, resp)
 	}
 
 	return &credit.ListApplicationResponse{
@@ -232,34 +462,32 @@ func (s *ApplicationServiceServer) List(ctx context.Context, req *credit.ListApp
 }
 
 func (s *ApplicationServiceServer) Get(ctx context.Context, req *credit.GetApplicationRequest) (*credit.ApplicationResponse, error) {
-	app, err := s.getUC.Execute(ctx, req.Id)
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
 
-	if err != nil {
+	app, err := s.getUC.Execute(ctx, req.Id)
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrApplicationNotFound):
+		return nil, status.Error(codes.NotFound, "application not found")
+	default:
 		return nil, status.Error(codes.Internal, "failed to load application")
 	}
 
-	return &credit.ApplicationResponse{
-		Id:                 app.ID.String(),
-		UserId:             app.UserID.String(),
-		DisbursementAmount: ToProtoDecimal(app.DisbursementAmount),
-		OriginationAmount:  ToProtoDecimal(app.OriginationAmount),
-		ToBankAccountId:    app.ToBankAccountID.String(),
-		Term:               uint32(app.Term),
-		Interest:           ToProtoDecimal(app.Interest),
-		Status:             MapDomainStatusToGRPC(app.Status),
-		ProductCode:        app.ProductCode,
-		ProductVersion:     app.ProductVersion,
-		CreatedAt:          timestamppb.New(app.CreatedAt),
-		UpdatedAt:          timestamppb.New(app.UpdatedAt),
-	}, nil
+	return ToApplicationResponse(app)
 }
 
 func (s *ApplicationServiceServer) Update(ctx context.Context, req *credit.UpdateApplicationRequest) (*credit.ApplicationResponse, error) {
-	now := time.Now()
-	ID, err := StringToUUID(req.Id)
-	if err != nil {
+	if _, err := StringToUUID(req.Id); err != nil {
 		return nil, err
 	}
+	// Статус меняется только через UpdateStatus и Cancel: там проверяются
+	// переходы, согласия и четыре глаза. DRAFT — значение по умолчанию,
+	// отличить его от незаполненного поля нельзя, поэтому оно игнорируется.
+	if req.Status != credit.ApplicationStatus_DRAFT {
+		return nil, status.Error(codes.InvalidArgument, "status cannot be changed by Update, use UpdateStatus or Cancel")
+	}
 	UserID, err := StringToUUID(req.UserId)
 	if err != nil {
 		return nil, err
@@ -268,49 +496,100 @@ func (s *ApplicationServiceServer) Update(ctx context.Context, req *credit.Updat
 	if err != nil {
 		return nil, err
 	}
+	currency, err := s.resolveCurrency(req.ProductCode, req.Currency, req.Interest, req.DisbursementAmount, req.OriginationAmount)
+	if err != nil {
+		return nil, err
+	}
+	disbursementAmount, err := ToDomainDecimal("disbursement_amount", req.DisbursementAmount)
+	if err != nil {
+		return nil, err
+	}
+	originationAmount, err := ToDomainDecimal("origination_amount", req.OriginationAmount)
+	if err != nil {
+		return nil, err
+	}
+	interest, err := ToDomainDecimal("interest", req.Interest)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateAmounts(currency, disbursementAmount, originationAmount); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 
-	app := &domain.CreditApplication{
-		ID:                 ID,
+	app, err := s.updateUC.Execute(ctx, req.Id, usecase.ApplicationChanges{
 		UserID:             UserID,
-		DisbursementAmount: ToDomainDecimal(req.DisbursementAmount),
-		OriginationAmount:  ToDomainDecimal(req.OriginationAmount),
 		ToBankAccountID:    ToBankAccountId,
+		DisbursementAmount: disbursementAmount,
+		OriginationAmount:  originationAmount,
 		Term:               uint32(req.Term),
-		Interest:           ToDomainDecimal(req.Interest),
-		Status:             MapGRPCStatusToDomain(req.Status),
+		Interest:           interest,
 		ProductCode:        req.ProductCode,
 		ProductVersion:     req.ProductVersion,
-		UpdatedAt:          now,
-	}
-
-	err = s.updateUC.Execute(ctx, app)
-
-	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to load application")
+		Currency:           currency.Code,
+	})
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrApplicationNotFound):
+		return nil, status.Error(codes.NotFound, "application not found")
+	case errors.Is(err, domain.ErrApplicationConflict):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, "failed to update application")
 	}
 
-	return &credit.ApplicationResponse{
-		Id:                 app.ID.String(),
-		UserId:             app.UserID.String(),
-		DisbursementAmount: ToProtoDecimal(app.DisbursementAmount),
-		OriginationAmount:  ToProtoDecimal(app.OriginationAmount),
-		ToBankAccountId:    app.ToBankAccountID.String(),
-		Term:               uint32(app.Term),
-		Interest:           ToProtoDecimal(app.Interest),
-		Status:             MapDomainStatusToGRPC(app.Status),
-		ProductCode:        app.ProductCode,
-		ProductVersion:     app.ProductVersion,
-		CreatedAt:          timestamppb.New(app.CreatedAt),
-		UpdatedAt:          timestamppb.New(app.UpdatedAt),
-	}, nil
+	return ToApplicationResponse(app)
 }
 
 func (s *ApplicationServiceServer) Delete(ctx context.Context, req *credit.DeleteApplicationRequest) (*emptypb.Empty, error) {
-	err := s.deleteUC.Execute(ctx, req.Id)
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
 
-	if err != nil {
+	err := s.deleteUC.Execute(ctx, req.Id)
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrApplicationNotFound):
+		return nil, status.Error(codes.NotFound, "application not found")
+	default:
 		return nil, status.Error(codes.Internal, "failed to delete application")
 	}
 
 	return &emptypb.Empty{}, nil
 }
+
+func (s *ApplicationServiceServer) Cancel(ctx context.Context, req *credit.CancelApplicationRequest) (*credit.ApplicationResponse, error) {
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
+
+	caller, ok := middleware.Principal(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "authentication is required")
+	}
+
+	app, err := s.cancelUC.Execute(ctx, req.Id, caller, req.Reason)
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrEmptyCancelReason):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrApplicationNotFound):
+		return nil, status.Error(codes.NotFound, "application not found")
+	case errors.Is(err, domain.ErrNotApplicationOwner):
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, domain.ErrApplicationConflict):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case domain.IsTransitionError(err):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, usecase.ErrStatusEventNotSent):
+		// Отмена уже сохранена, повтор вернёт FailedPrecondition.
+		return nil, status.Error(codes.Unavailable, err.Error())
+	default:
+		logger.FromContext(ctx).Error("cancelUC execution failed",
+			zap.String("app_id", req.Id),
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to cancel application")
+	}
+
+	return ToApplicationResponse(app)
+}
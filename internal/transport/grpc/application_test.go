@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToProtoAffordabilityEncodesFourDigitDTI(t *testing.T) {
+	app := &domain.CreditApplication{
+		Currency:              "RUB",
+		MonthlyPayment:        decimal.NewNullDecimal(decimal.RequireFromString("13850.12")),
+		DTI:                   decimal.NewNullDecimal(decimal.RequireFromString("0.2404")),
+		MaxDTI:                decimal.NewNullDecimal(decimal.RequireFromString("0.5")),
+		AffordabilityDecision: domain.AffordabilityPass,
+	}
+
+	got, err := ToProtoAffordability(app)
+	if err != nil {
+		t.Fatalf("ToProtoAffordability: %v", err)
+	}
+	if got.Dti.Unscaled != 2404 || got.Dti.Scale != 4 {
+		t.Errorf("dti = %d/%d, want 2404/4", got.Dti.Unscaled, got.Dti.Scale)
+	}
+	if got.MaxDti.Unscaled != 5000 || got.MaxDti.Scale != 4 {
+		t.Errorf("max_dti = %d/%d, want 5000/4", got.MaxDti.Unscaled, got.MaxDti.Scale)
+	}
+	if got.MonthlyPayment.Unscaled != 1385012 || got.MonthlyPayment.Scale != 2 {
+		t.Errorf("monthly_payment = %d/%d, want 1385012/2", got.MonthlyPayment.Unscaled, got.MonthlyPayment.Scale)
+	}
+}
+
+func TestToProtoRatioRejectsExtraDigits(t *testing.T) {
+	if _, err := ToProtoRatio("dti", decimal.RequireFromString("0.24041")); err == nil {
+		t.Fatal("five fractional digits must not be rounded silently")
+	}
+}
+
+func TestUpdateRejectsStatus(t *testing.T) {
+	s := &ApplicationServiceServer{}
+	_, err := s.Update(context.Background(), &credit.UpdateApplicationRequest{
+		Id:     uuid.NewString(),
+		Status: credit.ApplicationStatus_APPROVED,
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("code = %s, want InvalidArgument (%v)", status.Code(err), err)
+	}
+}
@@ -0,0 +1,156 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ApplicationServiceServer) BatchGetApplications(ctx context.Context, req *credit.BatchGetApplicationsRequest) (*credit.BatchGetApplicationsResponse, error) {
+	for _, id := range req.Ids {
+		if _, err := StringToUUID(id); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid id %q", id)
+		}
+	}
+
+	apps, notFound, err := s.batchGetUC.Execute(ctx, req.Ids)
+	if err != nil {
+		return nil, bulkError(ctx, err, "failed to load applications")
+	}
+
+	resp := &credit.BatchGetApplicationsResponse{
+		Applications: make([]*credit.ApplicationResponse, 0, len(apps)),
+		NotFoundIds:  notFound,
+	}
+	for _, app := range apps {
+		application, err := ToApplicationResponse(app)
+		if err != nil {
+			return nil, err
+		}
+		resp.Applications = append(resp.Applications, application)
+	}
+	return resp, nil
+}
+
+func (s *ApplicationServiceServer) BatchCreateApplications(ctx context.Context, req *credit.BatchCreateApplicationsRequest) (*credit.BatchCreateApplicationsResponse, error) {
+	results := make([]*credit.BatchCreateResult, len(req.Requests))
+	var apps []*domain.CreditApplication
+	var positions []int
+	for i, item := range req.Requests {
+		results[i] = &credit.BatchCreateResult{Index: uint32(i)}
+		app, err := s.newApplication(ctx, item)
+		if err != nil {
+			results[i].Result = &credit.BatchCreateResult_Error{Error: ToBatchItemError(ctx, err)}
+			continue
+		}
+		apps = append(apps, app)
+		positions = append(positions, i)
+	}
+
+	errs, err := s.batchCreateUC.Execute(ctx, apps)
+	if err != nil {
+		return nil, bulkError(ctx, err, "failed to create applications")
+	}
+
+	for k, app := range apps {
+		result := results[positions[k]]
+		if errs[k] != nil {
+			result.Result = &credit.BatchCreateResult_Error{Error: ToBatchItemError(ctx, errs[k])}
+			continue
+		}
+		application, err := ToApplicationResponse(app)
+		if err != nil {
+			result.Result = &credit.BatchCreateResult_Error{Error: ToBatchItemError(ctx, err)}
+			continue
+		}
+		result.Result = &credit.BatchCreateResult_Application{Application: application}
+	}
+
+	return &credit.BatchCreateApplicationsResponse{Results: results}, nil
+}
+
+func (s *ApplicationServiceServer) BulkTransition(ctx context.Context, req *credit.BulkTransitionRequest) (*credit.BulkTransitionResponse, error) {
+	var selector usecase.BulkSelector
+	switch sel := req.Selector.(type) {
+	case *credit.BulkTransitionRequest_Ids:
+		for _, id := range sel.Ids.Ids {
+			if _, err := StringToUUID(id); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid id %q", id)
+			}
+		}
+		selector.IDs = sel.Ids.Ids
+	case *credit.BulkTransitionRequest_Filter:
+		selector.ByFilter = true
+		selector.UserID = sel.Filter.UserId
+		for _, st := range sel.Filter.Status {
+			selector.Statuses = append(selector.Statuses, MapGRPCStatusToDomain(st))
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, usecase.ErrEmptySelector.Error())
+	}
+
+	target := MapGRPCStatusToDomain(req.Target)
+	logger.FromContext(ctx).Warn("Bulk transition requested",
+		zap.String("target", string(target)),
+		zap.Bool("by_filter", selector.ByFilter),
+		zap.Int("ids", len(selector.IDs)),
+		zap.String("reason", req.Reason),
+	)
+
+	results, err := s.bulkTransitionUC.Execute(ctx, selector, target, req.Reason)
+	if err != nil {
+		return nil, bulkError(ctx, err, "bulk transition failed")
+	}
+
+	resp := &credit.BulkTransitionResponse{
+		Results: make([]*credit.BulkTransitionResult, 0, len(results)),
+	}
+	for _, r := range results {
+		result := &credit.BulkTransitionResult{Id: r.ID, Version: r.Version}
+		if r.Err != nil {
+			result.Error = ToBatchItemError(ctx, r.Err)
+			resp.Failed++
+		} else {
+			resp.Transitioned++
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	return resp, nil
+}
+
+// ToBatchItemError maps an item failure to the code the same failure gets from
+// the single-item RPCs.
+func ToBatchItemError(ctx context.Context, err error) *credit.BatchItemError {
+	if st, ok := status.FromError(err); ok {
+		return &credit.BatchItemError{Code: int32(st.Code()), Message: st.Message()}
+	}
+
+	code := codes.Internal
+	message := "internal error"
+	switch {
+	case errors.Is(err, usecase.ErrApplicationNotFound):
+		code, message = codes.NotFound, err.Error()
+	case domain.IsTransitionError(err):
+		code, message = codes.FailedPrecondition, err.Error()
+	case errors.Is(err, usecase.ErrStatusEventNotSent):
+		code, message = codes.Unavailable, usecase.ErrStatusEventNotSent.Error()
+	default:
+		logger.FromContext(ctx).Error("Bulk item failed", zap.Error(err))
+	}
+	return &credit.BatchItemError{Code: int32(code), Message: message}
+}
+
+func bulkError(ctx context.Context, err error, message string) error {
+	if errors.Is(err, usecase.ErrBatchTooLarge) || errors.Is(err, usecase.ErrEmptySelector) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger.FromContext(ctx).Error(message, zap.Error(err))
+	return status.Error(codes.Internal, message)
+}
@@ -0,0 +1,65 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/middleware"
+	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// cancelRepo serves one application; UpdateIfCurrent fails with conflict.
+type cancelRepo struct {
+	domain.CreditRepository
+	app      domain.CreditApplication
+	conflict bool
+}
+
+func (r *cancelRepo) FindByID(_ context.Context, id string) (*domain.CreditApplication, error) {
+	if id != r.app.ID.String() {
+		return nil, fmt.Errorf("%w: %s", domain.ErrApplicationNotFound, id)
+	}
+	app := r.app
+	return &app, nil
+}
+
+func (r *cancelRepo) UpdateIfCurrent(context.Context, *domain.CreditApplication, domain.ApplicationStatus, int64) error {
+	if r.conflict {
+		return domain.ErrApplicationConflict
+	}
+	return nil
+}
+
+func TestCancelChecksCaller(t *testing.T) {
+	owner := uuid.New()
+	app := domain.CreditApplication{ID: uuid.New(), UserID: owner, Status: domain.SCORING}
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		id       string
+		conflict bool
+		code     codes.Code
+	}{
+		{"anonymous", context.Background(), app.ID.String(), false, codes.Unauthenticated},
+		{"another user", middleware.WithPrincipal(context.Background(), uuid.NewString()), app.ID.String(), false, codes.PermissionDenied},
+		{"unknown application", middleware.WithPrincipal(context.Background(), owner.String()), uuid.NewString(), false, codes.NotFound},
+		{"changed concurrently", middleware.WithPrincipal(context.Background(), owner.String()), app.ID.String(), true, codes.FailedPrecondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &cancelRepo{app: app, conflict: tt.conflict}
+			s := &ApplicationServiceServer{cancelUC: usecase.NewCancelApplicationUseCase(repo, nil, nil)}
+			_, err := s.Cancel(tt.ctx, &credit.CancelApplicationRequest{Id: tt.id, Reason: "changed my mind"})
+			if status.Code(err) != tt.code {
+				t.Fatalf("code = %s, want %s (%v)", status.Code(err), tt.code, err)
+			}
+		})
+	}
+}
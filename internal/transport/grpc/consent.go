@@ -0,0 +1,131 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/middleware"
+	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *ApplicationServiceServer) RecordConsent(ctx context.Context, req *credit.RecordConsentRequest) (*credit.Consent, error) {
+	appID, err := StringToUUID(req.ApplicationId)
+	if err != nil {
+		return nil, err
+	}
+	consentType, err := MapGRPCConsentTypeToDomain(req.Type)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	ipAddress, userAgent := clientInfo(ctx)
+	consent, err := domain.NewConsent(appID, consentType, req.Version, req.Channel, ipAddress, userAgent, time.Now().UTC())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	consent, err = s.consentUC.Record(ctx, consent)
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrApplicationNotFound):
+		return nil, status.Error(codes.NotFound, "application not found")
+	case errors.Is(err, usecase.ErrStatusEventNotSent):
+		// Согласие сохранено, отложенный переход выполнен, но событие не ушло.
+		return nil, status.Error(codes.Unavailable, err.Error())
+	default:
+		logger.FromContext(ctx).Error("consentUC execution failed",
+			zap.String("app_id", req.ApplicationId),
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to record consent")
+	}
+
+	return ToProtoConsent(consent), nil
+}
+
+func (s *ApplicationServiceServer) ListConsents(ctx context.Context, req *credit.ListConsentsRequest) (*credit.ListConsentsResponse, error) {
+	if _, err := StringToUUID(req.ApplicationId); err != nil {
+		return nil, err
+	}
+
+	consents, err := s.consentUC.List(ctx, req.ApplicationId)
+	switch {
+	case err == nil:
+	case errors.Is(err, domain.ErrApplicationNotFound):
+		return nil, status.Error(codes.NotFound, "application not found")
+	default:
+		logger.FromContext(ctx).Error("Failed to list consents",
+			zap.String("app_id", req.ApplicationId),
+			zap.Error(err),
+		)
+		return nil, status.Error(codes.Internal, "failed to list consents")
+	}
+
+	resp := &credit.ListConsentsResponse{Consents: make([]*credit.Consent, 0, len(consents))}
+	for _, consent := range consents {
+		resp.Consents = append(resp.Consents, ToProtoConsent(consent))
+	}
+	return resp, nil
+}
+
+// clientInfo returns the caller's address and user agent. The address is
+// resolved by middleware.ClientIPInterceptor, which believes forwarded
+// headers only from trusted proxies such as the REST gateway.
+func clientInfo(ctx context.Context) (ipAddress string, userAgent string) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, key := range []string{"grpcgateway-user-agent", "user-agent"} {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			userAgent = values[0]
+			break
+		}
+	}
+	return middleware.ClientIP(ctx), userAgent
+}
+
+func ToProtoConsent(consent *domain.Consent) *credit.Consent {
+	return &credit.Consent{
+		Id:            consent.ID.String(),
+		ApplicationId: consent.ApplicationID.String(),
+		Type:          MapDomainConsentTypeToGRPC(consent.Type),
+		Version:       consent.Version,
+		Channel:       consent.Channel,
+		IpAddress:     consent.IPAddress,
+		UserAgent:     consent.UserAgent,
+		GivenAt:       timestamppb.New(consent.GivenAt),
+	}
+}
+
+func MapGRPCConsentTypeToDomain(t credit.ConsentType) (domain.ConsentType, error) {
+	switch t {
+	case credit.ConsentType_CREDIT_BUREAU_CHECK:
+		return domain.ConsentCreditBureauCheck, nil
+	case credit.ConsentType_PERSONAL_DATA_PROCESSING:
+		return domain.ConsentPersonalDataProcessing, nil
+	case credit.ConsentType_AGREEMENT_TERMS:
+		return domain.ConsentAgreementTerms, nil
+	default:
+		return "", domain.ErrUnknownConsentType
+	}
+}
+
+func MapDomainConsentTypeToGRPC(t domain.ConsentType) credit.ConsentType {
+	switch t {
+	case domain.ConsentCreditBureauCheck:
+		return credit.ConsentType_CREDIT_BUREAU_CHECK
+	case domain.ConsentPersonalDataProcessing:
+		return credit.ConsentType_PERSONAL_DATA_PROCESSING
+	case domain.ConsentAgreementTerms:
+		return credit.ConsentType_AGREEMENT_TERMS
+	default:
+		return credit.ConsentType_CONSENT_TYPE_UNSPECIFIED
+	}
+}
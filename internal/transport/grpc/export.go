@@ -0,0 +1,90 @@
+package grpc
+
+import (
+	"bufio"
+	"errors"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/export"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// exportChunkSize keeps messages well under the default 4MB client limit.
+const exportChunkSize = 64 * 1024
+
+func (s *ApplicationServiceServer) ExportApplications(req *credit.ExportApplicationsRequest, stream credit.ApplicationService_ExportApplicationsServer) error {
+	columns, err := export.Columns(req.Columns)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	format, err := ToExportFormat(req.Format)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	filter := domain.ApplicationFilter{UserID: req.UserId}
+	if req.UserId != "" {
+		if _, err := StringToUUID(req.UserId); err != nil {
+			return status.Error(codes.InvalidArgument, "invalid user_id")
+		}
+	}
+	for _, st := range req.Status {
+		filter.Statuses = append(filter.Statuses, MapGRPCStatusToDomain(st))
+	}
+	if req.CreatedFrom != nil {
+		filter.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		filter.CreatedTo = req.CreatedTo.AsTime()
+	}
+
+	w := bufio.NewWriterSize(&chunkWriter{stream: stream}, exportChunkSize)
+	if _, err := s.exportUC.Execute(stream.Context(), filter, format, columns, w); err != nil {
+		if errors.Is(err, stream.Context().Err()) {
+			return status.FromContextError(err).Err()
+		}
+		logger.FromContext(stream.Context()).Error("Export failed", zap.Error(err))
+		return status.Error(codes.Internal, "export failed")
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func ToExportFormat(f credit.ExportFormat) (export.Format, error) {
+	switch f {
+	case credit.ExportFormat_EXPORT_FORMAT_CSV:
+		return export.FormatCSV, nil
+	case credit.ExportFormat_EXPORT_FORMAT_JSONL:
+		return export.FormatJSONL, nil
+	case credit.ExportFormat_EXPORT_FORMAT_PARQUET:
+		return export.FormatParquet, nil
+	}
+	return "", export.ErrUnknownFormat
+}
+
+// chunkWriter sends everything written to it as ExportChunk messages of at
+// most exportChunkSize bytes.
+type chunkWriter struct {
+	stream grpc.ServerStreamingServer[credit.ExportChunk]
+}
+
+func (cw *chunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := min(len(p), exportChunkSize)
+		// Send сериализует сообщение сразу, поэтому срез можно переиспользовать.
+		if err := cw.stream.Send(&credit.ExportChunk{Data: p[:n]}); err != nil {
+			return written, err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
@@ -0,0 +1,130 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/Andronzi/credit-origination/internal/chaos"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type FaultInjectionAdminServer struct {
+	credit.UnimplementedFaultInjectionAdminServiceServer
+	injector *chaos.Injector
+}
+
+func NewFaultInjectionAdminServer(injector *chaos.Injector) *FaultInjectionAdminServer {
+	return &FaultInjectionAdminServer{injector: injector}
+}
+
+func (s *FaultInjectionAdminServer) GetFaultInjection(ctx context.Context, _ *emptypb.Empty) (*credit.FaultInjectionConfig, error) {
+	return s.config(), nil
+}
+
+func (s *FaultInjectionAdminServer) SetFaultInjection(ctx context.Context, req *credit.FaultInjectionConfig) (*credit.FaultInjectionConfig, error) {
+	rules := make([]chaos.Rule, 0, len(req.Rules))
+	for _, r := range req.Rules {
+		rules = append(rules, ToDomainFaultRule(r))
+	}
+
+	if err := s.injector.SetRules(ctx, rules); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	s.injector.SetEnabled(ctx, req.Enabled)
+
+	logger.FromContext(ctx).Warn("Fault injection updated via admin RPC",
+		zap.Bool("enabled", req.Enabled),
+		zap.Int("rules", len(rules)),
+	)
+
+	return s.config(), nil
+}
+
+func (s *FaultInjectionAdminServer) config() *credit.FaultInjectionConfig {
+	rules := s.injector.Rules()
+	resp := &credit.FaultInjectionConfig{
+		Enabled: s.injector.Enabled(),
+		Rules:   make([]*credit.FaultRule, 0, len(rules)),
+	}
+	for _, r := range rules {
+		resp.Rules = append(resp.Rules, ToProtoFaultRule(r))
+	}
+	return resp
+}
+
+func ToDomainFaultRule(r *credit.FaultRule) chaos.Rule {
+	rule := chaos.Rule{
+		Name:       r.Name,
+		Operation:  r.Operation,
+		Headers:    r.Headers,
+		Percentage: r.Percentage,
+		Fault: chaos.Fault{
+			Code:    codes.Code(r.Code),
+			Message: r.Message,
+		},
+	}
+
+	switch r.Target {
+	case credit.FaultTarget_FAULT_TARGET_GRPC:
+		rule.Target = chaos.TargetGRPC
+	case credit.FaultTarget_FAULT_TARGET_KAFKA:
+		rule.Target = chaos.TargetKafka
+	case credit.FaultTarget_FAULT_TARGET_REPOSITORY:
+		rule.Target = chaos.TargetRepository
+	}
+
+	switch r.Type {
+	case credit.FaultType_FAULT_TYPE_LATENCY:
+		rule.Fault.Type = chaos.FaultLatency
+	case credit.FaultType_FAULT_TYPE_ERROR:
+		rule.Fault.Type = chaos.FaultError
+	case credit.FaultType_FAULT_TYPE_ABORT:
+		rule.Fault.Type = chaos.FaultAbort
+	}
+
+	if r.Latency != nil {
+		rule.Fault.Latency = r.Latency.AsDuration()
+	}
+
+	return rule
+}
+
+func ToProtoFaultRule(r chaos.Rule) *credit.FaultRule {
+	rule := &credit.FaultRule{
+		Name:       r.Name,
+		Operation:  r.Operation,
+		Headers:    r.Headers,
+		Percentage: r.Percentage,
+		Code:       int32(r.Fault.Code),
+		Message:    r.Fault.Message,
+	}
+
+	switch r.Target {
+	case chaos.TargetGRPC:
+		rule.Target = credit.FaultTarget_FAULT_TARGET_GRPC
+	case chaos.TargetKafka:
+		rule.Target = credit.FaultTarget_FAULT_TARGET_KAFKA
+	case chaos.TargetRepository:
+		rule.Target = credit.FaultTarget_FAULT_TARGET_REPOSITORY
+	}
+
+	switch r.Fault.Type {
+	case chaos.FaultLatency:
+		rule.Type = credit.FaultType_FAULT_TYPE_LATENCY
+	case chaos.FaultError:
+		rule.Type = credit.FaultType_FAULT_TYPE_ERROR
+	case chaos.FaultAbort:
+		rule.Type = credit.FaultType_FAULT_TYPE_ABORT
+	}
+
+	if r.Fault.Latency > 0 {
+		rule.Latency = durationpb.New(r.Fault.Latency)
+	}
+
+	return rule
+}
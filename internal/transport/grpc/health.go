@@ -0,0 +1,53 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/resilience"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// DependencyServicePrefix names the health service of each outbound
+// dependency, e.g. "dependency/bureau".
+const DependencyServicePrefix = "dependency/"
+
+// HealthReporter copies breaker states into the gRPC health service. The
+// overall service ("") stays SERVING: an open breaker degrades one feature,
+// and taking every replica out of balancing would not bring it back.
+type HealthReporter struct {
+	server   *health.Server
+	registry *resilience.Registry
+}
+
+func NewHealthReporter(server *health.Server, registry *resilience.Registry) *HealthReporter {
+	r := &HealthReporter{server: server, registry: registry}
+	r.report()
+	return r
+}
+
+func (r *HealthReporter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			r.server.Shutdown()
+			return
+		case <-ticker.C:
+			r.report()
+		}
+	}
+}
+
+func (r *HealthReporter) report() {
+	for _, state := range r.registry.States() {
+		status := healthpb.HealthCheckResponse_SERVING
+		if !state.Healthy() {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		r.server.SetServingStatus(DependencyServicePrefix+state.Name, status)
+	}
+	r.server.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+}
@@ -0,0 +1,109 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *ApplicationServiceServer) ListOffers(ctx context.Context, req *credit.ListOffersRequest) (*credit.ListOffersResponse, error) {
+	if _, err := StringToUUID(req.ApplicationId); err != nil {
+		return nil, err
+	}
+
+	app, offers, err := s.offerUC.List(ctx, req.ApplicationId)
+	if err != nil {
+		return nil, offerError(ctx, err, "failed to list offers")
+	}
+
+	resp := &credit.ListOffersResponse{Offers: make([]*credit.CounterOffer, 0, len(offers))}
+	for _, offer := range offers {
+		protoOffer, err := ToProtoCounterOffer(offer, app.Currency)
+		if err != nil {
+			return nil, err
+		}
+		resp.Offers = append(resp.Offers, protoOffer)
+	}
+	return resp, nil
+}
+
+func (s *ApplicationServiceServer) AcceptOffer(ctx context.Context, req *credit.AcceptOfferRequest) (*credit.ApplicationResponse, error) {
+	if _, err := StringToUUID(req.ApplicationId); err != nil {
+		return nil, err
+	}
+	if _, err := StringToUUID(req.OfferId); err != nil {
+		return nil, err
+	}
+
+	app, err := s.offerUC.Accept(ctx, req.ApplicationId, req.OfferId)
+	if err != nil {
+		return nil, offerError(ctx, err, "failed to accept offer")
+	}
+	return ToApplicationResponse(app)
+}
+
+func offerError(ctx context.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.ErrApplicationNotFound), errors.Is(err, domain.ErrOfferNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrOfferNotAcceptable),
+		errors.Is(err, domain.ErrApplicationConflict),
+		domain.IsTransitionError(err):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, usecase.ErrStatusEventNotSent):
+		// Предложение уже принято, повтор вернёт FailedPrecondition.
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	logger.FromContext(ctx).Error(message, zap.Error(err))
+	return status.Error(codes.Internal, message)
+}
+
+func ToProtoCounterOffer(offer *domain.CounterOffer, currency string) (*credit.CounterOffer, error) {
+	disbursementAmount, err := ToProtoDecimal("disbursement_amount", offer.DisbursementAmount)
+	if err != nil {
+		return nil, err
+	}
+	originationAmount, err := ToProtoDecimal("origination_amount", offer.OriginationAmount)
+	if err != nil {
+		return nil, err
+	}
+	interest, err := ToProtoDecimal("interest", offer.Interest)
+	if err != nil {
+		return nil, err
+	}
+	payment, err := ToProtoDecimal("monthly_payment", offer.MonthlyPayment)
+	if err != nil {
+		return nil, err
+	}
+	dti, err := ToProtoRatio("dti", offer.DTI)
+	if err != nil {
+		return nil, err
+	}
+	disbursementAmount.CurrencyCode = currency
+	originationAmount.CurrencyCode = currency
+	payment.CurrencyCode = currency
+
+	resp := &credit.CounterOffer{
+		Id:                 offer.ID.String(),
+		Kind:               string(offer.Kind),
+		DisbursementAmount: disbursementAmount,
+		OriginationAmount:  originationAmount,
+		Term:               offer.Term,
+		Interest:           interest,
+		MonthlyPayment:     payment,
+		Dti:                dti,
+		CreatedAt:          timestamppb.New(offer.CreatedAt),
+	}
+	if offer.AcceptedAt.Valid {
+		resp.AcceptedAt = timestamppb.New(offer.AcceptedAt.Time)
+	}
+	return resp, nil
+}
@@ -0,0 +1,30 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestToProtoCounterOfferEncodesFourDigitDTI(t *testing.T) {
+	offer := &domain.CounterOffer{
+		ID:                 uuid.New(),
+		Kind:               domain.OfferLongerTerm,
+		DisbursementAmount: decimal.RequireFromString("100000"),
+		OriginationAmount:  decimal.RequireFromString("100000"),
+		Term:               24,
+		Interest:           decimal.RequireFromString("15.5"),
+		MonthlyPayment:     decimal.RequireFromString("4872.33"),
+		DTI:                decimal.RequireFromString("0.4512"),
+	}
+
+	got, err := ToProtoCounterOffer(offer, "RUB")
+	if err != nil {
+		t.Fatalf("ToProtoCounterOffer: %v", err)
+	}
+	if got.Dti.Unscaled != 4512 || got.Dti.Scale != 4 {
+		t.Errorf("dti = %d/%d, want 4512/4", got.Dti.Unscaled, got.Dti.Scale)
+	}
+}
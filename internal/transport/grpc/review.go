@@ -0,0 +1,268 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/middleware"
+	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type ManualReviewServer struct {
+	credit.UnimplementedManualReviewServiceServer
+	reviewUC *usecase.ManualReviewUseCase
+}
+
+func NewManualReviewServer(reviewUC *usecase.ManualReviewUseCase) *ManualReviewServer {
+	return &ManualReviewServer{reviewUC: reviewUC}
+}
+
+func (s *ManualReviewServer) ListReviewQueue(ctx context.Context, req *credit.ListReviewQueueRequest) (*credit.ListReviewQueueResponse, error) {
+	filter := domain.ReviewQueueFilter{
+		Assignee:    req.Assignee,
+		ProductCode: req.ProductCode,
+	}
+	for _, state := range req.State {
+		filter.States = append(filter.States, MapGRPCReviewStateToDomain(state))
+	}
+
+	entries, total, err := s.reviewUC.List(ctx, filter, req.SlaBreachedOnly, int(req.Page), int(req.PageSize))
+	if err != nil {
+		return nil, reviewError(ctx, err, "failed to list review queue")
+	}
+
+	resp := &credit.ListReviewQueueResponse{
+		Reviews:    make([]*credit.Review, 0, len(entries)),
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+		TotalCount: uint32(total),
+	}
+	for _, entry := range entries {
+		review, err := ToReviewResponse(entry)
+		if err != nil {
+			return nil, err
+		}
+		resp.Reviews = append(resp.Reviews, review)
+	}
+	return resp, nil
+}
+
+func (s *ManualReviewServer) GetReview(ctx context.Context, req *credit.GetReviewRequest) (*credit.Review, error) {
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
+	entry, err := s.reviewUC.Get(ctx, req.Id)
+	return reviewResponse(ctx, entry, err)
+}
+
+func (s *ManualReviewServer) ClaimReview(ctx context.Context, req *credit.ReviewActionRequest) (*credit.Review, error) {
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
+	underwriter, err := requestUnderwriter(ctx, req.Underwriter)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.reviewUC.Claim(ctx, req.Id, underwriter)
+	return reviewResponse(ctx, entry, err)
+}
+
+func (s *ManualReviewServer) ReleaseReview(ctx context.Context, req *credit.ReviewActionRequest) (*credit.Review, error) {
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
+	underwriter, err := requestUnderwriter(ctx, req.Underwriter)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.reviewUC.Release(ctx, req.Id, underwriter)
+	return reviewResponse(ctx, entry, err)
+}
+
+func (s *ManualReviewServer) ApproveReview(ctx context.Context, req *credit.ReviewActionRequest) (*credit.Review, error) {
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
+	underwriter, err := requestUnderwriter(ctx, req.Underwriter)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.reviewUC.Approve(ctx, req.Id, underwriter)
+	return reviewResponse(ctx, entry, err)
+}
+
+func (s *ManualReviewServer) RejectReview(ctx context.Context, req *credit.RejectReviewRequest) (*credit.Review, error) {
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
+	underwriter, err := requestUnderwriter(ctx, req.Underwriter)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.reviewUC.Reject(ctx, req.Id, underwriter, req.Reason)
+	return reviewResponse(ctx, entry, err)
+}
+
+func (s *ManualReviewServer) AddReviewNote(ctx context.Context, req *credit.AddReviewNoteRequest) (*credit.Review, error) {
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
+	if req.Text == "" {
+		return nil, status.Error(codes.InvalidArgument, "text is required")
+	}
+	underwriter, err := requestUnderwriter(ctx, req.Author)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.reviewUC.AddNote(ctx, req.Id, underwriter, req.Text)
+	return reviewResponse(ctx, entry, err)
+}
+
+func (s *ManualReviewServer) AddReviewAttachment(ctx context.Context, req *credit.AddReviewAttachmentRequest) (*credit.Review, error) {
+	if _, err := StringToUUID(req.Id); err != nil {
+		return nil, err
+	}
+	if req.FileName == "" || req.Url == "" {
+		return nil, status.Error(codes.InvalidArgument, "file_name and url are required")
+	}
+	underwriter, err := requestUnderwriter(ctx, req.Author)
+	if err != nil {
+		return nil, err
+	}
+	entry, err := s.reviewUC.AddAttachment(ctx, req.Id, domain.ReviewAttachment{
+		Author:      underwriter,
+		FileName:    req.FileName,
+		ContentType: req.ContentType,
+		URL:         req.Url,
+	})
+	return reviewResponse(ctx, entry, err)
+}
+
+// requestUnderwriter returns the authenticated caller; a name in the request
+// body may only repeat it, so four-eyes cannot be passed by signing twice
+// under different names.
+func requestUnderwriter(ctx context.Context, claimed string) (string, error) {
+	principal, ok := middleware.Principal(ctx)
+	if !ok {
+		return "", status.Error(codes.Unauthenticated, "authentication is required")
+	}
+	if claimed != "" && claimed != principal {
+		return "", status.Errorf(codes.PermissionDenied, "underwriter %q is not the caller", claimed)
+	}
+	return principal, nil
+}
+
+func reviewResponse(ctx context.Context, entry usecase.ReviewQueueEntry, err error) (*credit.Review, error) {
+	if err != nil {
+		return nil, reviewError(ctx, err, "review operation failed")
+	}
+	return ToReviewResponse(entry)
+}
+
+func reviewError(ctx context.Context, err error, message string) error {
+	switch {
+	case errors.Is(err, domain.ErrReviewNotFound), errors.Is(err, domain.ErrApplicationNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, domain.ErrEmptyUnderwriter), errors.Is(err, domain.ErrEmptyReviewReason):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrReviewConflict):
+		return status.Error(codes.Aborted, err.Error())
+	case errors.Is(err, domain.ErrReviewAlreadyClaimed),
+		errors.Is(err, domain.ErrReviewNotClaimed),
+		errors.Is(err, domain.ErrReviewDecided),
+		errors.Is(err, domain.ErrSameApprover),
+		domain.IsTransitionError(err):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, usecase.ErrStatusEventNotSent):
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	logger.FromContext(ctx).Error(message, zap.Error(err))
+	return status.Error(codes.Internal, message)
+}
+
+func ToReviewResponse(entry usecase.ReviewQueueEntry) (*credit.Review, error) {
+	review := entry.Review
+	resp := &credit.Review{
+		Id:                     review.ID.String(),
+		ApplicationId:          review.ApplicationID.String(),
+		State:                  MapDomainReviewStateToGRPC(review.State),
+		Assignee:               review.Assignee.String,
+		ClaimedAt:              toProtoNullTime(review.ClaimedAt),
+		FirstApprover:          review.FirstApprover.String,
+		Decision:               review.Decision.String,
+		DecisionReason:         review.DecisionReason.String,
+		DecidedBy:              review.DecidedBy.String,
+		QueuedAt:               timestamppb.New(review.QueuedAt),
+		DecidedAt:              toProtoNullTime(review.DecidedAt),
+		Version:                review.Version,
+		TimeInQueue:            durationpb.New(entry.TimeInQueue),
+		SlaBreached:            entry.SLABreached,
+		RequiresSecondApproval: entry.RequiresSecondApproval,
+	}
+	for _, note := range review.Notes {
+		resp.Notes = append(resp.Notes, &credit.ReviewNote{
+			Id:        note.ID.String(),
+			Author:    note.Author,
+			Text:      note.Text,
+			CreatedAt: timestamppb.New(note.CreatedAt),
+		})
+	}
+	for _, attachment := range review.Attachments {
+		resp.Attachments = append(resp.Attachments, &credit.ReviewAttachment{
+			Id:          attachment.ID.String(),
+			Author:      attachment.Author,
+			FileName:    attachment.FileName,
+			ContentType: attachment.ContentType,
+			Url:         attachment.URL,
+			CreatedAt:   timestamppb.New(attachment.CreatedAt),
+		})
+	}
+	if review.Application != nil {
+		application, err := ToApplicationResponse(review.Application)
+		if err != nil {
+			return nil, err
+		}
+		resp.Application = application
+	}
+	return resp, nil
+}
+
+func toProtoNullTime(t sql.NullTime) *timestamppb.Timestamp {
+	if !t.Valid {
+		return nil
+	}
+	return timestamppb.New(t.Time)
+}
+
+func MapGRPCReviewStateToDomain(state credit.ReviewState) domain.ReviewState {
+	switch state {
+	case credit.ReviewState_REVIEW_STATE_CLAIMED:
+		return domain.REVIEW_CLAIMED
+	case credit.ReviewState_REVIEW_STATE_DECIDED:
+		return domain.REVIEW_DECIDED
+	default:
+		return domain.REVIEW_OPEN
+	}
+}
+
+func MapDomainReviewStateToGRPC(state domain.ReviewState) credit.ReviewState {
+	switch state {
+	case domain.REVIEW_OPEN:
+		return credit.ReviewState_REVIEW_STATE_OPEN
+	case domain.REVIEW_CLAIMED:
+		return credit.ReviewState_REVIEW_STATE_CLAIMED
+	case domain.REVIEW_DECIDED:
+		return credit.ReviewState_REVIEW_STATE_DECIDED
+	default:
+		return credit.ReviewState_REVIEW_STATE_UNSPECIFIED
+	}
+}
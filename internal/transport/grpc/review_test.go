@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/middleware"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRequestUnderwriterUsesCaller(t *testing.T) {
+	caller := middleware.WithPrincipal(context.Background(), "alice")
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		claimed string
+		want    string
+		code    codes.Code
+	}{
+		{"caller only", caller, "", "alice", codes.OK},
+		{"body repeats caller", caller, "alice", "alice", codes.OK},
+		{"body names someone else", caller, "bob", "", codes.PermissionDenied},
+		{"no caller", context.Background(), "alice", "", codes.Unauthenticated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := requestUnderwriter(tt.ctx, tt.claimed)
+			if status.Code(err) != tt.code {
+				t.Fatalf("code = %s, want %s (%v)", status.Code(err), tt.code, err)
+			}
+			if got != tt.want {
+				t.Fatalf("underwriter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package grpc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const watchSnapshotPageSize = 100
+
+func (s *ApplicationServiceServer) WatchApplication(req *credit.WatchApplicationRequest, stream credit.ApplicationService_WatchApplicationServer) error {
+	id, err := StringToUUID(req.Id)
+	if err != nil {
+		return err
+	}
+
+	// Подписка до чтения снимка, чтобы не потерять переход между ними.
+	changes, cancel := s.hub.Subscribe(func(c domain.StatusChange) bool {
+		return c.ApplicationID == id
+	})
+	defer cancel()
+
+	w := newWatcher(s, stream, map[string]int64{req.Id: req.FromVersion})
+	app, err := s.getUC.Execute(stream.Context(), req.Id)
+	if err != nil {
+		return watchLoadError(err)
+	}
+	if err := w.send(app); err != nil {
+		return err
+	}
+
+	return w.run(changes)
+}
+
+func (s *ApplicationServiceServer) WatchUserApplications(req *credit.WatchUserApplicationsRequest, stream credit.ApplicationService_WatchUserApplicationsServer) error {
+	userID, err := StringToUUID(req.UserId)
+	if err != nil {
+		return err
+	}
+
+	changes, cancel := s.hub.Subscribe(func(c domain.StatusChange) bool {
+		return c.UserID == userID
+	})
+	defer cancel()
+
+	known := make(map[string]int64, len(req.KnownVersions))
+	for id, version := range req.KnownVersions {
+		known[id] = version
+	}
+	w := newWatcher(s, stream, known)
+
+	for page := 1; ; page++ {
+		result, err := s.listUC.Execute(stream.Context(), nil, page, watchSnapshotPageSize, req.UserId)
+		if err != nil {
+			return status.Error(codes.Internal, "failed to list applications")
+		}
+		for _, app := range result.Applications {
+			if err := w.send(app); err != nil {
+				return err
+			}
+		}
+		if page >= result.TotalPages {
+			break
+		}
+	}
+
+	return w.run(changes)
+}
+
+// watcher sends application states to one stream, skipping versions the
+// client has already seen.
+type watcher struct {
+	s      *ApplicationServiceServer
+	stream grpc.ServerStreamingServer[credit.ApplicationUpdate]
+	sent   map[string]int64
+}
+
+func newWatcher(s *ApplicationServiceServer, stream grpc.ServerStreamingServer[credit.ApplicationUpdate], known map[string]int64) *watcher {
+	return &watcher{s: s, stream: stream, sent: known}
+}
+
+func (w *watcher) send(app *domain.CreditApplication) error {
+	id := app.ID.String()
+	if app.Version <= w.sent[id] {
+		return nil
+	}
+
+	resp, err := ToApplicationResponse(app)
+	if err != nil {
+		return err
+	}
+	if err := w.stream.Send(&credit.ApplicationUpdate{
+		Update: &credit.ApplicationUpdate_Application{Application: resp},
+	}); err != nil {
+		return err
+	}
+	w.sent[id] = app.Version
+	return nil
+}
+
+// run streams changes and heartbeats until the client goes away. A closed
+// changes channel means the hub dropped a lagging stream or the server is
+// stopping; the client should reconnect with the versions it has.
+func (w *watcher) run(changes <-chan domain.StatusChange) error {
+	ctx := w.stream.Context()
+	ticker := time.NewTicker(w.s.heartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := w.stream.Send(&credit.ApplicationUpdate{
+				Update: &credit.ApplicationUpdate_Heartbeat{
+					Heartbeat: &credit.Heartbeat{ServerTime: timestamppb.Now()},
+				},
+			}); err != nil {
+				return err
+			}
+		case change, ok := <-changes:
+			if !ok {
+				return status.Error(codes.Unavailable, "watch stream interrupted, resubscribe with the last received versions")
+			}
+			if change.Version <= w.sent[change.ApplicationID.String()] {
+				continue
+			}
+			app, err := w.s.getUC.Execute(ctx, change.ApplicationID.String())
+			if err != nil {
+				logger.FromContext(ctx).Error("Failed to load changed application",
+					zap.String("app_id", change.ApplicationID.String()),
+					zap.Error(err),
+				)
+				return watchLoadError(err)
+			}
+			if err := w.send(app); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watchLoadError ends a watch whose application cannot be loaded; a deleted
+// application is NotFound, as in Get.
+func watchLoadError(err error) error {
+	if errors.Is(err, domain.ErrApplicationNotFound) {
+		return status.Error(codes.NotFound, "application not found")
+	}
+	return status.Error(codes.Internal, "failed to load application")
+}
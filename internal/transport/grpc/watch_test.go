@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/usecase"
+	"github.com/Andronzi/credit-origination/internal/watch"
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// watchRepo keeps applications in memory for Get and UpdateStatus.
+type watchRepo struct {
+	domain.CreditRepository
+	mu   sync.Mutex
+	apps map[string]domain.CreditApplication
+}
+
+func (r *watchRepo) FindByID(_ context.Context, id string) (*domain.CreditApplication, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	app, ok := r.apps[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrApplicationNotFound, id)
+	}
+	return &app, nil
+}
+
+func (r *watchRepo) Update(_ context.Context, app *domain.CreditApplication) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.apps[app.ID.String()] = *app
+	return nil
+}
+
+func (r *watchRepo) delete(id string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.apps, id)
+}
+
+type noConsents struct{}
+
+func (noConsents) Create(context.Context, *domain.Consent) error { return nil }
+
+func (noConsents) ListByApplications(context.Context, []string) ([]*domain.Consent, error) {
+	return nil, nil
+}
+
+// watchStream hands sent updates to the test.
+type watchStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *credit.ApplicationUpdate
+}
+
+func (s *watchStream) Context() context.Context { return s.ctx }
+
+func (s *watchStream) Send(update *credit.ApplicationUpdate) error {
+	s.updates <- update
+	return nil
+}
+
+func (s *watchStream) next(t *testing.T) *credit.ApplicationResponse {
+	t.Helper()
+	select {
+	case update := <-s.updates:
+		return update.GetApplication()
+	case <-time.After(time.Second):
+		t.Fatal("no update sent")
+		return nil
+	}
+}
+
+func newWatchServer(repo *watchRepo, hub *watch.Hub) *ApplicationServiceServer {
+	return &ApplicationServiceServer{
+		getUC:             usecase.NewGetApplicationUseCase(repo),
+		hub:               hub,
+		heartbeatInterval: time.Hour,
+	}
+}
+
+func TestWatchApplication(t *testing.T) {
+	app := domain.CreditApplication{
+		ID:       uuid.New(),
+		UserID:   uuid.New(),
+		Currency: "RUB",
+		Status:   domain.APPLICATION_AGREEMENT_CREATED,
+		Version:  1,
+	}
+
+	tests := []struct {
+		name string
+		// act runs once the snapshot is sent.
+		act  func(repo *watchRepo, hub *watch.Hub) error
+		// next is the pending status of the update after act, "" if the
+		// stream ends instead.
+		next string
+		code codes.Code
+	}{
+		{
+			name: "deferred transition is sent",
+			act: func(repo *watchRepo, hub *watch.Hub) error {
+				policy := domain.NewConsentPolicy(map[domain.ApplicationStatus][]domain.ConsentType{
+					domain.SCORING: {domain.ConsentCreditBureauCheck},
+				}, nil)
+				return usecase.NewUpdateStatusUseCase(repo, noConsents{}, policy, nil, hub).
+					Execute(context.Background(), app.ID, domain.SCORING)
+			},
+			next: string(domain.SCORING),
+			code: codes.OK,
+		},
+		{
+			name: "deleted application",
+			act: func(repo *watchRepo, hub *watch.Hub) error {
+				repo.delete(app.ID.String())
+				return hub.NotifyStatusChange(context.Background(), domain.StatusChange{ApplicationID: app.ID, Version: 2})
+			},
+			code: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &watchRepo{apps: map[string]domain.CreditApplication{app.ID.String(): app}}
+			hub := watch.NewHub(8)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stream := &watchStream{ctx: ctx, updates: make(chan *credit.ApplicationUpdate, 8)}
+
+			done := make(chan error, 1)
+			go func() {
+				done <- newWatchServer(repo, hub).WatchApplication(&credit.WatchApplicationRequest{Id: app.ID.String()}, stream)
+			}()
+			if snapshot := stream.next(t); snapshot.Version != 1 {
+				t.Fatalf("snapshot version = %d, want 1", snapshot.Version)
+			}
+
+			if err := tt.act(repo, hub); err != nil {
+				t.Fatal(err)
+			}
+			if tt.next != "" {
+				got := stream.next(t)
+				if got.PendingStatus != tt.next || got.Version != 2 {
+					t.Fatalf("update pending = %q version = %d, want %q version 2", got.PendingStatus, got.Version, tt.next)
+				}
+				cancel()
+			}
+			select {
+			case err := <-done:
+				if status.Code(err) != tt.code {
+					t.Fatalf("code = %s, want %s (%v)", status.Code(err), tt.code, err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("watch did not end")
+			}
+		})
+	}
+}
+
+func TestWatchUnknownApplicationIsNotFound(t *testing.T) {
+	s := newWatchServer(&watchRepo{apps: map[string]domain.CreditApplication{}}, watch.NewHub(8))
+	stream := &watchStream{ctx: context.Background(), updates: make(chan *credit.ApplicationUpdate, 1)}
+
+	err := s.WatchApplication(&credit.WatchApplicationRequest{Id: uuid.NewString()}, stream)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("code = %s, want NotFound (%v)", status.Code(err), err)
+	}
+}
@@ -0,0 +1,416 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Andronzi/credit-origination/internal/antifraud"
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+var (
+	ErrBatchTooLarge       = errors.New("batch is too large")
+	ErrApplicationNotFound = domain.ErrApplicationNotFound
+	ErrEmptySelector       = errors.New("ids or filter is required")
+	ErrStatusEventNotSent  = errors.New("application saved, but its status event was not sent")
+)
+
+// BulkLimits bounds the size and parallelism of bulk operations.
+type BulkLimits struct {
+	ChunkSize        int
+	Concurrency      int
+	MaxBatchSize     int
+	MaxFilterMatches int
+}
+
+// forEachChunk calls fn for [from, to) chunks of n items, at most
+// limits.Concurrency at a time. Chunk failures are reported per item by fn.
+func forEachChunk(n int, limits BulkLimits, fn func(from, to int)) {
+	var g errgroup.Group
+	g.SetLimit(limits.Concurrency)
+	for from := 0; from < n; from += limits.ChunkSize {
+		to := min(from+limits.ChunkSize, n)
+		g.Go(func() error {
+			fn(from, to)
+			return nil
+		})
+	}
+	g.Wait()
+}
+
+type BatchGetApplicationsUseCase struct {
+	repo   domain.CreditRepository
+	limits BulkLimits
+}
+
+func NewBatchGetApplicationsUseCase(repo domain.CreditRepository, limits BulkLimits) *BatchGetApplicationsUseCase {
+	return &BatchGetApplicationsUseCase{repo, limits}
+}
+
+// Execute returns the found applications in the order of ids and the ids
+// that do not exist.
+func (uc *BatchGetApplicationsUseCase) Execute(ctx context.Context, ids []string) ([]*domain.CreditApplication, []string, error) {
+	if len(ids) > uc.limits.MaxBatchSize {
+		return nil, nil, fmt.Errorf("%w: %d ids, at most %d", ErrBatchTooLarge, len(ids), uc.limits.MaxBatchSize)
+	}
+	ids = uniqueIDs(ids)
+
+	found, err := uc.load(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	applications := make([]*domain.CreditApplication, 0, len(found))
+	var notFound []string
+	for _, id := range ids {
+		if app, ok := found[id]; ok {
+			applications = append(applications, app)
+		} else {
+			notFound = append(notFound, id)
+		}
+	}
+	return applications, notFound, nil
+}
+
+func (uc *BatchGetApplicationsUseCase) load(ctx context.Context, ids []string) (map[string]*domain.CreditApplication, error) {
+	var (
+		mu       sync.Mutex
+		found    = make(map[string]*domain.CreditApplication, len(ids))
+		firstErr error
+	)
+	forEachChunk(len(ids), uc.limits, func(from, to int) {
+		apps, err := uc.repo.FindByIDs(ctx, ids[from:to])
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return
+		}
+		for _, app := range apps {
+			found[app.ID.String()] = app
+		}
+	})
+	return found, firstErr
+}
+
+type BatchCreateApplicationsUseCase struct {
+	repo     domain.CreditRepository
+	reviews  domain.ReviewRepository
+	offers   domain.OfferRepository
+	screener *antifraud.Screener
+	// affordability is nil when the DTI check is disabled, counterOffers
+	// when no offers are generated.
+	affordability *domain.AffordabilityPolicy
+	counterOffers *domain.CounterOfferGenerator
+	tx            domain.Transactor
+	producer      *messaging.KafkaProducer
+	notifier      domain.StatusNotifier
+	limits        BulkLimits
+}
+
+func NewBatchCreateApplicationsUseCase(
+	repo domain.CreditRepository,
+	reviews domain.ReviewRepository,
+	offers domain.OfferRepository,
+	screener *antifraud.Screener,
+	affordability *domain.AffordabilityPolicy,
+	counterOffers *domain.CounterOfferGenerator,
+	tx domain.Transactor,
+	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
+	limits BulkLimits,
+) *BatchCreateApplicationsUseCase {
+	return &BatchCreateApplicationsUseCase{repo, reviews, offers, screener, affordability, counterOffers, tx, producer, notifier, limits}
+}
+
+// Execute creates the applications the way Create does: each one is screened,
+// saved and moved to AGREEMENT_CREATED, or to REJECTED, MANUAL_REVIEW or
+// COUNTER_OFFERED by antifraud and the DTI check, with a status event. The returned slice holds the error for apps[i] at
+// index i, nil on success.
+//
+// Items are screened in order before any chunk is saved, each against the
+// stored history and the items accepted before it, so a velocity burst
+// cannot pass by arriving in one batch.
+func (uc *BatchCreateApplicationsUseCase) Execute(ctx context.Context, apps []*domain.CreditApplication) ([]error, error) {
+	if len(apps) > uc.limits.MaxBatchSize {
+		return nil, fmt.Errorf("%w: %d items, at most %d", ErrBatchTooLarge, len(apps), uc.limits.MaxBatchSize)
+	}
+
+	errs := make([]error, len(apps))
+	offers := make([][]*domain.CounterOffer, len(apps))
+	var accepted []*domain.CreditApplication
+	for i, app := range apps {
+		if err := screenApplication(ctx, uc.screener, app, accepted...); err != nil {
+			errs[i] = err
+			continue
+		}
+		appOffers, err := assessAffordability(uc.affordability, uc.counterOffers, app)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		offers[i] = appOffers
+		if app.PassedChecks() {
+			if err := app.ChangeStatus(domain.APPLICATION_AGREEMENT_CREATED); err != nil {
+				errs[i] = err
+				continue
+			}
+		}
+		accepted = append(accepted, app)
+	}
+
+	forEachChunk(len(apps), uc.limits, func(from, to int) {
+		var chunk []*domain.CreditApplication
+		var positions []int
+		for i := from; i < to; i++ {
+			if errs[i] == nil {
+				chunk = append(chunk, apps[i])
+				positions = append(positions, i)
+			}
+		}
+		if len(chunk) == 0 {
+			return
+		}
+
+		// Чанк сохраняется целиком вместе с очередью ревью и предложениями.
+		err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.repo.SaveAll(ctx, chunk); err != nil {
+				return err
+			}
+			for k, app := range chunk {
+				if err := saveCheckOutcome(ctx, uc.reviews, uc.offers, app, offers[positions[k]]); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			logger.FromContext(ctx).Error("Failed to save application chunk",
+				zap.Int("from", from),
+				zap.Int("size", len(chunk)),
+				zap.Error(err),
+			)
+			for _, i := range positions {
+				errs[i] = err
+			}
+			return
+		}
+
+		for k, app := range chunk {
+			if err := publishStatusChange(ctx, uc.producer, uc.notifier, app); err != nil {
+				errs[positions[k]] = err
+			}
+		}
+	})
+
+	return errs, nil
+}
+
+// BulkSelector picks applications either by IDs or by a filter.
+type BulkSelector struct {
+	IDs      []string
+	Statuses []domain.ApplicationStatus
+	UserID   string
+	ByFilter bool
+}
+
+type BulkTransitionResult struct {
+	ID      string
+	Version int64
+	Err     error
+}
+
+type BulkTransitionUseCase struct {
+	repo          domain.CreditRepository
+	consents      domain.ConsentRepository
+	consentPolicy *domain.ConsentPolicy
+	producer      *messaging.KafkaProducer
+	notifier      domain.StatusNotifier
+	limits        BulkLimits
+}
+
+func NewBulkTransitionUseCase(
+	repo domain.CreditRepository,
+	consents domain.ConsentRepository,
+	consentPolicy *domain.ConsentPolicy,
+	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
+	limits BulkLimits,
+) *BulkTransitionUseCase {
+	return &BulkTransitionUseCase{repo, consents, consentPolicy, producer, notifier, limits}
+}
+
+// Execute moves every selected application to target, chunk by chunk. A chunk
+// is written in one transaction; a transition that the state machine forbids
+// only fails its own item. As in UpdateStatusUseCase, a transition that lacks
+// consents is not failed but kept in pending_status until they are recorded;
+// its item succeeds without a status event.
+func (uc *BulkTransitionUseCase) Execute(ctx context.Context, selector BulkSelector, target domain.ApplicationStatus, reason string) ([]BulkTransitionResult, error) {
+	ids, err := uc.resolve(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]BulkTransitionResult, len(ids))
+	for i, id := range ids {
+		results[i].ID = id
+	}
+
+	forEachChunk(len(ids), uc.limits, func(from, to int) {
+		chunkIDs := ids[from:to]
+		apps, err := uc.repo.FindByIDs(ctx, chunkIDs)
+		if err != nil {
+			for i := from; i < to; i++ {
+				results[i].Err = err
+			}
+			return
+		}
+		found := make(map[string]*domain.CreditApplication, len(apps))
+		for _, app := range apps {
+			found[app.ID.String()] = app
+		}
+		var consents []*domain.Consent
+		if uc.consentPolicy != nil {
+			if consents, err = uc.consents.ListByApplications(ctx, chunkIDs); err != nil {
+				for i := from; i < to; i++ {
+					results[i].Err = err
+				}
+				return
+			}
+		}
+
+		var changed []*domain.CreditApplication
+		var positions []int
+		// awaiting are the positions of transitions deferred until consents.
+		awaiting := make(map[int]bool)
+		for i := from; i < to; i++ {
+			app, ok := found[ids[i]]
+			if !ok {
+				results[i].Err = ErrApplicationNotFound
+				continue
+			}
+			if uc.consentPolicy != nil {
+				if err := uc.consentPolicy.Check(app, target, consents); err != nil {
+					if !errors.Is(err, domain.ErrConsentsMissing) {
+						results[i].Err = err
+						continue
+					}
+					app.AwaitConsents(target)
+					changed = append(changed, app)
+					positions = append(positions, i)
+					awaiting[i] = true
+					continue
+				}
+			}
+			if err := app.ChangeStatus(target); err != nil {
+				results[i].Err = err
+				continue
+			}
+			if target == domain.REJECTED && reason != "" {
+				app.RejectReason = sql.NullString{String: reason, Valid: true}
+			}
+			changed = append(changed, app)
+			positions = append(positions, i)
+		}
+		if len(changed) == 0 {
+			return
+		}
+
+		if err := uc.repo.UpdateAll(ctx, changed); err != nil {
+			logger.FromContext(ctx).Error("Failed to update application chunk",
+				zap.Int("from", from),
+				zap.Int("size", len(changed)),
+				zap.Error(err),
+			)
+			for _, i := range positions {
+				results[i].Err = err
+			}
+			return
+		}
+
+		for k, app := range changed {
+			i := positions[k]
+			results[i].Version = app.Version
+			if awaiting[i] {
+				logger.FromContext(ctx).Warn("Transition blocked until consents are recorded",
+					zap.String("app_id", app.ID.String()),
+					zap.String("new_status", string(target)),
+				)
+				notifyStatusChange(ctx, uc.notifier, app)
+				continue
+			}
+			if err := publishStatusChange(ctx, uc.producer, uc.notifier, app); err != nil {
+				results[i].Err = err
+			}
+		}
+	})
+
+	logger.FromContext(ctx).Info("Bulk transition finished",
+		zap.String("target", string(target)),
+		zap.Int("selected", len(ids)),
+	)
+	return results, nil
+}
+
+// resolve turns the selector into IDs up front, so that moving applications
+// out of a status filter does not shift the pages still to be read.
+func (uc *BulkTransitionUseCase) resolve(ctx context.Context, selector BulkSelector) ([]string, error) {
+	if !selector.ByFilter {
+		if len(selector.IDs) == 0 {
+			return nil, ErrEmptySelector
+		}
+		if len(selector.IDs) > uc.limits.MaxBatchSize {
+			return nil, fmt.Errorf("%w: %d ids, at most %d", ErrBatchTooLarge, len(selector.IDs), uc.limits.MaxBatchSize)
+		}
+		return uniqueIDs(selector.IDs), nil
+	}
+
+	// Один запрос: страницы без сортировки по offset могут пропускать строки.
+	apps, total, err := uc.repo.List(ctx, selector.Statuses, 0, uc.limits.MaxFilterMatches, selector.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if total > uc.limits.MaxFilterMatches {
+		return nil, fmt.Errorf("%w: filter matches %d applications, at most %d", ErrBatchTooLarge, total, uc.limits.MaxFilterMatches)
+	}
+	ids := make([]string, 0, len(apps))
+	for _, app := range apps {
+		ids = append(ids, app.ID.String())
+	}
+	return ids, nil
+}
+
+// publishStatusChange emits the Kafka status event and notifies watchers for
+// an application whose status change is already committed.
+func publishStatusChange(ctx context.Context, producer *messaging.KafkaProducer, notifier domain.StatusNotifier, app *domain.CreditApplication) error {
+	notifyStatusChange(ctx, notifier, app)
+	if err := producer.SendStatusEvent(ctx, createStatusEvent(ctx, app)); err != nil {
+		logger.FromContext(ctx).Error("Failed to send status event",
+			zap.String("app_id", app.ID.String()),
+			zap.Error(err),
+		)
+		return fmt.Errorf("%w: %w", ErrStatusEventNotSent, err)
+	}
+	return nil
+}
+
+func uniqueIDs(ids []string) []string {
+	seen := make(map[string]bool, len(ids))
+	unique := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
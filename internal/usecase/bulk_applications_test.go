@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/antifraud"
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+)
+
+var testBulkLimits = BulkLimits{ChunkSize: 2, Concurrency: 2, MaxBatchSize: 10, MaxFilterMatches: 10}
+
+func TestBulkTransitionDefersTransitionsWithoutConsents(t *testing.T) {
+	consented := &domain.CreditApplication{ID: uuid.New(), Status: domain.APPLICATION_AGREEMENT_CREATED, Version: 1}
+	unconsented := &domain.CreditApplication{ID: uuid.New(), Status: domain.APPLICATION_AGREEMENT_CREATED, Version: 1}
+	rejected := &domain.CreditApplication{ID: uuid.New(), Status: domain.REJECTED, Version: 1}
+	repo := newMemoryRepo(consented, unconsented, rejected)
+	consents := &memoryConsents{consents: []*domain.Consent{
+		{ApplicationID: consented.ID, Type: domain.ConsentCreditBureauCheck},
+		{ApplicationID: rejected.ID, Type: domain.ConsentCreditBureauCheck},
+	}}
+	policy := domain.NewConsentPolicy(map[domain.ApplicationStatus][]domain.ConsentType{
+		domain.SCORING: {domain.ConsentCreditBureauCheck},
+	}, nil)
+	producer, sent := newTestProducer(t)
+	uc := NewBulkTransitionUseCase(repo, consents, policy, producer, &recordingNotifier{}, testBulkLimits)
+
+	ids := []string{consented.ID.String(), unconsented.ID.String(), rejected.ID.String()}
+	results, err := uc.Execute(context.Background(), BulkSelector{IDs: ids}, domain.SCORING, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		app     *domain.CreditApplication
+		failed  bool
+		status  domain.ApplicationStatus
+		pending string
+	}{
+		{"consents given", consented, false, domain.SCORING, ""},
+		{"consents missing", unconsented, false, domain.APPLICATION_AGREEMENT_CREATED, string(domain.SCORING)},
+		{"forbidden transition", rejected, true, domain.REJECTED, ""},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if (results[i].Err != nil) != tt.failed {
+				t.Fatalf("item error = %v, failed = %v", results[i].Err, tt.failed)
+			}
+			stored := repo.stored(tt.app.ID)
+			if stored.Status != tt.status || stored.PendingStatus.String != tt.pending {
+				t.Fatalf("status = %s, pending = %q; want %s, %q", stored.Status, stored.PendingStatus.String, tt.status, tt.pending)
+			}
+		})
+	}
+	if keys := sent.sent(); len(keys) != 1 || keys[0] != consented.ID.String() {
+		t.Fatalf("sent events for %v, want only the transitioned application", keys)
+	}
+	if !domain.IsTransitionError(results[2].Err) {
+		t.Fatalf("forbidden transition error = %v", results[2].Err)
+	}
+}
+
+func TestBatchCreateScreensAgainstEarlierItems(t *testing.T) {
+	user := uuid.New()
+	var apps []*domain.CreditApplication
+	for i := 0; i < 3; i++ {
+		app := staleApplication(domain.DRAFT, time.Now().UTC())
+		app.UserID = user
+		app.CreatedAt = app.UpdatedAt
+		apps = append(apps, app)
+	}
+	repo := newMemoryRepo()
+	screener := antifraud.NewScreener(repo,
+		[]antifraud.WeightedRule{{Rule: antifraud.VelocityRule{Max: 2, Window: time.Hour}, Score: 100}},
+		antifraud.Thresholds{Review: 40, Reject: 80}, time.Hour, 10)
+	producer, _ := newTestProducer(t)
+	uc := NewBatchCreateApplicationsUseCase(repo, nil, nil, screener, nil, nil, inlineTx{}, producer, &recordingNotifier{}, testBulkLimits)
+
+	errs, err := uc.Execute(context.Background(), apps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []domain.ApplicationStatus{domain.APPLICATION_AGREEMENT_CREATED, domain.APPLICATION_AGREEMENT_CREATED, domain.REJECTED}
+	for i, app := range apps {
+		if errs[i] != nil {
+			t.Fatalf("item %d: %v", i, errs[i])
+		}
+		if stored := repo.stored(app.ID); stored.Status != want[i] {
+			t.Fatalf("item %d status = %s, want %s", i, stored.Status, want[i])
+		}
+	}
+}
+
+func TestBatchGetApplications(t *testing.T) {
+	first := staleApplication(domain.DRAFT, time.Now())
+	second := staleApplication(domain.SCORING, time.Now())
+	missing := uuid.NewString()
+	uc := NewBatchGetApplicationsUseCase(newMemoryRepo(first, second), testBulkLimits)
+
+	tests := []struct {
+		name         string
+		ids          []string
+		wantFound    []string
+		wantNotFound []string
+		wantErr      error
+	}{
+		{"keeps the order of ids", []string{second.ID.String(), first.ID.String()},
+			[]string{second.ID.String(), first.ID.String()}, nil, nil},
+		{"reports missing ids", []string{first.ID.String(), missing},
+			[]string{first.ID.String()}, []string{missing}, nil},
+		{"drops duplicates", []string{first.ID.String(), first.ID.String(), first.ID.String()},
+			[]string{first.ID.String()}, nil, nil},
+		{"too many ids", make([]string, testBulkLimits.MaxBatchSize+1), nil, nil, ErrBatchTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apps, notFound, err := uc.Execute(context.Background(), tt.ids)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			var found []string
+			for _, app := range apps {
+				found = append(found, app.ID.String())
+			}
+			if !slices.Equal(found, tt.wantFound) || !slices.Equal(notFound, tt.wantNotFound) {
+				t.Fatalf("Execute() = %v, %v, want %v, %v", found, notFound, tt.wantFound, tt.wantNotFound)
+			}
+		})
+	}
+}
+
+// failingSaveRepo fails SaveAll for every chunk that holds the application.
+type failingSaveRepo struct {
+	*memoryRepo
+	failFor uuid.UUID
+}
+
+var errSaveFailed = errors.New("save failed")
+
+func (r *failingSaveRepo) SaveAll(ctx context.Context, apps []*domain.CreditApplication) error {
+	for _, app := range apps {
+		if app.ID == r.failFor {
+			return errSaveFailed
+		}
+	}
+	return r.memoryRepo.SaveAll(ctx, apps)
+}
+
+func TestBatchCreateApplications(t *testing.T) {
+	newDrafts := func(n int) []*domain.CreditApplication {
+		apps := make([]*domain.CreditApplication, n)
+		for i := range apps {
+			apps[i] = staleApplication(domain.DRAFT, time.Now().UTC())
+		}
+		return apps
+	}
+
+	tests := []struct {
+		name string
+		apps []*domain.CreditApplication
+		// failAt is the item whose chunk fails to save, -1 for none.
+		failAt   int
+		wantErrs []error
+		wantErr  error
+	}{
+		{"all saved", newDrafts(3), -1, []error{nil, nil, nil}, nil},
+		// Чанки по два элемента: ошибка второго валит и первый, третий сохраняется.
+		{"failed chunk fails its items only", newDrafts(3), 1, []error{errSaveFailed, errSaveFailed, nil}, nil},
+		{"empty batch", nil, -1, []error{}, nil},
+		{"too many items", newDrafts(testBulkLimits.MaxBatchSize + 1), -1, nil, ErrBatchTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &failingSaveRepo{memoryRepo: newMemoryRepo()}
+			if tt.failAt >= 0 {
+				repo.failFor = tt.apps[tt.failAt].ID
+			}
+			producer, sent := newTestProducer(t)
+			uc := NewBatchCreateApplicationsUseCase(repo, nil, nil, nil, nil, nil, inlineTx{}, producer, &recordingNotifier{}, testBulkLimits)
+
+			errs, err := uc.Execute(context.Background(), tt.apps)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(errs) != len(tt.wantErrs) {
+				t.Fatalf("got %d item errors, want %d", len(errs), len(tt.wantErrs))
+			}
+
+			var wantSent []string
+			for i, app := range tt.apps {
+				if !errors.Is(errs[i], tt.wantErrs[i]) || (tt.wantErrs[i] == nil && errs[i] != nil) {
+					t.Fatalf("item %d error = %v, want %v", i, errs[i], tt.wantErrs[i])
+				}
+				saved := repo.stored(app.ID).ID == app.ID
+				if saved != (tt.wantErrs[i] == nil) {
+					t.Fatalf("item %d saved = %v", i, saved)
+				}
+				if saved {
+					wantSent = append(wantSent, app.ID.String())
+				}
+			}
+			// Одно событие на каждую сохранённую заявку.
+			got := sent.sent()
+			slices.Sort(got)
+			slices.Sort(wantSent)
+			if !slices.Equal(got, wantSent) {
+				t.Fatalf("sent events for %v, want %v", got, wantSent)
+			}
+		})
+	}
+}
+
+func TestBulkTransitionSelectors(t *testing.T) {
+	user := uuid.New()
+	scoring := staleApplication(domain.SCORING, time.Now())
+	scoring.UserID = user
+	otherUser := staleApplication(domain.SCORING, time.Now())
+	draft := staleApplication(domain.DRAFT, time.Now())
+	draft.UserID = user
+	missing := uuid.NewString()
+
+	tests := []struct {
+		name     string
+		selector BulkSelector
+		limits   BulkLimits
+		wantIDs  []string
+		wantErrs []error
+		wantErr  error
+	}{
+		{"by ids", BulkSelector{IDs: []string{scoring.ID.String(), otherUser.ID.String()}}, testBulkLimits,
+			[]string{scoring.ID.String(), otherUser.ID.String()}, []error{nil, nil}, nil},
+		{"duplicate ids", BulkSelector{IDs: []string{scoring.ID.String(), scoring.ID.String()}}, testBulkLimits,
+			[]string{scoring.ID.String()}, []error{nil}, nil},
+		{"missing id fails its item", BulkSelector{IDs: []string{missing, scoring.ID.String()}}, testBulkLimits,
+			[]string{missing, scoring.ID.String()}, []error{ErrApplicationNotFound, nil}, nil},
+		{"by status and user", BulkSelector{ByFilter: true, Statuses: []domain.ApplicationStatus{domain.SCORING}, UserID: user.String()}, testBulkLimits,
+			[]string{scoring.ID.String()}, []error{nil}, nil},
+		{"no ids", BulkSelector{}, testBulkLimits, nil, nil, ErrEmptySelector},
+		{"too many ids", BulkSelector{IDs: make([]string, testBulkLimits.MaxBatchSize+1)}, testBulkLimits, nil, nil, ErrBatchTooLarge},
+		{"filter matches too many", BulkSelector{ByFilter: true, Statuses: []domain.ApplicationStatus{domain.SCORING}},
+			BulkLimits{ChunkSize: 2, Concurrency: 2, MaxBatchSize: 10, MaxFilterMatches: 1}, nil, nil, ErrBatchTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newMemoryRepo(scoring, otherUser, draft)
+			producer, sent := newTestProducer(t)
+			uc := NewBulkTransitionUseCase(repo, nil, nil, producer, &recordingNotifier{}, tt.limits)
+
+			results, err := uc.Execute(context.Background(), tt.selector, domain.REJECTED, "Bulk clean-up")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Execute() error = %v, want %v", err, tt.wantErr)
+			}
+			if len(results) != len(tt.wantIDs) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.wantIDs))
+			}
+
+			var wantSent []string
+			for i, result := range results {
+				if result.ID != tt.wantIDs[i] || !errors.Is(result.Err, tt.wantErrs[i]) || (tt.wantErrs[i] == nil && result.Err != nil) {
+					t.Fatalf("result %d = %s, %v; want %s, %v", i, result.ID, result.Err, tt.wantIDs[i], tt.wantErrs[i])
+				}
+				if result.Err != nil {
+					continue
+				}
+				stored := repo.stored(uuid.MustParse(result.ID))
+				if stored.Status != domain.REJECTED || stored.RejectReason.String != "Bulk clean-up" || result.Version != stored.Version {
+					t.Fatalf("stored %s = %s %q v%d, result v%d", result.ID, stored.Status, stored.RejectReason.String, stored.Version, result.Version)
+				}
+				wantSent = append(wantSent, result.ID)
+			}
+			got := sent.sent()
+			slices.Sort(got)
+			slices.Sort(wantSent)
+			if !slices.Equal(got, wantSent) {
+				t.Fatalf("sent events for %v, want %v", got, wantSent)
+			}
+			if stored := repo.stored(draft.ID); stored.Status != domain.DRAFT {
+				t.Fatalf("unselected application moved to %s", stored.Status)
+			}
+		})
+	}
+}
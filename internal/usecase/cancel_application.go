@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type CancelApplicationUseCase struct {
+	repo     domain.CreditRepository
+	producer *messaging.KafkaProducer
+	notifier domain.StatusNotifier
+}
+
+func NewCancelApplicationUseCase(
+	repo domain.CreditRepository,
+	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
+) *CancelApplicationUseCase {
+	return &CancelApplicationUseCase{repo, producer, notifier}
+}
+
+// Execute withdraws the application of caller and publishes a CANCELLED
+// event, so the agreement and scoring services stop working on it. The
+// cancellation is saved only if the application is unchanged since it was
+// read; otherwise ErrApplicationConflict is returned and nothing is published.
+func (uc *CancelApplicationUseCase) Execute(ctx context.Context, appID, caller, reason string) (*domain.CreditApplication, error) {
+	app, err := uc.repo.FindByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	if !app.OwnedBy(caller) {
+		logger.FromContext(ctx).Warn("Cancel requested by another user",
+			zap.String("app_id", appID),
+		)
+		return nil, domain.ErrNotApplicationOwner
+	}
+
+	readStatus, readVersion := app.Status, app.Version
+	if err := app.Cancel(reason); err != nil {
+		logger.FromContext(ctx).Info("Application cannot be cancelled",
+			zap.String("app_id", appID),
+			zap.String("status", string(app.Status)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	if err := uc.repo.UpdateIfCurrent(ctx, app, readStatus, readVersion); err != nil {
+		logger.FromContext(ctx).Error("Failed to save cancelled application",
+			zap.String("app_id", appID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("Application cancelled",
+		zap.String("app_id", appID),
+		zap.String("reason", app.CancelReason.String),
+	)
+
+	if err := publishStatusChange(ctx, uc.producer, uc.notifier, app); err != nil {
+		return app, err
+	}
+	return app, nil
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestCancelApplication(t *testing.T) {
+	tests := []struct {
+		name string
+		// caller is the owner unless set.
+		caller string
+		// concurrent, if set, changes the stored application between the
+		// read and the write of Cancel.
+		concurrent func(app *domain.CreditApplication) error
+		err        error
+		status     domain.ApplicationStatus
+	}{
+		{name: "owner cancels", status: domain.CANCELLED},
+		{name: "another user", caller: uuid.NewString(), err: domain.ErrNotApplicationOwner, status: domain.SCORING},
+		{
+			name:       "changed after read",
+			concurrent: func(app *domain.CreditApplication) error { return app.ChangeStatus(domain.APPROVED) },
+			err:        domain.ErrApplicationConflict,
+			status:     domain.APPROVED,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := staleApplication(domain.SCORING, time.Now())
+			repo := newMemoryRepo(app)
+			if tt.concurrent != nil {
+				repo.afterRead = func() {
+					changed := repo.stored(app.ID)
+					if err := tt.concurrent(&changed); err != nil {
+						t.Fatal(err)
+					}
+					if err := repo.Update(context.Background(), &changed); err != nil {
+						t.Fatal(err)
+					}
+				}
+			}
+			caller := tt.caller
+			if caller == "" {
+				caller = app.UserID.String()
+			}
+			producer, sent := newTestProducer(t)
+			uc := NewCancelApplicationUseCase(repo, producer, &recordingNotifier{})
+
+			_, err := uc.Execute(context.Background(), app.ID.String(), caller, "changed my mind")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Execute error = %v, want %v", err, tt.err)
+			}
+			if stored := repo.stored(app.ID); stored.Status != tt.status {
+				t.Fatalf("status = %s, want %s", stored.Status, tt.status)
+			}
+			wantSent := 0
+			if tt.err == nil {
+				wantSent = 1
+			}
+			if got := len(sent.sent()); got != wantSent {
+				t.Fatalf("sent %d events, want %d", got, wantSent)
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type ConsentUseCase struct {
+	repo     domain.CreditRepository
+	consents domain.ConsentRepository
+	policy   *domain.ConsentPolicy
+	tx       domain.Transactor
+	producer *messaging.KafkaProducer
+	notifier domain.StatusNotifier
+}
+
+func NewConsentUseCase(
+	repo domain.CreditRepository,
+	consents domain.ConsentRepository,
+	policy *domain.ConsentPolicy,
+	tx domain.Transactor,
+	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
+) *ConsentUseCase {
+	return &ConsentUseCase{repo, consents, policy, tx, producer, notifier}
+}
+
+// Record stores the consent. When it completes the consents a deferred
+// transition was waiting for, the transition is made and published.
+func (uc *ConsentUseCase) Record(ctx context.Context, consent *domain.Consent) (*domain.Consent, error) {
+	appID := consent.ApplicationID.String()
+	app, err := uc.repo.FindByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.consents.Create(ctx, consent); err != nil {
+		logger.FromContext(ctx).Error("Failed to save consent",
+			zap.String("app_id", appID),
+			zap.String("type", string(consent.Type)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("Consent recorded",
+		zap.String("app_id", appID),
+		zap.String("type", string(consent.Type)),
+		zap.String("version", consent.Version),
+		zap.String("channel", consent.Channel),
+	)
+
+	if !app.PendingStatus.Valid {
+		return consent, nil
+	}
+	return consent, uc.resume(ctx, app)
+}
+
+func (uc *ConsentUseCase) resume(ctx context.Context, app *domain.CreditApplication) error {
+	pending := domain.ApplicationStatus(app.PendingStatus.String)
+	if err := checkConsents(ctx, uc.consents, uc.policy, app, pending); err != nil {
+		if errors.Is(err, domain.ErrConsentsMissing) {
+			return nil
+		}
+		return err
+	}
+
+	if err := app.ResumePending(); err != nil {
+		// Заявка ушла в другой статус, пока ждала согласий; переход больше не нужен.
+		logger.FromContext(ctx).Info("Dropping deferred transition",
+			zap.String("app_id", app.ID.String()),
+			zap.String("pending_status", string(pending)),
+			zap.Error(err),
+		)
+		app.DropPending()
+		if err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+			if err := uc.repo.Update(ctx, app); err != nil {
+				return err
+			}
+			return uc.repo.ClearPendingStatus(ctx, app.ID.String())
+		}); err != nil {
+			return err
+		}
+		notifyStatusChange(ctx, uc.notifier, app)
+		return nil
+	}
+
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Update(ctx, app); err != nil {
+			return err
+		}
+		return uc.repo.ClearPendingStatus(ctx, app.ID.String())
+	})
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to save resumed transition",
+			zap.String("app_id", app.ID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	logger.FromContext(ctx).Info("Deferred transition made",
+		zap.String("app_id", app.ID.String()),
+		zap.String("status", string(app.Status)),
+	)
+	return publishStatusChange(ctx, uc.producer, uc.notifier, app)
+}
+
+func (uc *ConsentUseCase) List(ctx context.Context, appID string) ([]*domain.Consent, error) {
+	if _, err := uc.repo.FindByID(ctx, appID); err != nil {
+		return nil, err
+	}
+	return uc.consents.ListByApplications(ctx, []string{appID})
+}
+
+// checkConsents fails with ErrConsentsMissing when the application lacks
+// consents required to enter status. A nil policy requires nothing.
+func checkConsents(ctx context.Context, consents domain.ConsentRepository, policy *domain.ConsentPolicy, app *domain.CreditApplication, status domain.ApplicationStatus) error {
+	if policy == nil || len(policy.Required(app, status)) == 0 {
+		return nil
+	}
+	given, err := consents.ListByApplications(ctx, []string{app.ID.String()})
+	if err != nil {
+		return err
+	}
+	return policy.Check(app, status, given)
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+type CounterOfferUseCase struct {
+	repo     domain.CreditRepository
+	offers   domain.OfferRepository
+	tx       domain.Transactor
+	producer *messaging.KafkaProducer
+	notifier domain.StatusNotifier
+}
+
+func NewCounterOfferUseCase(
+	repo domain.CreditRepository,
+	offers domain.OfferRepository,
+	tx domain.Transactor,
+	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
+) *CounterOfferUseCase {
+	return &CounterOfferUseCase{repo, offers, tx, producer, notifier}
+}
+
+// List returns the application with its offers; amounts of the offers are
+// in the application currency.
+func (uc *CounterOfferUseCase) List(ctx context.Context, appID string) (*domain.CreditApplication, []*domain.CounterOffer, error) {
+	app, err := uc.repo.FindByID(ctx, appID)
+	if err != nil {
+		return nil, nil, err
+	}
+	offers, err := uc.offers.ListByApplication(ctx, appID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return app, offers, nil
+}
+
+// Accept applies the offer's terms to the application and moves it to
+// AGREEMENT_CREATED, from where it goes to scoring and approval as usual.
+func (uc *CounterOfferUseCase) Accept(ctx context.Context, appID string, offerID string) (*domain.CreditApplication, error) {
+	app, err := uc.repo.FindByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	offer, err := uc.offers.FindByID(ctx, offerID)
+	if err != nil {
+		return nil, err
+	}
+
+	readStatus, readVersion := app.Status, app.Version
+	if err := app.AcceptOffer(offer, time.Now().UTC()); err != nil {
+		logger.FromContext(ctx).Info("Offer cannot be accepted",
+			zap.String("app_id", appID),
+			zap.String("offer_id", offerID),
+			zap.String("status", string(app.Status)),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+
+	// Заявка пишется, только если её не изменили после чтения (отмена,
+	// истечение срока), и блокируется до конца транзакции: из двух одновременных
+	// принятий разных предложений пройдёт одно, второе увидит первое.
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.UpdateIfCurrent(ctx, app, readStatus, readVersion); err != nil {
+			return err
+		}
+		return uc.offers.MarkAccepted(ctx, offer)
+	})
+	if errors.Is(err, domain.ErrOfferNotAcceptable) || errors.Is(err, domain.ErrApplicationConflict) {
+		return nil, err
+	}
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to save application with accepted offer",
+			zap.String("app_id", appID),
+			zap.String("offer_id", offerID),
+			zap.Error(err),
+		)
+		return nil, err
+	}
+	logger.FromContext(ctx).Info("Counter-offer accepted",
+		zap.String("app_id", appID),
+		zap.String("offer_id", offerID),
+		zap.String("kind", string(offer.Kind)),
+	)
+
+	if err := publishStatusChange(ctx, uc.producer, uc.notifier, app); err != nil {
+		return app, err
+	}
+	return app, nil
+}
@@ -0,0 +1,53 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func TestAcceptDoesNotOverwriteConcurrentCancel(t *testing.T) {
+	app := &domain.CreditApplication{
+		ID:                 uuid.New(),
+		DisbursementAmount: decimal.NewFromInt(100000),
+		Currency:           "RUB",
+		Status:             domain.COUNTER_OFFERED,
+		Version:            3,
+	}
+	offer := &domain.CounterOffer{
+		ID:                 uuid.New(),
+		ApplicationID:      app.ID,
+		Kind:               domain.OfferLowerAmount,
+		DisbursementAmount: decimal.NewFromInt(50000),
+		OriginationAmount:  decimal.NewFromInt(50000),
+		Term:               12,
+	}
+	repo, offers := newMemoryRepo(app), newMemoryOffers(offer)
+
+	// Клиент отменил заявку между чтением и записью принятия.
+	repo.afterRead = func() {
+		cancelled := repo.stored(app.ID)
+		if err := cancelled.Cancel("changed my mind"); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Update(context.Background(), &cancelled); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	uc := NewCounterOfferUseCase(repo, offers, inlineTx{}, nil, nil)
+	_, err := uc.Accept(context.Background(), app.ID.String(), offer.ID.String())
+	if !errors.Is(err, domain.ErrApplicationConflict) {
+		t.Fatalf("Accept error = %v, want ErrApplicationConflict", err)
+	}
+	if stored := repo.stored(app.ID); stored.Status != domain.CANCELLED {
+		t.Fatalf("status = %s, the cancellation must survive", stored.Status)
+	}
+	if offers.offers[offer.ID].AcceptedAt.Valid {
+		t.Fatal("offer of a cancelled application was accepted")
+	}
+}
@@ -2,40 +2,150 @@ package usecase
 
 import (
 	"context"
-	"log"
 
+	"github.com/Andronzi/credit-origination/internal/antifraud"
 	"github.com/Andronzi/credit-origination/internal/client"
 	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
 )
 
 type CreateApplicationUseCase struct {
-	repo    domain.CreditRepository
-	scoring *client.ScoringClient
+	repo     domain.CreditRepository
+	reviews  domain.ReviewRepository
+	offers   domain.OfferRepository
+	scoring  *client.ScoringClient
+	screener *antifraud.Screener
+	// affordability is nil when the DTI check is disabled, counterOffers
+	// when no offers are generated.
+	affordability *domain.AffordabilityPolicy
+	counterOffers *domain.CounterOfferGenerator
+	tx            domain.Transactor
+	producer      *messaging.KafkaProducer
+	notifier      domain.StatusNotifier
 }
 
+// NewCreateApplicationUseCase takes a nil screener when antifraud is disabled,
+// a nil affordability policy when the DTI check is disabled and a nil
+// generator when declined applications get no counter-offers.
 func NewCreateApplicationUseCase(
 	repo domain.CreditRepository,
+	reviews domain.ReviewRepository,
+	offers domain.OfferRepository,
 	scoring *client.ScoringClient,
+	screener *antifraud.Screener,
+	affordability *domain.AffordabilityPolicy,
+	counterOffers *domain.CounterOfferGenerator,
+	tx domain.Transactor,
+	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
 ) *CreateApplicationUseCase {
-	return &CreateApplicationUseCase{repo, scoring}
+	return &CreateApplicationUseCase{repo, reviews, offers, scoring, screener, affordability, counterOffers, tx, producer, notifier}
 }
 
+// Execute screens and saves the application. An application rejected by
+// antifraud or by the DTI check is saved as REJECTED, one held by antifraud
+// goes to the manual review queue and one that failed the DTI check with
+// counter-offers is saved as COUNTER_OFFERED with the offers, all with their
+// status event. The application is saved in one transaction with its review
+// or offers. The caller moves only applications that passed the checks
+// further.
 func (uc *CreateApplicationUseCase) Execute(ctx context.Context, app *domain.CreditApplication) error {
-	log.Printf("Creating application with ID: %s", app.ID)
+	ctx = logger.WithApplicationID(ctx, app.ID.String())
+	log := logger.FromContext(ctx)
+	log.Info("Creating application")
 
-	if err := uc.repo.Save(ctx, app); err != nil {
-		log.Printf("Repository error: %v", err)
+	if err := screenApplication(ctx, uc.screener, app); err != nil {
+		log.Error("Antifraud check failed", zap.Error(err))
+		return err
+	}
+	offers, err := assessAffordability(uc.affordability, uc.counterOffers, app)
+	if err != nil {
+		log.Error("Affordability check failed", zap.Error(err))
+		return err
+	}
+
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Save(ctx, app); err != nil {
+			return err
+		}
+		return saveCheckOutcome(ctx, uc.reviews, uc.offers, app, offers)
+	})
+	if err != nil {
+		log.Error("Failed to save application", zap.Error(err))
 		return err
 	}
 
+	switch app.Status {
+	case domain.REJECTED:
+		log.Info("Application rejected",
+			zap.String("reason", app.RejectReason.String),
+			zap.Int("score", app.RiskScore),
+			zap.String("dti", app.DTI.Decimal.String()),
+		)
+		return publishStatusChange(ctx, uc.producer, uc.notifier, app)
+	case domain.MANUAL_REVIEW:
+		log.Info("Application sent to manual review", zap.Int("score", app.RiskScore))
+		return publishStatusChange(ctx, uc.producer, uc.notifier, app)
+	case domain.COUNTER_OFFERED:
+		log.Info("Application counter-offered", zap.Int("offers", len(offers)), zap.String("dti", app.DTI.Decimal.String()))
+		return publishStatusChange(ctx, uc.producer, uc.notifier, app)
+	}
+
 	// TODO: Добавить асинхронное действие верификации
 	// go uc.verifyAsync(ctx, app.ID)
 
-	log.Printf("Application created successfully: %s", app.ID)
+	log.Info("Application created successfully")
 
 	return nil
 }
 
+// screenApplication stores the antifraud verdict on a new application and
+// moves it to REJECTED or MANUAL_REVIEW accordingly.
+func screenApplication(ctx context.Context, screener *antifraud.Screener, app *domain.CreditApplication, pending ...*domain.CreditApplication) error {
+	if screener == nil {
+		return nil
+	}
+	assessment, err := screener.Assess(ctx, app, pending...)
+	if err != nil {
+		return err
+	}
+	return app.ApplyRisk(assessment)
+}
+
+// saveCheckOutcome saves what the checks left besides the application: the
+// review of one held by antifraud or the counter-offers of one that failed
+// the DTI check. Callers run it in the transaction that saves the application.
+func saveCheckOutcome(ctx context.Context, reviews domain.ReviewRepository, offerRepo domain.OfferRepository, app *domain.CreditApplication, offers []*domain.CounterOffer) error {
+	switch app.Status {
+	case domain.MANUAL_REVIEW:
+		return enqueueReview(ctx, reviews, app)
+	case domain.COUNTER_OFFERED:
+		return offerRepo.CreateAll(ctx, offers)
+	}
+	return nil
+}
+
+// assessAffordability stores the DTI check on a new application with
+// financials. When the ratio is too high it returns the counter-offers the
+// application was parked with, or rejects it. Applications already rejected
+// by antifraud are not assessed, and ones held by it get no offers.
+func assessAffordability(policy *domain.AffordabilityPolicy, generator *domain.CounterOfferGenerator, app *domain.CreditApplication) ([]*domain.CounterOffer, error) {
+	if policy == nil || !app.HasFinancials() || app.Status == domain.REJECTED {
+		return nil, nil
+	}
+	assessment, err := policy.Assess(app)
+	if err != nil {
+		return nil, err
+	}
+	var offers []*domain.CounterOffer
+	if assessment.Decision == domain.AffordabilityFail && generator != nil && app.PassedRiskCheck() {
+		offers = generator.Generate(app, app.UpdatedAt, nil)
+	}
+	return offers, app.ApplyAffordability(assessment, offers)
+}
+
 // TODO: Реализовать логику верификации заявки
 /*
 func (uc *CreateApplicationUseCase) verifyAsync(ctx context.Context, appID string) {
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// ExpiryFenceName fences the writes of the expiry job.
+const ExpiryFenceName = "expire-applications"
+
+type ExpireApplicationsUseCase struct {
+	repo      domain.CreditRepository
+	tx        domain.Transactor
+	fence     domain.Fence
+	producer  *messaging.KafkaProducer
+	notifier  domain.StatusNotifier
+	policy    *domain.ExpiryPolicy
+	batchSize int
+}
+
+func NewExpireApplicationsUseCase(
+	repo domain.CreditRepository,
+	tx domain.Transactor,
+	fence domain.Fence,
+	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
+	policy *domain.ExpiryPolicy,
+	batchSize int,
+) *ExpireApplicationsUseCase {
+	return &ExpireApplicationsUseCase{repo, tx, fence, producer, notifier, policy, batchSize}
+}
+
+// Execute rejects up to batchSize stale applications per status and product
+// with the EXPIRED reason and returns how many were expired. The rest are
+// left for the next run; one failed application does not stop the others.
+// Applications that changed after they were listed are skipped.
+//
+// Every write is fenced with token, the fencing token of the leadership term
+// running the job: once a newer leader has written, the run stops with
+// ErrStaleFencingToken.
+func (uc *ExpireApplicationsUseCase) Execute(ctx context.Context, now time.Time, token int64) (int, error) {
+	expired := 0
+	var errs []error
+	for _, filter := range uc.policy.StaleFilters(now) {
+		apps, err := uc.repo.ListStale(ctx, filter, uc.batchSize)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		for _, app := range apps {
+			err := uc.expire(ctx, app, token)
+			if errors.Is(err, domain.ErrStaleFencingToken) {
+				return expired, err
+			}
+			if errors.Is(err, domain.ErrApplicationConflict) {
+				// Заявка сдвинулась после выборки, она больше не просрочена.
+				logger.FromContext(ctx).Info("Skipping application changed since it was listed",
+					zap.String("app_id", app.ID.String()),
+					zap.String("status", string(filter.Status)),
+				)
+				continue
+			}
+			if err != nil {
+				logger.FromContext(ctx).Error("Failed to expire application",
+					zap.String("app_id", app.ID.String()),
+					zap.String("status", string(filter.Status)),
+					zap.Error(err),
+				)
+				errs = append(errs, err)
+				continue
+			}
+			expired++
+		}
+	}
+	return expired, errors.Join(errs...)
+}
+
+func (uc *ExpireApplicationsUseCase) expire(ctx context.Context, app *domain.CreditApplication, token int64) error {
+	status, version := app.Status, app.Version
+	if err := app.Expire(); err != nil {
+		return err
+	}
+	err := uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.fence.Check(ctx, ExpiryFenceName, token); err != nil {
+			return err
+		}
+		return uc.repo.UpdateIfCurrent(ctx, app, status, version)
+	})
+	if err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Application expired",
+		zap.String("app_id", app.ID.String()),
+		zap.String("status", string(status)),
+		zap.Time("updated_at", app.UpdatedAt),
+	)
+	return publishStatusChange(ctx, uc.producer, uc.notifier, app)
+}
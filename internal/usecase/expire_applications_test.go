@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+func staleApplication(status domain.ApplicationStatus, updated time.Time) *domain.CreditApplication {
+	return &domain.CreditApplication{
+		ID:                 uuid.New(),
+		UserID:             uuid.New(),
+		ToBankAccountID:    uuid.New(),
+		DisbursementAmount: decimal.NewFromInt(100000),
+		OriginationAmount:  decimal.NewFromInt(100000),
+		Interest:           decimal.NewFromInt(15),
+		ProductCode:        "code-1",
+		Currency:           "RUB",
+		Status:             status,
+		Version:            4,
+		UpdatedAt:          updated,
+	}
+}
+
+func TestExpireSkipsApplicationsChangedAfterListing(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	stale := staleApplication(domain.SCORING, now.Add(-48*time.Hour))
+	scored := staleApplication(domain.SCORING, now.Add(-48*time.Hour))
+	repo := newMemoryRepo(stale, scored)
+
+	// Результат скоринга приходит, пока джоба обрабатывает выборку.
+	repo.afterRead = func() {
+		approved := repo.stored(scored.ID)
+		if err := approved.ChangeStatus(domain.APPROVED); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Update(context.Background(), &approved); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	policy, err := domain.NewExpiryPolicy(map[domain.ApplicationStatus]time.Duration{domain.SCORING: 24 * time.Hour}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	producer, sent := newTestProducer(t)
+	uc := NewExpireApplicationsUseCase(repo, inlineTx{}, &memoryFence{}, producer, &recordingNotifier{}, policy, 10)
+
+	expired, err := uc.Execute(context.Background(), now, 1)
+	if err != nil {
+		t.Fatalf("a concurrent transition must not fail the run: %v", err)
+	}
+	if expired != 1 {
+		t.Fatalf("expired = %d, want 1", expired)
+	}
+	if got := repo.stored(scored.ID); got.Status != domain.APPROVED {
+		t.Fatalf("scored application is %s, the approval must survive", got.Status)
+	}
+	if got := repo.stored(stale.ID); got.Status != domain.REJECTED || got.RejectReason.String != domain.ExpiredReason {
+		t.Fatalf("stale application is %s %q, want REJECTED %s", got.Status, got.RejectReason.String, domain.ExpiredReason)
+	}
+	if keys := sent.sent(); len(keys) != 1 || keys[0] != stale.ID.String() {
+		t.Fatalf("events for %v, want only the expired application", keys)
+	}
+}
+
+func TestExpireStopsWithStaleFencingToken(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := staleApplication(domain.SCORING, now.Add(-48*time.Hour))
+	second := staleApplication(domain.SCORING, now.Add(-48*time.Hour))
+	repo := newMemoryRepo(first, second)
+
+	policy, err := domain.NewExpiryPolicy(map[domain.ApplicationStatus]time.Duration{domain.SCORING: 24 * time.Hour}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	producer, sent := newTestProducer(t)
+	fence := &memoryFence{}
+	uc := NewExpireApplicationsUseCase(repo, inlineTx{}, fence, producer, &recordingNotifier{}, policy, 10)
+
+	// Новый лидер уже писал с токеном 8, старый лидер с токеном 7 не должен.
+	if err := fence.Check(context.Background(), ExpiryFenceName, 8); err != nil {
+		t.Fatal(err)
+	}
+	expired, err := uc.Execute(context.Background(), now, 7)
+	if !errors.Is(err, domain.ErrStaleFencingToken) {
+		t.Fatalf("Execute error = %v, want ErrStaleFencingToken", err)
+	}
+	if expired != 0 || repo.updates != 0 || len(sent.sent()) != 0 {
+		t.Fatalf("expired %d, updates %d, events %d: a replaced leader must not write", expired, repo.updates, len(sent.sent()))
+	}
+
+	expired, err = uc.Execute(context.Background(), now, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expired != 2 {
+		t.Fatalf("expired = %d, the current leader expires both", expired)
+	}
+}
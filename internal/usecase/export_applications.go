@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"context"
+	"io"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/export"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const exportBatchSize = 1000
+
+type ExportApplicationsUseCase struct {
+	repo domain.CreditRepository
+}
+
+func NewExportApplicationsUseCase(repo domain.CreditRepository) *ExportApplicationsUseCase {
+	return &ExportApplicationsUseCase{repo}
+}
+
+// Execute writes every application matching filter to w and returns the row
+// count. Rows are read in batches, so memory does not grow with the result.
+func (uc *ExportApplicationsUseCase) Execute(
+	ctx context.Context,
+	filter domain.ApplicationFilter,
+	format export.Format,
+	columns []export.Column,
+	w io.Writer,
+) (int, error) {
+	ew, err := export.NewWriter(format, columns, w)
+	if err != nil {
+		return 0, err
+	}
+
+	rows := 0
+	err = uc.repo.Export(ctx, filter, exportBatchSize, func(apps []*domain.CreditApplication) error {
+		for _, app := range apps {
+			if err := ew.Write(app); err != nil {
+				return err
+			}
+			rows++
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		return rows, err
+	}
+	if err := ew.Close(); err != nil {
+		return rows, err
+	}
+
+	logger.FromContext(ctx).Info("Applications exported",
+		zap.String("format", string(format)),
+		zap.Int("rows", rows),
+	)
+	return rows, nil
+}
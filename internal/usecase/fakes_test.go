@@ -0,0 +1,280 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/internal/resilience"
+	"github.com/IBM/sarama"
+	"github.com/google/uuid"
+)
+
+// memoryRepo keeps applications in memory with the version check of
+// UpdateIfCurrent. Methods the tests do not use panic on the nil embedded
+// interface.
+type memoryRepo struct {
+	domain.CreditRepository
+	mu      sync.Mutex
+	apps    map[uuid.UUID]domain.CreditApplication
+	updates int
+	// afterRead, if set, runs once after the next FindByID or ListStale,
+	// e.g. to commit a concurrent write between a read and the write based
+	// on it.
+	afterRead func()
+}
+
+func newMemoryRepo(apps ...*domain.CreditApplication) *memoryRepo {
+	r := &memoryRepo{apps: make(map[uuid.UUID]domain.CreditApplication)}
+	for _, app := range apps {
+		r.apps[app.ID] = *app
+	}
+	return r
+}
+
+func (r *memoryRepo) stored(id uuid.UUID) domain.CreditApplication {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.apps[id]
+}
+
+func (r *memoryRepo) FindByID(_ context.Context, id string) (*domain.CreditApplication, error) {
+	r.mu.Lock()
+	app, ok := r.apps[uuid.MustParse(id)]
+	hook := r.afterRead
+	r.afterRead = nil
+	r.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", domain.ErrApplicationNotFound, id)
+	}
+	if hook != nil {
+		hook()
+	}
+	return &app, nil
+}
+
+func (r *memoryRepo) FindByIDs(_ context.Context, ids []string) ([]*domain.CreditApplication, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var found []*domain.CreditApplication
+	for _, id := range ids {
+		if app, ok := r.apps[uuid.MustParse(id)]; ok {
+			found = append(found, &app)
+		}
+	}
+	return found, nil
+}
+
+func (r *memoryRepo) ListStale(_ context.Context, filter domain.StaleFilter, limit int) ([]*domain.CreditApplication, error) {
+	r.mu.Lock()
+	var stale []*domain.CreditApplication
+	for _, app := range r.apps {
+		if app.Status == filter.Status && app.UpdatedAt.Before(filter.UpdatedBefore) && len(stale) < limit {
+			app := app
+			stale = append(stale, &app)
+		}
+	}
+	hook := r.afterRead
+	r.afterRead = nil
+	r.mu.Unlock()
+	if hook != nil {
+		hook()
+	}
+	return stale, nil
+}
+
+func (r *memoryRepo) ListByUserOrBankAccount(_ context.Context, userID, bankAccountID string, since time.Time, limit int) ([]*domain.CreditApplication, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var history []*domain.CreditApplication
+	for _, app := range r.apps {
+		related := app.UserID.String() == userID || app.ToBankAccountID.String() == bankAccountID
+		if related && !app.CreatedAt.Before(since) && len(history) < limit {
+			app := app
+			history = append(history, &app)
+		}
+	}
+	return history, nil
+}
+
+func (r *memoryRepo) List(_ context.Context, statuses []domain.ApplicationStatus, offset int, limit int, userID string) ([]*domain.CreditApplication, int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var matched []*domain.CreditApplication
+	for _, app := range r.apps {
+		if userID != "" && app.UserID.String() != userID {
+			continue
+		}
+		if len(statuses) > 0 && !slices.Contains(statuses, app.Status) {
+			continue
+		}
+		app := app
+		matched = append(matched, &app)
+	}
+	total := len(matched)
+	matched = matched[min(offset, total):min(offset+limit, total)]
+	return matched, total, nil
+}
+
+func (r *memoryRepo) SaveAll(_ context.Context, apps []*domain.CreditApplication) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, app := range apps {
+		r.apps[app.ID] = *app
+	}
+	return nil
+}
+
+func (r *memoryRepo) Update(_ context.Context, app *domain.CreditApplication) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.apps[app.ID] = *app
+	r.updates++
+	return nil
+}
+
+func (r *memoryRepo) UpdateAll(_ context.Context, apps []*domain.CreditApplication) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, app := range apps {
+		r.apps[app.ID] = *app
+		r.updates++
+	}
+	return nil
+}
+
+func (r *memoryRepo) UpdateIfCurrent(_ context.Context, app *domain.CreditApplication, expectedStatus domain.ApplicationStatus, expectedVersion int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	current := r.apps[app.ID]
+	if current.Status != expectedStatus || current.Version != expectedVersion {
+		return fmt.Errorf("%w: %s", domain.ErrApplicationConflict, app.ID)
+	}
+	r.apps[app.ID] = *app
+	r.updates++
+	return nil
+}
+
+type memoryConsents struct {
+	consents []*domain.Consent
+}
+
+func (c *memoryConsents) Create(_ context.Context, consent *domain.Consent) error {
+	c.consents = append(c.consents, consent)
+	return nil
+}
+
+func (c *memoryConsents) ListByApplications(context.Context, []string) ([]*domain.Consent, error) {
+	return c.consents, nil
+}
+
+// inlineTx runs fn without a transaction; fakes do not roll back.
+type inlineTx struct{}
+
+func (inlineTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type memoryOffers struct {
+	domain.OfferRepository
+	offers map[uuid.UUID]*domain.CounterOffer
+}
+
+func newMemoryOffers(offers ...*domain.CounterOffer) *memoryOffers {
+	o := &memoryOffers{offers: make(map[uuid.UUID]*domain.CounterOffer)}
+	for _, offer := range offers {
+		o.offers[offer.ID] = offer
+	}
+	return o
+}
+
+func (o *memoryOffers) FindByID(_ context.Context, id string) (*domain.CounterOffer, error) {
+	offer, ok := o.offers[uuid.MustParse(id)]
+	if !ok {
+		return nil, domain.ErrOfferNotFound
+	}
+	copied := *offer
+	return &copied, nil
+}
+
+func (o *memoryOffers) MarkAccepted(_ context.Context, offer *domain.CounterOffer) error {
+	o.offers[offer.ID].AcceptedAt = offer.AcceptedAt
+	return nil
+}
+
+// recordingProducer keeps the keys of sent messages, i.e. application IDs.
+type recordingProducer struct {
+	sarama.SyncProducer
+	mu   sync.Mutex
+	keys []string
+}
+
+func (p *recordingProducer) SendMessage(msg *sarama.ProducerMessage) (int32, int64, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	key, _ := msg.Key.Encode()
+	p.keys = append(p.keys, string(key))
+	return 0, int64(len(p.keys)), nil
+}
+
+func (p *recordingProducer) sent() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.keys...)
+}
+
+func newTestProducer(t *testing.T) (*messaging.KafkaProducer, *recordingProducer) {
+	t.Helper()
+	schema, err := os.ReadFile("../../schemas/avro/application/v3/ApplicationEvent.avsc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	registry, err := resilience.NewRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	recorder := &recordingProducer{}
+	producer, err := messaging.NewKafkaProducerFromSync(recorder, "application", string(schema), 1,
+		registry.Register("kafka", resilience.Settings{Retry: resilience.RetryPolicy{Attempts: 1}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return producer, recorder
+}
+
+type recordingNotifier struct {
+	mu      sync.Mutex
+	changes []domain.StatusChange
+}
+
+func (n *recordingNotifier) NotifyStatusChange(_ context.Context, change domain.StatusChange) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.changes = append(n.changes, change)
+	return nil
+}
+
+// memoryFence keeps the greatest accepted token of every name.
+type memoryFence struct {
+	mu     sync.Mutex
+	tokens map[string]int64
+}
+
+func (f *memoryFence) Check(_ context.Context, name string, token int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.tokens == nil {
+		f.tokens = make(map[string]int64)
+	}
+	if token < f.tokens[name] {
+		return fmt.Errorf("%w: %s token %d", domain.ErrStaleFencingToken, name, token)
+	}
+	f.tokens[name] = token
+	return nil
+}
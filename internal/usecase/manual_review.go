@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ReviewQueueEntry is a review with its SLA state at the time of listing.
+// RequiresSecondApproval reports that the amount is above the four-eyes
+// threshold.
+type ReviewQueueEntry struct {
+	Review                 *domain.Review
+	TimeInQueue            time.Duration
+	SLABreached            bool
+	RequiresSecondApproval bool
+}
+
+type ManualReviewUseCase struct {
+	reviews  domain.ReviewRepository
+	repo     domain.CreditRepository
+	tx       domain.Transactor
+	producer *messaging.KafkaProducer
+	notifier domain.StatusNotifier
+	fourEyes *domain.FourEyesPolicy
+	sla      time.Duration
+}
+
+func NewManualReviewUseCase(
+	reviews domain.ReviewRepository,
+	repo domain.CreditRepository,
+	tx domain.Transactor,
+	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
+	fourEyes *domain.FourEyesPolicy,
+	sla time.Duration,
+) *ManualReviewUseCase {
+	return &ManualReviewUseCase{reviews, repo, tx, producer, notifier, fourEyes, sla}
+}
+
+// List returns the queue oldest first. breachedOnly keeps reviews waiting
+// longer than the SLA.
+func (uc *ManualReviewUseCase) List(ctx context.Context, filter domain.ReviewQueueFilter, breachedOnly bool, page int, pageSize int) ([]ReviewQueueEntry, int, error) {
+	now := time.Now().UTC()
+	if breachedOnly {
+		filter.QueuedBefore = now.Add(-uc.sla)
+	}
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = 20
+	}
+
+	reviews, total, err := uc.reviews.ListQueue(ctx, filter, (page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, 0, err
+	}
+	entries := make([]ReviewQueueEntry, 0, len(reviews))
+	for _, review := range reviews {
+		entries = append(entries, uc.entry(review, now))
+	}
+	return entries, total, nil
+}
+
+func (uc *ManualReviewUseCase) Get(ctx context.Context, id string) (ReviewQueueEntry, error) {
+	review, err := uc.reviews.FindByID(ctx, id)
+	if err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	return uc.entry(review, time.Now().UTC()), nil
+}
+
+func (uc *ManualReviewUseCase) Claim(ctx context.Context, id string, underwriter string) (ReviewQueueEntry, error) {
+	return uc.change(ctx, id, func(review *domain.Review, now time.Time) error {
+		return review.Claim(underwriter, now)
+	})
+}
+
+func (uc *ManualReviewUseCase) Release(ctx context.Context, id string, underwriter string) (ReviewQueueEntry, error) {
+	return uc.change(ctx, id, func(review *domain.Review, _ time.Time) error {
+		return review.Release(underwriter)
+	})
+}
+
+// Approve moves the application on to AGREEMENT_CREATED, or only records the
+// first of two required approvals.
+func (uc *ManualReviewUseCase) Approve(ctx context.Context, id string, underwriter string) (ReviewQueueEntry, error) {
+	return uc.decide(ctx, id, func(review *domain.Review, app *domain.CreditApplication, now time.Time) (bool, error) {
+		final, err := review.Approve(underwriter, uc.fourEyes.Requires(app), now)
+		if err != nil || !final {
+			return false, err
+		}
+		return true, app.ChangeStatus(domain.APPLICATION_AGREEMENT_CREATED)
+	})
+}
+
+func (uc *ManualReviewUseCase) Reject(ctx context.Context, id string, underwriter string, reason string) (ReviewQueueEntry, error) {
+	return uc.decide(ctx, id, func(review *domain.Review, app *domain.CreditApplication, now time.Time) (bool, error) {
+		if err := review.Reject(underwriter, reason, now); err != nil {
+			return false, err
+		}
+		return true, app.Reject(review.DecisionReason.String)
+	})
+}
+
+func (uc *ManualReviewUseCase) AddNote(ctx context.Context, id string, author string, text string) (ReviewQueueEntry, error) {
+	review, err := uc.reviews.FindByID(ctx, id)
+	if err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	if strings.TrimSpace(author) == "" {
+		return ReviewQueueEntry{}, domain.ErrEmptyUnderwriter
+	}
+	note := domain.ReviewNote{
+		ID:        uuid.New(),
+		ReviewID:  review.ID,
+		Author:    author,
+		Text:      text,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := uc.reviews.AddNote(ctx, &note); err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	review.Notes = append(review.Notes, note)
+	return uc.entry(review, time.Now().UTC()), nil
+}
+
+func (uc *ManualReviewUseCase) AddAttachment(ctx context.Context, id string, attachment domain.ReviewAttachment) (ReviewQueueEntry, error) {
+	review, err := uc.reviews.FindByID(ctx, id)
+	if err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	if strings.TrimSpace(attachment.Author) == "" {
+		return ReviewQueueEntry{}, domain.ErrEmptyUnderwriter
+	}
+	attachment.ID = uuid.New()
+	attachment.ReviewID = review.ID
+	attachment.CreatedAt = time.Now().UTC()
+	if err := uc.reviews.AddAttachment(ctx, &attachment); err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	review.Attachments = append(review.Attachments, attachment)
+	return uc.entry(review, time.Now().UTC()), nil
+}
+
+func (uc *ManualReviewUseCase) change(ctx context.Context, id string, fn func(review *domain.Review, now time.Time) error) (ReviewQueueEntry, error) {
+	review, err := uc.reviews.FindByID(ctx, id)
+	if err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	version := review.Version
+	now := time.Now().UTC()
+	if err := fn(review, now); err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	if err := uc.reviews.Update(ctx, review, version); err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	return uc.entry(review, now), nil
+}
+
+// decide applies an underwriter decision. The review and the application are
+// saved in one transaction, the review first: its version check is what
+// stops two underwriters deciding at the same time.
+func (uc *ManualReviewUseCase) decide(ctx context.Context, id string, fn func(review *domain.Review, app *domain.CreditApplication, now time.Time) (bool, error)) (ReviewQueueEntry, error) {
+	review, err := uc.reviews.FindByID(ctx, id)
+	if err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	app, err := uc.repo.FindByID(ctx, review.ApplicationID.String())
+	if err != nil {
+		return ReviewQueueEntry{}, err
+	}
+
+	version := review.Version
+	now := time.Now().UTC()
+	final, err := fn(review, app, now)
+	if err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.reviews.Update(ctx, review, version); err != nil {
+			return err
+		}
+		if !final {
+			return nil
+		}
+		return uc.repo.Update(ctx, app)
+	})
+	if err != nil {
+		return ReviewQueueEntry{}, err
+	}
+	review.Application = app
+	if !final {
+		logger.FromContext(ctx).Info("Review approved, waiting for second approval",
+			zap.String("review_id", id),
+			zap.String("approver", review.FirstApprover.String),
+		)
+		return uc.entry(review, now), nil
+	}
+
+	logger.FromContext(ctx).Info("Review decided",
+		zap.String("review_id", id),
+		zap.String("app_id", app.ID.String()),
+		zap.String("decision", review.Decision.String),
+		zap.String("decided_by", review.DecidedBy.String),
+	)
+	return uc.entry(review, now), publishStatusChange(ctx, uc.producer, uc.notifier, app)
+}
+
+func (uc *ManualReviewUseCase) entry(review *domain.Review, now time.Time) ReviewQueueEntry {
+	inQueue := review.TimeInQueue(now)
+	entry := ReviewQueueEntry{
+		Review:      review,
+		TimeInQueue: inQueue,
+		SLABreached: inQueue > uc.sla,
+	}
+	if review.Application != nil {
+		entry.RequiresSecondApproval = uc.fourEyes.Requires(review.Application)
+	}
+	return entry
+}
+
+// enqueueReview opens a review for an application that antifraud sent to
+// MANUAL_REVIEW; the application is already saved.
+func enqueueReview(ctx context.Context, reviews domain.ReviewRepository, app *domain.CreditApplication) error {
+	if err := reviews.Create(ctx, domain.NewReview(app.ID, app.UpdatedAt)); err != nil {
+		logger.FromContext(ctx).Error("Failed to enqueue application for manual review",
+			zap.String("app_id", app.ID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"go.uber.org/zap"
+)
+
+const replayBatchSize = 500
+
+type ReplayStatusEventsUseCase struct {
+	repo     domain.CreditRepository
+	producer *messaging.KafkaProducer
+}
+
+func NewReplayStatusEventsUseCase(
+	repo domain.CreditRepository,
+	producer *messaging.KafkaProducer,
+) *ReplayStatusEventsUseCase {
+	return &ReplayStatusEventsUseCase{repo, producer}
+}
+
+// ExecuteByIDs re-publishes the current status event of every given application.
+func (uc *ReplayStatusEventsUseCase) ExecuteByIDs(ctx context.Context, ids []string) (int, error) {
+	published := 0
+	for _, id := range ids {
+		app, err := uc.repo.FindByID(ctx, id)
+		if err != nil {
+			return published, err
+		}
+		if err := uc.publish(ctx, app); err != nil {
+			return published, err
+		}
+		published++
+	}
+	return published, nil
+}
+
+// ExecuteByCreatedAt re-publishes status events of applications created in [from, to).
+func (uc *ReplayStatusEventsUseCase) ExecuteByCreatedAt(ctx context.Context, from time.Time, to time.Time) (int, error) {
+	published := 0
+	for offset := 0; ; offset += replayBatchSize {
+		apps, err := uc.repo.ListCreatedBetween(ctx, from, to, offset, replayBatchSize)
+		if err != nil {
+			return published, err
+		}
+		for _, app := range apps {
+			if err := ctx.Err(); err != nil {
+				return published, err
+			}
+			if err := uc.publish(ctx, app); err != nil {
+				return published, err
+			}
+			published++
+		}
+		if len(apps) < replayBatchSize {
+			return published, nil
+		}
+	}
+}
+
+func (uc *ReplayStatusEventsUseCase) publish(ctx context.Context, app *domain.CreditApplication) error {
+	if err := uc.producer.SendStatusEvent(ctx, createStatusEvent(ctx, app)); err != nil {
+		logger.FromContext(ctx).Error("Failed to replay status event",
+			zap.String("app_id", app.ID.String()),
+			zap.Error(err),
+		)
+		return err
+	}
+	logger.FromContext(ctx).Info("Status event replayed",
+		zap.String("app_id", app.ID.String()),
+		zap.String("status", string(app.Status)),
+	)
+	return nil
+}
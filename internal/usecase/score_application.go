@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/internal/messaging"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ScoreApplicationUseCase scores an application on its credit bureau report.
+// Below the scorecard cutoff it counter-offers terms that would pass, or
+// rejects the application when there are none.
+type ScoreApplicationUseCase struct {
+	repo      domain.CreditRepository
+	offers    domain.OfferRepository
+	reports   domain.BureauReportRepository
+	bureau    domain.CreditBureau
+	scorecard domain.Scorecard
+	// counterOffers is nil when declined applications get no counter-offers.
+	counterOffers *domain.CounterOfferGenerator
+	tx            domain.Transactor
+	producer      *messaging.KafkaProducer
+	notifier      domain.StatusNotifier
+}
+
+func NewScoreApplicationUseCase(
+	repo domain.CreditRepository,
+	offers domain.OfferRepository,
+	reports domain.BureauReportRepository,
+	bureau domain.CreditBureau,
+	scorecard domain.Scorecard,
+	counterOffers *domain.CounterOfferGenerator,
+	tx domain.Transactor,
+	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
+) *ScoreApplicationUseCase {
+	return &ScoreApplicationUseCase{repo, offers, reports, bureau, scorecard, counterOffers, tx, producer, notifier}
+}
+
+// Execute reports whether the application passed the scorecard. One that did
+// not is saved as COUNTER_OFFERED with its offers or as REJECTED, and its
+// status change published.
+func (uc *ScoreApplicationUseCase) Execute(ctx context.Context, appID uuid.UUID) (bool, error) {
+	app, err := uc.repo.FindByID(ctx, appID.String())
+	if err != nil {
+		return false, err
+	}
+
+	report, err := uc.report(ctx, app)
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	result := uc.scorecard.Score(app, report.Data, now)
+	logger.FromContext(ctx).Info("Application scored",
+		zap.String("app_id", app.ID.String()),
+		zap.String("bureau", report.Bureau),
+		zap.Int("score", result.Score),
+		zap.Bool("passed", result.Passed),
+		zap.Strings("reasons", result.Reasons),
+	)
+	var offers []*domain.CounterOffer
+	if !result.Passed {
+		if offers, err = uc.generateOffers(ctx, app, report.Data, now); err != nil {
+			return false, err
+		}
+	}
+	if err := app.ApplyScore(result, offers); err != nil {
+		return false, err
+	}
+	err = uc.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := uc.repo.Update(ctx, app); err != nil {
+			return err
+		}
+		return uc.offers.CreateAll(ctx, offers)
+	})
+	if err != nil {
+		return false, err
+	}
+	if !result.Passed {
+		if len(offers) > 0 {
+			logger.FromContext(ctx).Info("Application counter-offered after scoring",
+				zap.String("app_id", app.ID.String()),
+				zap.Int("offers", len(offers)),
+			)
+		}
+		return false, publishStatusChange(ctx, uc.producer, uc.notifier, app)
+	}
+	return true, nil
+}
+
+// generateOffers returns offers that pass the scorecard on the same report.
+// An application gets them once: after an accepted offer failed scoring too
+// it is rejected, since only one offer per application can be accepted.
+func (uc *ScoreApplicationUseCase) generateOffers(ctx context.Context, app *domain.CreditApplication, data domain.BureauData, now time.Time) ([]*domain.CounterOffer, error) {
+	if uc.counterOffers == nil {
+		return nil, nil
+	}
+	existing, err := uc.offers.ListByApplication(ctx, app.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, offer := range existing {
+		if offer.AcceptedAt.Valid {
+			return nil, nil
+		}
+	}
+	return uc.counterOffers.Generate(app, now.UTC(), func(candidate *domain.CreditApplication) bool {
+		return uc.scorecard.Score(candidate, data, now).Passed
+	}), nil
+}
+
+// report returns the report stored for the application, so a redelivered
+// scoring event does not query the bureau again.
+func (uc *ScoreApplicationUseCase) report(ctx context.Context, app *domain.CreditApplication) (*domain.BureauReport, error) {
+	report, err := uc.reports.FindByApplication(ctx, app.ID.String())
+	if err == nil {
+		return report, nil
+	}
+	if !errors.Is(err, domain.ErrBureauReportNotFound) {
+		return nil, err
+	}
+
+	data, err := uc.bureau.FetchReport(ctx, app.UserID)
+	if err != nil {
+		return nil, err
+	}
+	report = domain.NewBureauReport(app, uc.bureau.Name(), data, time.Now())
+	if err := uc.reports.Save(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -2,10 +2,28 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
 )
 
+// ApplicationChanges are the fields a client may edit. Status is not among
+// them: it changes only through UpdateStatusUseCase, CancelApplicationUseCase
+// and the review and offer flows, which check transitions and consents.
+type ApplicationChanges struct {
+	UserID             uuid.UUID
+	ToBankAccountID    uuid.UUID
+	DisbursementAmount decimal.Decimal
+	OriginationAmount  decimal.Decimal
+	Term               uint32
+	Interest           decimal.Decimal
+	ProductCode        string
+	ProductVersion     string
+	Currency           string
+}
+
 type UpdateApplicationUseCase struct {
 	repo domain.CreditRepository
 }
@@ -16,6 +34,29 @@ func NewUpdateApplicationUseCase(
 	return &UpdateApplicationUseCase{repo}
 }
 
-func (uc *UpdateApplicationUseCase) Execute(ctx context.Context, app *domain.CreditApplication) error {
-	return uc.repo.Update(ctx, app)
+// Execute applies changes to the stored application. The write is
+// conditional on the status and version read, so an edit cannot undo a
+// transition saved in between; it fails with ErrApplicationConflict instead.
+func (uc *UpdateApplicationUseCase) Execute(ctx context.Context, appID string, changes ApplicationChanges) (*domain.CreditApplication, error) {
+	app, err := uc.repo.FindByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	readStatus, readVersion := app.Status, app.Version
+
+	app.UserID = changes.UserID
+	app.ToBankAccountID = changes.ToBankAccountID
+	app.DisbursementAmount = changes.DisbursementAmount
+	app.OriginationAmount = changes.OriginationAmount
+	app.Term = changes.Term
+	app.Interest = changes.Interest
+	app.ProductCode = changes.ProductCode
+	app.ProductVersion = changes.ProductVersion
+	app.Currency = changes.Currency
+	app.UpdatedAt = time.Now().UTC()
+
+	if err := uc.repo.UpdateIfCurrent(ctx, app, readStatus, readVersion); err != nil {
+		return nil, err
+	}
+	return app, nil
 }
@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/shopspring/decimal"
+)
+
+func TestUpdateApplicationKeepsStatus(t *testing.T) {
+	app := staleApplication(domain.SCORING, time.Now())
+	repo := newMemoryRepo(app)
+	uc := NewUpdateApplicationUseCase(repo)
+
+	changes := ApplicationChanges{
+		UserID:             app.UserID,
+		ToBankAccountID:    app.ToBankAccountID,
+		DisbursementAmount: decimal.NewFromInt(50000),
+		OriginationAmount:  decimal.NewFromInt(50000),
+		Term:               24,
+		Interest:           app.Interest,
+		ProductCode:        app.ProductCode,
+		Currency:           app.Currency,
+	}
+	if _, err := uc.Execute(context.Background(), app.ID.String(), changes); err != nil {
+		t.Fatal(err)
+	}
+	stored := repo.stored(app.ID)
+	if stored.Status != domain.SCORING || stored.Version != app.Version {
+		t.Fatalf("status = %s, version = %d; an edit must not change them", stored.Status, stored.Version)
+	}
+	if !stored.DisbursementAmount.Equal(changes.DisbursementAmount) || stored.Term != 24 {
+		t.Fatalf("edit not saved: %+v", stored)
+	}
+
+	// Скоринг одобрил заявку, пока клиент её редактировал.
+	repo.afterRead = func() {
+		approved := repo.stored(app.ID)
+		if err := approved.ChangeStatus(domain.APPROVED); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Update(context.Background(), &approved); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := uc.Execute(context.Background(), app.ID.String(), changes); !errors.Is(err, domain.ErrApplicationConflict) {
+		t.Fatalf("Execute error = %v, want ErrApplicationConflict", err)
+	}
+	if stored := repo.stored(app.ID); stored.Status != domain.APPROVED {
+		t.Fatalf("status = %s, the approval must survive", stored.Status)
+	}
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/Andronzi/credit-origination/internal/domain"
@@ -12,15 +13,21 @@ import (
 )
 
 type UpdateStatusUseCase struct {
-	repo     domain.CreditRepository
-	producer *messaging.KafkaProducer
+	repo          domain.CreditRepository
+	consents      domain.ConsentRepository
+	consentPolicy *domain.ConsentPolicy
+	producer      *messaging.KafkaProducer
+	notifier      domain.StatusNotifier
 }
 
 func NewUpdateStatusUseCase(
 	repo domain.CreditRepository,
+	consents domain.ConsentRepository,
+	consentPolicy *domain.ConsentPolicy,
 	producer *messaging.KafkaProducer,
+	notifier domain.StatusNotifier,
 ) *UpdateStatusUseCase {
-	return &UpdateStatusUseCase{repo, producer}
+	return &UpdateStatusUseCase{repo, consents, consentPolicy, producer, notifier}
 }
 
 func MapDomainStatusToAvro(status domain.ApplicationStatus) string {
@@ -31,82 +38,124 @@ func MapDomainStatusToAvro(status domain.ApplicationStatus) string {
 	}
 }
 
-func CreatePaymentDate(status domain.ApplicationStatus) *int64 {
-	logger.Logger.Info("CreatePaymentDate", zap.String("status", string(status)))
+func CreatePaymentDate(ctx context.Context, status domain.ApplicationStatus) *int64 {
+	logger.FromContext(ctx).Info("CreatePaymentDate", zap.String("status", string(status)))
 	if status == domain.APPROVED {
 		now := time.Now()
 		startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
 		unixStartOfDay := startOfDay.Unix()
-		logger.Logger.Info("CreatePaymentDate", zap.String("tinme", string(unixStartOfDay)))
+		logger.FromContext(ctx).Info("CreatePaymentDate", zap.Int64("time", unixStartOfDay))
 		return &unixStartOfDay
 	} else {
 		return nil
 	}
 }
 
+// Execute moves the application to newStatus. A transition that lacks the
+// consents the policy requires for newStatus is blocked, not failed: the
+// status stays, the transition is kept in pending_status and ConsentUseCase
+// makes it when the last consent is recorded. The status flow is driven by
+// events and is not retried, so callers see success and go on; whoever needs
+// to tell the outcomes apart reads the application.
 func (uc *UpdateStatusUseCase) Execute(ctx context.Context, appID uuid.UUID, newStatus domain.ApplicationStatus) error {
-	logger.Logger.Info("UpdateStatusUseCase.Execute started",
+	logger.FromContext(ctx).Info("UpdateStatusUseCase.Execute started",
 		zap.String("app_id", appID.String()),
 		zap.String("new_status", string(newStatus)),
 	)
 
 	app, err := uc.repo.FindByID(ctx, appID.String())
 	if err != nil {
-		logger.Logger.Error("Failed to find application",
+		logger.FromContext(ctx).Error("Failed to find application",
 			zap.String("app_id", appID.String()),
 			zap.Error(err),
 		)
 		return err
 	}
-	logger.Logger.Info("Application found",
+	logger.FromContext(ctx).Info("Application found",
 		zap.String("app_id", app.ID.String()),
 		zap.String("current_status", string(app.Status)),
 	)
 
+	if missing := checkConsents(ctx, uc.consents, uc.consentPolicy, app, newStatus); missing != nil {
+		if !errors.Is(missing, domain.ErrConsentsMissing) {
+			return missing
+		}
+		app.AwaitConsents(newStatus)
+		if err := uc.repo.Update(ctx, app); err != nil {
+			return err
+		}
+		notifyStatusChange(ctx, uc.notifier, app)
+		logger.FromContext(ctx).Warn("Transition blocked until consents are recorded",
+			zap.String("app_id", app.ID.String()),
+			zap.String("new_status", string(newStatus)),
+			zap.String("missing", missing.Error()),
+		)
+		return nil
+	}
+
 	if err := app.ChangeStatus(newStatus); err != nil {
-		logger.Logger.Error("Failed to change application status",
+		logger.FromContext(ctx).Error("Failed to change application status",
 			zap.String("app_id", app.ID.String()),
 			zap.String("new_status", string(newStatus)),
 			zap.Error(err),
 		)
 		return err
 	}
-	logger.Logger.Info("Application status changed",
+	logger.FromContext(ctx).Info("Application status changed",
 		zap.String("app_id", app.ID.String()),
 		zap.String("new_status", string(newStatus)),
 	)
 
 	if err := uc.repo.Update(ctx, app); err != nil {
-		logger.Logger.Error("Failed to update application in repository",
+		logger.FromContext(ctx).Error("Failed to update application in repository",
 			zap.String("app_id", app.ID.String()),
 			zap.Error(err),
 		)
 		return err
 	}
-	logger.Logger.Info("Application updated in repository",
+	logger.FromContext(ctx).Info("Application updated in repository",
 		zap.String("app_id", app.ID.String()),
 	)
 
-	event := uc.createStatusEvent(app)
-	logger.Logger.Info("Status event created",
+	notifyStatusChange(ctx, uc.notifier, app)
+
+	event := createStatusEvent(ctx, app)
+	logger.FromContext(ctx).Info("Status event created",
 		zap.String("app_id", app.ID.String()),
 		zap.Any("event", event),
 	)
-	if err := uc.producer.SendStatusEvent(event); err != nil {
-		logger.Logger.Error("Failed to send status event",
+	if err := uc.producer.SendStatusEvent(ctx, event); err != nil {
+		logger.FromContext(ctx).Error("Failed to send status event",
 			zap.String("app_id", app.ID.String()),
 			zap.Error(err),
 		)
 		return err
 	}
-	logger.Logger.Info("Status event sent successfully",
+	logger.FromContext(ctx).Info("Status event sent successfully",
 		zap.String("app_id", app.ID.String()),
 	)
 
 	return nil
 }
 
-func (uc *UpdateStatusUseCase) createStatusEvent(app *domain.CreditApplication) messaging.ApplicationStatusEvent {
+// notifyStatusChange tells watchers about a committed status change. Watchers
+// catch up on a missed change by version, so a failure is only logged.
+func notifyStatusChange(ctx context.Context, notifier domain.StatusNotifier, app *domain.CreditApplication) {
+	if err := notifier.NotifyStatusChange(ctx, domain.StatusChange{
+		ApplicationID: app.ID,
+		UserID:        app.UserID,
+		Status:        app.Status,
+		Version:       app.Version,
+		ChangedAt:     app.UpdatedAt,
+	}); err != nil {
+		logger.FromContext(ctx).Error("Failed to notify status watchers",
+			zap.String("app_id", app.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
+
+func createStatusEvent(ctx context.Context, app *domain.CreditApplication) messaging.ApplicationStatusEvent {
 	event := messaging.ApplicationStatusEvent{
 		ApplicationID: app.ID.String(),
 		EventType:     MapDomainStatusToAvro(app.Status),
@@ -114,18 +163,25 @@ func (uc *UpdateStatusUseCase) createStatusEvent(app *domain.CreditApplication)
 		AgreementDetails: messaging.AgreementDetails{
 			ApplicationID:      app.ID.String(),
 			ClientID:           app.UserID.String(),
-			DisbursementAmount: app.DisbursementAmount.IntPart(),
-			OriginationAmount:  app.OriginationAmount.IntPart(),
+			DisbursementAmount: app.DisbursementAmount,
+			OriginationAmount:  app.OriginationAmount,
 			ToBankAccountID:    app.ToBankAccountID.String(),
 			Term:               int32(app.Term),
-			Interest:           app.Interest.IntPart(),
+			Interest:           app.Interest,
 			ProductCode:        app.ProductCode,
 			ProductVersion:     app.ProductVersion,
-			PaymentDate:        CreatePaymentDate(app.Status),
+			Currency:           app.Currency,
+			PaymentDate:        CreatePaymentDate(ctx, app.Status),
 		},
 	}
+	if app.Status == domain.CANCELLED && app.CancelReason.Valid {
+		event.CancelReason = &app.CancelReason.String
+	}
+	if app.Status == domain.REJECTED && app.RejectReason.Valid {
+		event.RejectReason = &app.RejectReason.String
+	}
 
-	logger.Logger.Info("createStatusEvent: event generated",
+	logger.FromContext(ctx).Info("createStatusEvent: event generated",
 		zap.String("app_id", app.ID.String()),
 		zap.Any("event", event),
 	)
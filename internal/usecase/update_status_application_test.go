@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/google/uuid"
+)
+
+func TestExecuteBlocksTransitionWithoutConsents(t *testing.T) {
+	app := &domain.CreditApplication{ID: uuid.New(), Status: domain.APPLICATION_AGREEMENT_CREATED, Version: 1}
+	repo := newMemoryRepo(app)
+	policy := domain.NewConsentPolicy(map[domain.ApplicationStatus][]domain.ConsentType{
+		domain.SCORING: {domain.ConsentCreditBureauCheck},
+	}, nil)
+	notifier := &recordingNotifier{}
+	uc := NewUpdateStatusUseCase(repo, &memoryConsents{}, policy, nil, notifier)
+
+	if err := uc.Execute(context.Background(), app.ID, domain.SCORING); err != nil {
+		t.Fatalf("a blocked transition must not fail the caller: %v", err)
+	}
+	stored := repo.stored(app.ID)
+	if stored.Status != domain.APPLICATION_AGREEMENT_CREATED {
+		t.Fatalf("status = %s, the transition must not be made", stored.Status)
+	}
+	if stored.PendingStatus.String != string(domain.SCORING) {
+		t.Fatalf("pending_status = %v, want the blocked transition kept", stored.PendingStatus)
+	}
+	if repo.updates != 1 {
+		t.Fatalf("updates = %d, want the pending status saved once", repo.updates)
+	}
+	// Наблюдатели пропускают известные версии, поэтому ожидание её поднимает.
+	if stored.Version != 2 {
+		t.Fatalf("version = %d, want 2 so watchers see pending_status", stored.Version)
+	}
+	if len(notifier.changes) != 1 || notifier.changes[0].Version != 2 {
+		t.Fatalf("notified %+v, want one change with version 2", notifier.changes)
+	}
+}
@@ -0,0 +1,98 @@
+package watch
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+)
+
+// Hub fans status changes out to the watch streams of this process.
+// A subscriber that does not drain its buffer is dropped: its channel is
+// closed and the client is expected to reconnect with the last seen version.
+type Hub struct {
+	mu         sync.Mutex
+	nextID     int
+	bufferSize int
+	subs       map[int]*subscription
+	closed     bool
+}
+
+type subscription struct {
+	match func(domain.StatusChange) bool
+	ch    chan domain.StatusChange
+}
+
+var _ domain.StatusNotifier = (*Hub)(nil)
+
+func NewHub(bufferSize int) *Hub {
+	return &Hub{
+		bufferSize: bufferSize,
+		subs:       make(map[int]*subscription),
+	}
+}
+
+// Subscribe registers a stream for changes accepted by match. The returned
+// cancel func must be called when the stream ends.
+func (h *Hub) Subscribe(match func(domain.StatusChange) bool) (<-chan domain.StatusChange, func()) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	id := h.nextID
+	h.nextID++
+	sub := &subscription{match: match, ch: make(chan domain.StatusChange, h.bufferSize)}
+	if h.closed {
+		close(sub.ch)
+		return sub.ch, func() {}
+	}
+	h.subs[id] = sub
+
+	return sub.ch, func() { h.unsubscribe(id) }
+}
+
+func (h *Hub) unsubscribe(id int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if sub, ok := h.subs[id]; ok {
+		delete(h.subs, id)
+		close(sub.ch)
+	}
+}
+
+// Close ends every stream so that a graceful stop of the gRPC server does not
+// wait for clients that would otherwise watch forever.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.closed = true
+	for id, sub := range h.subs {
+		delete(h.subs, id)
+		close(sub.ch)
+	}
+}
+
+func (h *Hub) Broadcast(change domain.StatusChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for id, sub := range h.subs {
+		if !sub.match(change) {
+			continue
+		}
+		select {
+		case sub.ch <- change:
+		default:
+			delete(h.subs, id)
+			close(sub.ch)
+		}
+	}
+}
+
+// NotifyStatusChange delivers the change to local watchers only; used when
+// there is no Redis to reach other replicas.
+func (h *Hub) NotifyStatusChange(_ context.Context, change domain.StatusChange) error {
+	h.Broadcast(change)
+	return nil
+}
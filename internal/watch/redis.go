@@ -0,0 +1,74 @@
+package watch
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Andronzi/credit-origination/internal/domain"
+	"github.com/Andronzi/credit-origination/pkg/logger"
+	"github.com/go-redis/redis/v8"
+	"go.uber.org/zap"
+)
+
+// publishTimeout bounds how long a status update waits for Redis.
+const publishTimeout = 2 * time.Second
+
+// RedisNotifier publishes status changes to a Redis channel shared by all
+// replicas. Changes made by this process reach the local hub through the
+// same channel, so every watcher sees one ordered feed.
+type RedisNotifier struct {
+	client  *redis.Client
+	channel string
+	hub     *Hub
+}
+
+var _ domain.StatusNotifier = (*RedisNotifier)(nil)
+
+func NewRedisNotifier(client *redis.Client, channel string, hub *Hub) *RedisNotifier {
+	return &RedisNotifier{client: client, channel: channel, hub: hub}
+}
+
+func (n *RedisNotifier) NotifyStatusChange(ctx context.Context, change domain.StatusChange) error {
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, publishTimeout)
+	defer cancel()
+	return n.client.Publish(ctx, n.channel, data).Err()
+}
+
+// Run feeds the hub from the Redis channel until ctx is cancelled.
+// go-redis re-subscribes on its own after a dropped connection.
+func (n *RedisNotifier) Run(ctx context.Context) error {
+	pubsub := n.client.Subscribe(ctx, n.channel)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return err
+	}
+	logger.FromContext(ctx).Info("Subscribed to status changes", zap.String("channel", n.channel))
+
+	messages := pubsub.ChannelWithSubscriptions(ctx, 100)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			payload, ok := msg.(*redis.Message)
+			if !ok {
+				continue
+			}
+			var change domain.StatusChange
+			if err := json.Unmarshal([]byte(payload.Payload), &change); err != nil {
+				logger.FromContext(ctx).Error("Failed to decode status change", zap.Error(err))
+				continue
+			}
+			n.hub.Broadcast(change)
+		}
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
 )
 
 func readSecret(filePath string) (string, error) {
@@ -16,7 +17,9 @@ func readSecret(filePath string) (string, error) {
 	return string(data), err
 }
 
-func ConnectPostgres() (*gorm.DB, error) {
+// ConnectPostgres connects with log as the gorm logger, gorm's default when
+// nil.
+func ConnectPostgres(log gormlogger.Interface) (*gorm.DB, error) {
 	userPath := os.Getenv("DB_USER_FILE")
 	passwordPath := os.Getenv("DB_PASSWORD_FILE")
 	dbNamePath := os.Getenv("DB_NAME_FILE")
@@ -45,12 +48,13 @@ func ConnectPostgres() (*gorm.DB, error) {
 		dbname,
 	)
 
-	fmt.Println("Connecting with DSN:", dsn)
-
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: log})
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect database: %w", err)
 	}
+	if err := db.Use(TracingPlugin{}); err != nil {
+		return nil, fmt.Errorf("failed to enable query tracing: %w", err)
+	}
 
 	// sqlDB, err := db.DB()
 	// if err != nil {
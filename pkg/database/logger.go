@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/utils"
+)
+
+// ParseLogLevel parses silent, error, warn or info.
+func ParseLogLevel(level string) (gormlogger.LogLevel, error) {
+	switch strings.ToLower(level) {
+	case "silent":
+		return gormlogger.Silent, nil
+	case "error":
+		return gormlogger.Error, nil
+	case "warn":
+		return gormlogger.Warn, nil
+	case "info":
+		return gormlogger.Info, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", level)
+	}
+}
+
+// GormLogger writes gorm logs to zap. Failed queries are logged at Error,
+// queries slower than the threshold at Warn and, at the info level, every
+// other query at Debug.
+type GormLogger struct {
+	log           *zap.Logger
+	level         gormlogger.LogLevel
+	slowThreshold time.Duration
+}
+
+var _ gormlogger.Interface = (*GormLogger)(nil)
+
+func NewGormLogger(log *zap.Logger, level gormlogger.LogLevel, slowThreshold time.Duration) *GormLogger {
+	return &GormLogger{log: log.Named("gorm").WithOptions(zap.WithCaller(false)), level: level, slowThreshold: slowThreshold}
+}
+
+func (l *GormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	copied := *l
+	copied.level = level
+	return &copied
+}
+
+func (l *GormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log.Info(fmt.Sprintf(msg, args...), traceFields(ctx)...)
+	}
+}
+
+func (l *GormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log.Warn(fmt.Sprintf(msg, args...), traceFields(ctx)...)
+	}
+}
+
+func (l *GormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log.Error(fmt.Sprintf(msg, args...), traceFields(ctx)...)
+	}
+}
+
+func (l *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	switch {
+	case err != nil && !errors.Is(err, gorm.ErrRecordNotFound) && l.level >= gormlogger.Error:
+		sql, rows := fc()
+		l.log.Error("Query failed", append(queryFields(ctx, sql, rows, elapsed), zap.Error(err))...)
+	case l.slowThreshold > 0 && elapsed > l.slowThreshold && l.level >= gormlogger.Warn:
+		sql, rows := fc()
+		l.log.Warn("Slow query", append(queryFields(ctx, sql, rows, elapsed), zap.Duration("threshold", l.slowThreshold))...)
+	case l.level >= gormlogger.Info:
+		sql, rows := fc()
+		l.log.Debug("Query", queryFields(ctx, sql, rows, elapsed)...)
+	}
+}
+
+func queryFields(ctx context.Context, sql string, rows int64, elapsed time.Duration) []zap.Field {
+	return append(traceFields(ctx),
+		zap.String("sql", sql),
+		zap.Int64("rows", rows),
+		zap.Duration("elapsed", elapsed),
+		zap.String("source", utils.FileWithLineNum()),
+	)
+}
+
+func traceFields(ctx context.Context) []zap.Field {
+	spanCtx := trace.SpanContextFromContext(ctx)
+	if !spanCtx.IsValid() {
+		return nil
+	}
+	return []zap.Field{
+		zap.String("trace_id", spanCtx.TraceID().String()),
+		zap.String("span_id", spanCtx.SpanID().String()),
+	}
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+func TestGormLoggerTrace(t *testing.T) {
+	const slow = 100 * time.Millisecond
+	tests := []struct {
+		name      string
+		level     gormlogger.LogLevel
+		elapsed   time.Duration
+		err       error
+		wantLevel zapcore.Level
+		wantMsg   string
+	}{
+		{"failed query", gormlogger.Error, 0, errors.New("syntax error"), zap.ErrorLevel, "Query failed"},
+		{"not found is not a failure", gormlogger.Warn, 0, gorm.ErrRecordNotFound, 0, ""},
+		{"slow query", gormlogger.Warn, 2 * slow, nil, zap.WarnLevel, "Slow query"},
+		{"slow query below warn", gormlogger.Error, 2 * slow, nil, 0, ""},
+		{"fast query at info", gormlogger.Info, 0, nil, zap.DebugLevel, "Query"},
+		{"fast query at warn", gormlogger.Warn, 0, nil, 0, ""},
+		{"silent", gormlogger.Silent, 0, errors.New("syntax error"), 0, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zap.DebugLevel)
+			log := NewGormLogger(zap.New(core), tt.level, slow)
+
+			log.Trace(context.Background(), time.Now().Add(-tt.elapsed), func() (string, int64) {
+				return "SELECT 1", 1
+			}, tt.err)
+
+			entries := logs.All()
+			if tt.wantMsg == "" {
+				if len(entries) != 0 {
+					t.Fatalf("logged %q, want nothing", entries[0].Message)
+				}
+				return
+			}
+			if len(entries) != 1 || entries[0].Message != tt.wantMsg || entries[0].Level != tt.wantLevel {
+				t.Fatalf("logged %v, want one %s %q", entries, tt.wantLevel, tt.wantMsg)
+			}
+			if got := entries[0].ContextMap()["sql"]; got != "SELECT 1" {
+				t.Fatalf("sql = %v, want SELECT 1", got)
+			}
+		})
+	}
+}
+
+func TestGormLoggerAddsTraceIDs(t *testing.T) {
+	useTestTracer(t)
+	core, logs := observer.New(zap.DebugLevel)
+	log := NewGormLogger(zap.New(core), gormlogger.Info, 0)
+
+	ctx, span := otel.Tracer("test").Start(context.Background(), "rpc")
+	defer span.End()
+	log.Trace(ctx, time.Now(), func() (string, int64) { return "SELECT 1", 1 }, nil)
+
+	fields := logs.All()[0].ContextMap()
+	if fields["trace_id"] != span.SpanContext().TraceID().String() || fields["span_id"] != span.SpanContext().SpanID().String() {
+		t.Fatalf("fields = %v, want the ids of the request span", fields)
+	}
+}
@@ -0,0 +1,145 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Andronzi/credit-origination/db"
+	"github.com/pressly/goose/v3"
+	"gorm.io/gorm"
+)
+
+const migrationsDir = "migrations"
+
+var ErrSchemaOutdated = errors.New("database schema is behind the embedded migrations")
+
+func init() {
+	goose.SetBaseFS(db.Migrations)
+	if err := goose.SetDialect("postgres"); err != nil {
+		panic(err)
+	}
+}
+
+// Migrate runs a goose command (up, down, status, redo, version) against the
+// migrations embedded in the binary.
+func Migrate(ctx context.Context, sqlDB *sql.DB, command string, args ...string) error {
+	switch command {
+	case "up", "down", "status", "redo", "version":
+	default:
+		return fmt.Errorf("unknown migrate command %q", command)
+	}
+	return goose.RunContext(ctx, command, sqlDB, migrationsDir, args...)
+}
+
+func LatestMigrationVersion() (int64, error) {
+	migrations, err := goose.CollectMigrations(migrationsDir, 0, goose.MaxVersion)
+	if err != nil {
+		return 0, err
+	}
+	last, err := migrations.Last()
+	if err != nil {
+		return 0, err
+	}
+	return last.Version, nil
+}
+
+// EnsureSchemaUpToDate fails when the database has not been migrated to the
+// latest migration shipped with this binary.
+func EnsureSchemaUpToDate(ctx context.Context, sqlDB *sql.DB) error {
+	latest, err := LatestMigrationVersion()
+	if err != nil {
+		return fmt.Errorf("failed to read embedded migrations: %w", err)
+	}
+
+	current, err := goose.GetDBVersionContext(ctx, sqlDB)
+	if err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if current < latest {
+		return fmt.Errorf("%w: current %d, expected %d", ErrSchemaOutdated, current, latest)
+	}
+	return nil
+}
+
+// CheckSchemaDrift compares gorm models with the columns of their tables and
+// returns one line per mismatch: a field without a column, a column without a
+// field, or an explicit gorm `type:` that differs from the column type.
+func CheckSchemaDrift(gdb *gorm.DB, models ...interface{}) ([]string, error) {
+	var drift []string
+
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: gdb}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("failed to parse model %T: %w", model, err)
+		}
+		table := stmt.Schema.Table
+
+		columnTypes, err := gdb.Migrator().ColumnTypes(model)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+		}
+		columns := make(map[string]gorm.ColumnType, len(columnTypes))
+		for _, ct := range columnTypes {
+			columns[ct.Name()] = ct
+		}
+
+		fields := make(map[string]bool)
+		for _, field := range stmt.Schema.Fields {
+			if field.DBName == "" {
+				continue
+			}
+			fields[field.DBName] = true
+
+			ct, ok := columns[field.DBName]
+			if !ok {
+				drift = append(drift, fmt.Sprintf("%s.%s: field %s has no column", table, field.DBName, field.Name))
+				continue
+			}
+
+			declared, ok := field.TagSettings["TYPE"]
+			if !ok {
+				continue
+			}
+			if want, got := normalizeColumnType(declared), normalizeColumnType(ct.DatabaseTypeName()); want != got {
+				drift = append(drift, fmt.Sprintf("%s.%s: model type %q, column type %q", table, field.DBName, declared, ct.DatabaseTypeName()))
+			}
+		}
+
+		for name := range columns {
+			if !fields[name] {
+				drift = append(drift, fmt.Sprintf("%s.%s: column has no model field", table, name))
+			}
+		}
+	}
+
+	return drift, nil
+}
+
+var columnTypeAliases = map[string]string{
+	"decimal":           "numeric",
+	"character varying": "varchar",
+	"int":               "int4",
+	"integer":           "int4",
+	"bigint":            "int8",
+	"smallint":          "int2",
+	"float":             "float8",
+	"double precision":  "float8",
+	"boolean":           "bool",
+	"timestamp":         "timestamp",
+	"timestamptz":       "timestamptz",
+}
+
+func normalizeColumnType(t string) string {
+	t = strings.ToLower(strings.TrimSpace(t))
+	if i := strings.Index(t, "("); i >= 0 {
+		t = strings.TrimSpace(t[:i])
+	}
+	if alias, ok := columnTypeAliases[t]; ok {
+		return alias
+	}
+	return t
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RedisTracingHook creates a client span per Redis command and per pipeline.
+// Only command names are recorded: values may hold cached personal data.
+type RedisTracingHook struct {
+	tracer trace.Tracer
+	addr   string
+}
+
+var _ redis.Hook = (*RedisTracingHook)(nil)
+
+func NewRedisTracingHook(addr string) *RedisTracingHook {
+	return &RedisTracingHook{tracer: otel.Tracer("credit-origination-service/redis"), addr: addr}
+}
+
+func (h *RedisTracingHook) BeforeProcess(ctx context.Context, cmd redis.Cmder) (context.Context, error) {
+	ctx, _ = h.tracer.Start(ctx, "redis."+cmd.FullName(),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperation(cmd.FullName()),
+			attribute.String("server.address", h.addr),
+		),
+	)
+	return ctx, nil
+}
+
+func (h *RedisTracingHook) AfterProcess(ctx context.Context, cmd redis.Cmder) error {
+	endRedisSpan(ctx, cmd.Err())
+	return nil
+}
+
+func (h *RedisTracingHook) BeforeProcessPipeline(ctx context.Context, cmds []redis.Cmder) (context.Context, error) {
+	names := make([]string, 0, len(cmds))
+	for _, cmd := range cmds {
+		names = append(names, cmd.FullName())
+	}
+	ctx, _ = h.tracer.Start(ctx, "redis.pipeline",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperation(strings.Join(names, " ")),
+			attribute.Int("db.redis.num_cmd", len(cmds)),
+			attribute.String("server.address", h.addr),
+		),
+	)
+	return ctx, nil
+}
+
+func (h *RedisTracingHook) AfterProcessPipeline(ctx context.Context, cmds []redis.Cmder) error {
+	var err error
+	for _, cmd := range cmds {
+		if cmdErr := cmd.Err(); cmdErr != nil && !errors.Is(cmdErr, redis.Nil) {
+			err = cmdErr
+			break
+		}
+	}
+	endRedisSpan(ctx, err)
+	return nil
+}
+
+func endRedisSpan(ctx context.Context, err error) {
+	span := trace.SpanFromContext(ctx)
+	// redis.Nil — отсутствие ключа, а не ошибка.
+	if err != nil && !errors.Is(err, redis.Nil) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
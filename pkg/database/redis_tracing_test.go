@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+)
+
+func TestRedisTracingHook(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(ctx context.Context, rdb *redis.Client)
+		span      string
+		operation string
+		status    codes.Code
+	}{
+		{"command", func(ctx context.Context, rdb *redis.Client) {
+			rdb.Set(ctx, "app:1", "cached", 0)
+		}, "redis.set", "set", codes.Unset},
+		{"missing key is not an error", func(ctx context.Context, rdb *redis.Client) {
+			rdb.Get(ctx, "app:missing")
+		}, "redis.get", "get", codes.Unset},
+		{"failed command", func(ctx context.Context, rdb *redis.Client) {
+			rdb.Set(ctx, "app:1", "cached", 0)
+			rdb.Incr(ctx, "app:1")
+		}, "redis.incr", "incr", codes.Error},
+		{"pipeline", func(ctx context.Context, rdb *redis.Client) {
+			rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+				pipe.Get(ctx, "app:missing")
+				pipe.Set(ctx, "app:1", "cached", 0)
+				return nil
+			})
+		}, "redis.pipeline", "get set", codes.Unset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := useTestTracer(t)
+			server := miniredis.RunT(t)
+			rdb := redis.NewClient(&redis.Options{Addr: server.Addr()})
+			t.Cleanup(func() { rdb.Close() })
+			rdb.AddHook(NewRedisTracingHook(server.Addr()))
+
+			ctx, parent := otel.Tracer("test").Start(context.Background(), "rpc")
+			tt.run(ctx, rdb)
+			parent.End()
+
+			for _, span := range recorder.Ended() {
+				if span.Name() != tt.span {
+					continue
+				}
+				if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+					t.Fatalf("span %q is not a child of the request span", span.Name())
+				}
+				if got := spanAttribute(span, "db.operation"); got != tt.operation {
+					t.Fatalf("db.operation = %q, want %q", got, tt.operation)
+				}
+				if got := spanAttribute(span, "server.address"); got != server.Addr() {
+					t.Fatalf("server.address = %q, want %q", got, server.Addr())
+				}
+				if got := span.Status().Code; got != tt.status {
+					t.Fatalf("status = %s, want %s", got, tt.status)
+				}
+				return
+			}
+			t.Fatalf("no ended span %q", tt.span)
+		})
+	}
+}
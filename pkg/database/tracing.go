@@ -0,0 +1,88 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+const parentContextKey = "otel:parent_context"
+
+// TracingPlugin creates a client span for every gorm operation, a child of
+// the span in the statement context, so queries run by RPCs and Kafka
+// handlers join their traces. Repositories pass the context with
+// db.WithContext.
+type TracingPlugin struct{}
+
+var _ gorm.Plugin = TracingPlugin{}
+
+func (TracingPlugin) Name() string { return "otel-tracing" }
+
+func (TracingPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("otel:before_create", startSpan("create")),
+		cb.Create().After("gorm:create").Register("otel:after_create", endSpan),
+		cb.Query().Before("gorm:query").Register("otel:before_query", startSpan("query")),
+		cb.Query().After("gorm:query").Register("otel:after_query", endSpan),
+		cb.Update().Before("gorm:update").Register("otel:before_update", startSpan("update")),
+		cb.Update().After("gorm:update").Register("otel:after_update", endSpan),
+		cb.Delete().Before("gorm:delete").Register("otel:before_delete", startSpan("delete")),
+		cb.Delete().After("gorm:delete").Register("otel:after_delete", endSpan),
+		cb.Row().Before("gorm:row").Register("otel:before_row", startSpan("row")),
+		cb.Row().After("gorm:row").Register("otel:after_row", endSpan),
+		cb.Raw().Before("gorm:raw").Register("otel:before_raw", startSpan("raw")),
+		cb.Raw().After("gorm:raw").Register("otel:after_raw", endSpan),
+	)
+}
+
+func startSpan(operation string) func(*gorm.DB) {
+	tracer := otel.Tracer("credit-origination-service/gorm")
+	return func(db *gorm.DB) {
+		parent := db.Statement.Context
+		if parent == nil {
+			parent = context.Background()
+		}
+		ctx, _ := tracer.Start(parent, "gorm."+operation,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				semconv.DBSystemPostgreSQL,
+				semconv.DBOperation(operation),
+			),
+		)
+		db.InstanceSet(parentContextKey, parent)
+		db.Statement.Context = ctx
+	}
+}
+
+func endSpan(db *gorm.DB) {
+	span := trace.SpanFromContext(db.Statement.Context)
+	if parent, ok := db.InstanceGet(parentContextKey); ok {
+		// Сессия gorm может переиспользовать Statement; спан не должен стать
+		// родителем следующих запросов.
+		db.Statement.Context = parent.(context.Context)
+	}
+	if !span.IsRecording() {
+		span.End()
+		return
+	}
+
+	span.SetAttributes(
+		semconv.DBStatement(db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	if db.Statement.Table != "" {
+		span.SetAttributes(semconv.DBSQLTable(db.Statement.Table))
+	}
+	if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+		span.RecordError(db.Error)
+		span.SetStatus(codes.Error, db.Error.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+type tracedRow struct {
+	ID   string
+	Name string
+}
+
+func useTestTracer(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+	return recorder
+}
+
+// openDryRunDB builds statements without a database: sql.Open does not
+// connect and DryRun skips execution, but every gorm callback still runs.
+func openDryRunDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	conn, err := sql.Open("pgx", "host=127.0.0.1 port=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: conn}), &gorm.Config{
+		DryRun:               true,
+		DisableAutomaticPing: true,
+		// Иначе create, update и delete открывают транзакцию на соединении.
+		SkipDefaultTransaction: true,
+		Logger:                 gormlogger.Discard,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Use(TracingPlugin{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func spanAttribute(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, attr := range span.Attributes() {
+		if attr.Key == key {
+			return attr.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestTracingPluginCreatesChildSpans(t *testing.T) {
+	tests := []struct {
+		name      string
+		run       func(db *gorm.DB) *gorm.DB
+		span      string
+		statement string
+	}{
+		{"create", func(db *gorm.DB) *gorm.DB { return db.Create(&tracedRow{ID: "1", Name: "a"}) }, "gorm.create", `INSERT INTO "traced_rows"`},
+		{"query", func(db *gorm.DB) *gorm.DB { return db.Where("id = ?", "1").Find(&[]tracedRow{}) }, "gorm.query", `SELECT * FROM "traced_rows"`},
+		{"update", func(db *gorm.DB) *gorm.DB {
+			return db.Model(&tracedRow{}).Where("id = ?", "1").Update("name", "b")
+		}, "gorm.update", `UPDATE "traced_rows"`},
+		{"delete", func(db *gorm.DB) *gorm.DB { return db.Where("id = ?", "1").Delete(&tracedRow{}) }, "gorm.delete", `DELETE FROM "traced_rows"`},
+		{"raw", func(db *gorm.DB) *gorm.DB { return db.Exec("TRUNCATE traced_rows") }, "gorm.raw", "TRUNCATE traced_rows"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := useTestTracer(t)
+			db := openDryRunDB(t)
+
+			ctx, parent := otel.Tracer("test").Start(context.Background(), "rpc")
+			// Два запроса подряд в одной сессии: второй не должен стать дочерним для первого.
+			session := db.WithContext(ctx)
+			for i := 0; i < 2; i++ {
+				if err := tt.run(session).Error; err != nil {
+					t.Fatal(err)
+				}
+			}
+			parent.End()
+
+			var spans []sdktrace.ReadOnlySpan
+			for _, span := range recorder.Ended() {
+				if span.Name() == tt.span {
+					spans = append(spans, span)
+				}
+			}
+			if len(spans) != 2 {
+				t.Fatalf("got %d %q spans, want 2", len(spans), tt.span)
+			}
+			for _, span := range spans {
+				if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+					t.Fatalf("span %q is not a child of the request span", span.Name())
+				}
+				if span.SpanKind() != trace.SpanKindClient {
+					t.Fatalf("span kind = %s, want client", span.SpanKind())
+				}
+				if got := spanAttribute(span, "db.statement"); !strings.HasPrefix(got, tt.statement) {
+					t.Fatalf("db.statement = %q, want prefix %q", got, tt.statement)
+				}
+			}
+		})
+	}
+}
+
+func TestTracingPluginRecordsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want codes.Code
+	}{
+		{"success", nil, codes.Unset},
+		{"record not found is not a failure", gorm.ErrRecordNotFound, codes.Unset},
+		{"query error", errors.New("connection reset"), codes.Error},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := useTestTracer(t)
+			db := openDryRunDB(t)
+			// Ошибка выставляется между выполнением запроса и закрытием спана.
+			err := db.Callback().Query().After("gorm:query").Before("otel:after_query").Register("test:fail", func(db *gorm.DB) {
+				if tt.err != nil {
+					db.AddError(tt.err)
+				}
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			db.Find(&[]tracedRow{})
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("ended spans = %d, want 1", len(spans))
+			}
+			if got := spans[0].Status().Code; got != tt.want {
+				t.Fatalf("status = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
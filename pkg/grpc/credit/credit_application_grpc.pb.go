@@ -20,11 +20,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ApplicationService_Get_FullMethodName    = "/credit.v1.ApplicationService/Get"
-	ApplicationService_Create_FullMethodName = "/credit.v1.ApplicationService/Create"
-	ApplicationService_Update_FullMethodName = "/credit.v1.ApplicationService/Update"
-	ApplicationService_Delete_FullMethodName = "/credit.v1.ApplicationService/Delete"
-	ApplicationService_List_FullMethodName   = "/credit.v1.ApplicationService/List"
+	ApplicationService_Get_FullMethodName                     = "/credit.v1.ApplicationService/Get"
+	ApplicationService_Create_FullMethodName                  = "/credit.v1.ApplicationService/Create"
+	ApplicationService_Update_FullMethodName                  = "/credit.v1.ApplicationService/Update"
+	ApplicationService_Delete_FullMethodName                  = "/credit.v1.ApplicationService/Delete"
+	ApplicationService_Cancel_FullMethodName                  = "/credit.v1.ApplicationService/Cancel"
+	ApplicationService_ListOffers_FullMethodName              = "/credit.v1.ApplicationService/ListOffers"
+	ApplicationService_AcceptOffer_FullMethodName             = "/credit.v1.ApplicationService/AcceptOffer"
+	ApplicationService_RecordConsent_FullMethodName           = "/credit.v1.ApplicationService/RecordConsent"
+	ApplicationService_ListConsents_FullMethodName            = "/credit.v1.ApplicationService/ListConsents"
+	ApplicationService_List_FullMethodName                    = "/credit.v1.ApplicationService/List"
+	ApplicationService_WatchApplication_FullMethodName        = "/credit.v1.ApplicationService/WatchApplication"
+	ApplicationService_WatchUserApplications_FullMethodName   = "/credit.v1.ApplicationService/WatchUserApplications"
+	ApplicationService_BatchGetApplications_FullMethodName    = "/credit.v1.ApplicationService/BatchGetApplications"
+	ApplicationService_BatchCreateApplications_FullMethodName = "/credit.v1.ApplicationService/BatchCreateApplications"
+	ApplicationService_BulkTransition_FullMethodName          = "/credit.v1.ApplicationService/BulkTransition"
+	ApplicationService_ExportApplications_FullMethodName      = "/credit.v1.ApplicationService/ExportApplications"
 )
 
 // ApplicationServiceClient is the client API for ApplicationService service.
@@ -35,7 +46,25 @@ type ApplicationServiceClient interface {
 	Create(ctx context.Context, in *CreateApplicationRequest, opts ...grpc.CallOption) (*ApplicationResponse, error)
 	Update(ctx context.Context, in *UpdateApplicationRequest, opts ...grpc.CallOption) (*ApplicationResponse, error)
 	Delete(ctx context.Context, in *DeleteApplicationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Отзыв заявки её клиентом (субъект bearer-токена); доступен из любого статуса до APPROVED/REJECTED.
+	Cancel(ctx context.Context, in *CancelApplicationRequest, opts ...grpc.CallOption) (*ApplicationResponse, error)
+	ListOffers(ctx context.Context, in *ListOffersRequest, opts ...grpc.CallOption) (*ListOffersResponse, error)
+	// Подставляет условия предложения в заявку и переводит её в APPLICATION_AGREEMENT_CREATED.
+	AcceptOffer(ctx context.Context, in *AcceptOfferRequest, opts ...grpc.CallOption) (*ApplicationResponse, error)
+	// IP-адрес и User-Agent берутся из метаданных запроса.
+	RecordConsent(ctx context.Context, in *RecordConsentRequest, opts ...grpc.CallOption) (*Consent, error)
+	ListConsents(ctx context.Context, in *ListConsentsRequest, opts ...grpc.CallOption) (*ListConsentsResponse, error)
 	List(ctx context.Context, in *ListApplicationRequest, opts ...grpc.CallOption) (*ListApplicationResponse, error)
+	// Отправляет текущее состояние заявки, затем каждое изменение статуса.
+	// Через REST-шлюз приходит как поток JSON-объектов, по одному на строку.
+	WatchApplication(ctx context.Context, in *WatchApplicationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ApplicationUpdate], error)
+	WatchUserApplications(ctx context.Context, in *WatchUserApplicationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ApplicationUpdate], error)
+	BatchGetApplications(ctx context.Context, in *BatchGetApplicationsRequest, opts ...grpc.CallOption) (*BatchGetApplicationsResponse, error)
+	// Каждый элемент создаётся независимо, ошибки возвращаются по элементам.
+	BatchCreateApplications(ctx context.Context, in *BatchCreateApplicationsRequest, opts ...grpc.CallOption) (*BatchCreateApplicationsResponse, error)
+	BulkTransition(ctx context.Context, in *BulkTransitionRequest, opts ...grpc.CallOption) (*BulkTransitionResponse, error)
+	// Поток байтов файла выгрузки; склеенные data всех сообщений образуют файл.
+	ExportApplications(ctx context.Context, in *ExportApplicationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error)
 }
 
 type applicationServiceClient struct {
@@ -86,6 +115,56 @@ func (c *applicationServiceClient) Delete(ctx context.Context, in *DeleteApplica
 	return out, nil
 }
 
+func (c *applicationServiceClient) Cancel(ctx context.Context, in *CancelApplicationRequest, opts ...grpc.CallOption) (*ApplicationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplicationResponse)
+	err := c.cc.Invoke(ctx, ApplicationService_Cancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) ListOffers(ctx context.Context, in *ListOffersRequest, opts ...grpc.CallOption) (*ListOffersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListOffersResponse)
+	err := c.cc.Invoke(ctx, ApplicationService_ListOffers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) AcceptOffer(ctx context.Context, in *AcceptOfferRequest, opts ...grpc.CallOption) (*ApplicationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplicationResponse)
+	err := c.cc.Invoke(ctx, ApplicationService_AcceptOffer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) RecordConsent(ctx context.Context, in *RecordConsentRequest, opts ...grpc.CallOption) (*Consent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Consent)
+	err := c.cc.Invoke(ctx, ApplicationService_RecordConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) ListConsents(ctx context.Context, in *ListConsentsRequest, opts ...grpc.CallOption) (*ListConsentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListConsentsResponse)
+	err := c.cc.Invoke(ctx, ApplicationService_ListConsents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *applicationServiceClient) List(ctx context.Context, in *ListApplicationRequest, opts ...grpc.CallOption) (*ListApplicationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListApplicationResponse)
@@ -96,6 +175,93 @@ func (c *applicationServiceClient) List(ctx context.Context, in *ListApplication
 	return out, nil
 }
 
+func (c *applicationServiceClient) WatchApplication(ctx context.Context, in *WatchApplicationRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ApplicationUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ApplicationService_ServiceDesc.Streams[0], ApplicationService_WatchApplication_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchApplicationRequest, ApplicationUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApplicationService_WatchApplicationClient = grpc.ServerStreamingClient[ApplicationUpdate]
+
+func (c *applicationServiceClient) WatchUserApplications(ctx context.Context, in *WatchUserApplicationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ApplicationUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ApplicationService_ServiceDesc.Streams[1], ApplicationService_WatchUserApplications_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchUserApplicationsRequest, ApplicationUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApplicationService_WatchUserApplicationsClient = grpc.ServerStreamingClient[ApplicationUpdate]
+
+func (c *applicationServiceClient) BatchGetApplications(ctx context.Context, in *BatchGetApplicationsRequest, opts ...grpc.CallOption) (*BatchGetApplicationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchGetApplicationsResponse)
+	err := c.cc.Invoke(ctx, ApplicationService_BatchGetApplications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) BatchCreateApplications(ctx context.Context, in *BatchCreateApplicationsRequest, opts ...grpc.CallOption) (*BatchCreateApplicationsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchCreateApplicationsResponse)
+	err := c.cc.Invoke(ctx, ApplicationService_BatchCreateApplications_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) BulkTransition(ctx context.Context, in *BulkTransitionRequest, opts ...grpc.CallOption) (*BulkTransitionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkTransitionResponse)
+	err := c.cc.Invoke(ctx, ApplicationService_BulkTransition_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *applicationServiceClient) ExportApplications(ctx context.Context, in *ExportApplicationsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ApplicationService_ServiceDesc.Streams[2], ApplicationService_ExportApplications_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportApplicationsRequest, ExportChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApplicationService_ExportApplicationsClient = grpc.ServerStreamingClient[ExportChunk]
+
 // ApplicationServiceServer is the server API for ApplicationService service.
 // All implementations must embed UnimplementedApplicationServiceServer
 // for forward compatibility.
@@ -104,7 +270,25 @@ type ApplicationServiceServer interface {
 	Create(context.Context, *CreateApplicationRequest) (*ApplicationResponse, error)
 	Update(context.Context, *UpdateApplicationRequest) (*ApplicationResponse, error)
 	Delete(context.Context, *DeleteApplicationRequest) (*emptypb.Empty, error)
+	// Отзыв заявки её клиентом (субъект bearer-токена); доступен из любого статуса до APPROVED/REJECTED.
+	Cancel(context.Context, *CancelApplicationRequest) (*ApplicationResponse, error)
+	ListOffers(context.Context, *ListOffersRequest) (*ListOffersResponse, error)
+	// Подставляет условия предложения в заявку и переводит её в APPLICATION_AGREEMENT_CREATED.
+	AcceptOffer(context.Context, *AcceptOfferRequest) (*ApplicationResponse, error)
+	// IP-адрес и User-Agent берутся из метаданных запроса.
+	RecordConsent(context.Context, *RecordConsentRequest) (*Consent, error)
+	ListConsents(context.Context, *ListConsentsRequest) (*ListConsentsResponse, error)
 	List(context.Context, *ListApplicationRequest) (*ListApplicationResponse, error)
+	// Отправляет текущее состояние заявки, затем каждое изменение статуса.
+	// Через REST-шлюз приходит как поток JSON-объектов, по одному на строку.
+	WatchApplication(*WatchApplicationRequest, grpc.ServerStreamingServer[ApplicationUpdate]) error
+	WatchUserApplications(*WatchUserApplicationsRequest, grpc.ServerStreamingServer[ApplicationUpdate]) error
+	BatchGetApplications(context.Context, *BatchGetApplicationsRequest) (*BatchGetApplicationsResponse, error)
+	// Каждый элемент создаётся независимо, ошибки возвращаются по элементам.
+	BatchCreateApplications(context.Context, *BatchCreateApplicationsRequest) (*BatchCreateApplicationsResponse, error)
+	BulkTransition(context.Context, *BulkTransitionRequest) (*BulkTransitionResponse, error)
+	// Поток байтов файла выгрузки; склеенные data всех сообщений образуют файл.
+	ExportApplications(*ExportApplicationsRequest, grpc.ServerStreamingServer[ExportChunk]) error
 	mustEmbedUnimplementedApplicationServiceServer()
 }
 
@@ -127,9 +311,42 @@ func (UnimplementedApplicationServiceServer) Update(context.Context, *UpdateAppl
 func (UnimplementedApplicationServiceServer) Delete(context.Context, *DeleteApplicationRequest) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedApplicationServiceServer) Cancel(context.Context, *CancelApplicationRequest) (*ApplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedApplicationServiceServer) ListOffers(context.Context, *ListOffersRequest) (*ListOffersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOffers not implemented")
+}
+func (UnimplementedApplicationServiceServer) AcceptOffer(context.Context, *AcceptOfferRequest) (*ApplicationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptOffer not implemented")
+}
+func (UnimplementedApplicationServiceServer) RecordConsent(context.Context, *RecordConsentRequest) (*Consent, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RecordConsent not implemented")
+}
+func (UnimplementedApplicationServiceServer) ListConsents(context.Context, *ListConsentsRequest) (*ListConsentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListConsents not implemented")
+}
 func (UnimplementedApplicationServiceServer) List(context.Context, *ListApplicationRequest) (*ListApplicationResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedApplicationServiceServer) WatchApplication(*WatchApplicationRequest, grpc.ServerStreamingServer[ApplicationUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchApplication not implemented")
+}
+func (UnimplementedApplicationServiceServer) WatchUserApplications(*WatchUserApplicationsRequest, grpc.ServerStreamingServer[ApplicationUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUserApplications not implemented")
+}
+func (UnimplementedApplicationServiceServer) BatchGetApplications(context.Context, *BatchGetApplicationsRequest) (*BatchGetApplicationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetApplications not implemented")
+}
+func (UnimplementedApplicationServiceServer) BatchCreateApplications(context.Context, *BatchCreateApplicationsRequest) (*BatchCreateApplicationsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchCreateApplications not implemented")
+}
+func (UnimplementedApplicationServiceServer) BulkTransition(context.Context, *BulkTransitionRequest) (*BulkTransitionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BulkTransition not implemented")
+}
+func (UnimplementedApplicationServiceServer) ExportApplications(*ExportApplicationsRequest, grpc.ServerStreamingServer[ExportChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportApplications not implemented")
+}
 func (UnimplementedApplicationServiceServer) mustEmbedUnimplementedApplicationServiceServer() {}
 func (UnimplementedApplicationServiceServer) testEmbeddedByValue()                            {}
 
@@ -223,6 +440,96 @@ func _ApplicationService_Delete_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelApplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApplicationService_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).Cancel(ctx, req.(*CancelApplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_ListOffers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOffersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).ListOffers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApplicationService_ListOffers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).ListOffers(ctx, req.(*ListOffersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_AcceptOffer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AcceptOfferRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).AcceptOffer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApplicationService_AcceptOffer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).AcceptOffer(ctx, req.(*AcceptOfferRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_RecordConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).RecordConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApplicationService_RecordConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).RecordConsent(ctx, req.(*RecordConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_ListConsents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListConsentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).ListConsents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApplicationService_ListConsents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).ListConsents(ctx, req.(*ListConsentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ApplicationService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListApplicationRequest)
 	if err := dec(in); err != nil {
@@ -241,6 +548,93 @@ func _ApplicationService_List_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ApplicationService_WatchApplication_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchApplicationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApplicationServiceServer).WatchApplication(m, &grpc.GenericServerStream[WatchApplicationRequest, ApplicationUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApplicationService_WatchApplicationServer = grpc.ServerStreamingServer[ApplicationUpdate]
+
+func _ApplicationService_WatchUserApplications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchUserApplicationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApplicationServiceServer).WatchUserApplications(m, &grpc.GenericServerStream[WatchUserApplicationsRequest, ApplicationUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApplicationService_WatchUserApplicationsServer = grpc.ServerStreamingServer[ApplicationUpdate]
+
+func _ApplicationService_BatchGetApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchGetApplicationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).BatchGetApplications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApplicationService_BatchGetApplications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).BatchGetApplications(ctx, req.(*BatchGetApplicationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_BatchCreateApplications_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchCreateApplicationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).BatchCreateApplications(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApplicationService_BatchCreateApplications_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).BatchCreateApplications(ctx, req.(*BatchCreateApplicationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_BulkTransition_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkTransitionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ApplicationServiceServer).BulkTransition(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ApplicationService_BulkTransition_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ApplicationServiceServer).BulkTransition(ctx, req.(*BulkTransitionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ApplicationService_ExportApplications_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportApplicationsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ApplicationServiceServer).ExportApplications(m, &grpc.GenericServerStream[ExportApplicationsRequest, ExportChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ApplicationService_ExportApplicationsServer = grpc.ServerStreamingServer[ExportChunk]
+
 // ApplicationService_ServiceDesc is the grpc.ServiceDesc for ApplicationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -264,11 +658,59 @@ var ApplicationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Delete",
 			Handler:    _ApplicationService_Delete_Handler,
 		},
+		{
+			MethodName: "Cancel",
+			Handler:    _ApplicationService_Cancel_Handler,
+		},
+		{
+			MethodName: "ListOffers",
+			Handler:    _ApplicationService_ListOffers_Handler,
+		},
+		{
+			MethodName: "AcceptOffer",
+			Handler:    _ApplicationService_AcceptOffer_Handler,
+		},
+		{
+			MethodName: "RecordConsent",
+			Handler:    _ApplicationService_RecordConsent_Handler,
+		},
+		{
+			MethodName: "ListConsents",
+			Handler:    _ApplicationService_ListConsents_Handler,
+		},
 		{
 			MethodName: "List",
 			Handler:    _ApplicationService_List_Handler,
 		},
+		{
+			MethodName: "BatchGetApplications",
+			Handler:    _ApplicationService_BatchGetApplications_Handler,
+		},
+		{
+			MethodName: "BatchCreateApplications",
+			Handler:    _ApplicationService_BatchCreateApplications_Handler,
+		},
+		{
+			MethodName: "BulkTransition",
+			Handler:    _ApplicationService_BulkTransition_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchApplication",
+			Handler:       _ApplicationService_WatchApplication_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchUserApplications",
+			Handler:       _ApplicationService_WatchUserApplications_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportApplications",
+			Handler:       _ApplicationService_ExportApplications_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/v1/credit_application.proto",
 }
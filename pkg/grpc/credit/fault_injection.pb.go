@@ -0,0 +1,422 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.5
+// 	protoc        v5.29.3
+// source: proto/v1/fault_injection.proto
+
+package credit
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FaultTarget int32
+
+const (
+	FaultTarget_FAULT_TARGET_ANY        FaultTarget = 0
+	FaultTarget_FAULT_TARGET_GRPC       FaultTarget = 1
+	FaultTarget_FAULT_TARGET_KAFKA      FaultTarget = 2
+	FaultTarget_FAULT_TARGET_REPOSITORY FaultTarget = 3
+)
+
+// Enum value maps for FaultTarget.
+var (
+	FaultTarget_name = map[int32]string{
+		0: "FAULT_TARGET_ANY",
+		1: "FAULT_TARGET_GRPC",
+		2: "FAULT_TARGET_KAFKA",
+		3: "FAULT_TARGET_REPOSITORY",
+	}
+	FaultTarget_value = map[string]int32{
+		"FAULT_TARGET_ANY":        0,
+		"FAULT_TARGET_GRPC":       1,
+		"FAULT_TARGET_KAFKA":      2,
+		"FAULT_TARGET_REPOSITORY": 3,
+	}
+)
+
+func (x FaultTarget) Enum() *FaultTarget {
+	p := new(FaultTarget)
+	*p = x
+	return p
+}
+
+func (x FaultTarget) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FaultTarget) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_fault_injection_proto_enumTypes[0].Descriptor()
+}
+
+func (FaultTarget) Type() protoreflect.EnumType {
+	return &file_proto_v1_fault_injection_proto_enumTypes[0]
+}
+
+func (x FaultTarget) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FaultTarget.Descriptor instead.
+func (FaultTarget) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_fault_injection_proto_rawDescGZIP(), []int{0}
+}
+
+type FaultType int32
+
+const (
+	FaultType_FAULT_TYPE_LATENCY FaultType = 0
+	FaultType_FAULT_TYPE_ERROR   FaultType = 1
+	FaultType_FAULT_TYPE_ABORT   FaultType = 2
+)
+
+// Enum value maps for FaultType.
+var (
+	FaultType_name = map[int32]string{
+		0: "FAULT_TYPE_LATENCY",
+		1: "FAULT_TYPE_ERROR",
+		2: "FAULT_TYPE_ABORT",
+	}
+	FaultType_value = map[string]int32{
+		"FAULT_TYPE_LATENCY": 0,
+		"FAULT_TYPE_ERROR":   1,
+		"FAULT_TYPE_ABORT":   2,
+	}
+)
+
+func (x FaultType) Enum() *FaultType {
+	p := new(FaultType)
+	*p = x
+	return p
+}
+
+func (x FaultType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FaultType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_v1_fault_injection_proto_enumTypes[1].Descriptor()
+}
+
+func (FaultType) Type() protoreflect.EnumType {
+	return &file_proto_v1_fault_injection_proto_enumTypes[1]
+}
+
+func (x FaultType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FaultType.Descriptor instead.
+func (FaultType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_v1_fault_injection_proto_rawDescGZIP(), []int{1}
+}
+
+type FaultRule struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Name   string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Target FaultTarget            `protobuf:"varint,2,opt,name=target,proto3,enum=credit.v1.FaultTarget" json:"target,omitempty"`
+	// exact name or prefix ending with "*": gRPC full method, Kafka topic or repository method
+	Operation string            `protobuf:"bytes,3,opt,name=operation,proto3" json:"operation,omitempty"`
+	Headers   map[string]string `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// 0..100
+	Percentage float64              `protobuf:"fixed64,5,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	Type       FaultType            `protobuf:"varint,6,opt,name=type,proto3,enum=credit.v1.FaultType" json:"type,omitempty"`
+	Latency    *durationpb.Duration `protobuf:"bytes,7,opt,name=latency,proto3" json:"latency,omitempty"`
+	// google.rpc.Code for FAULT_TYPE_ERROR
+	Code          int32  `protobuf:"varint,8,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FaultRule) Reset() {
+	*x = FaultRule{}
+	mi := &file_proto_v1_fault_injection_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FaultRule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultRule) ProtoMessage() {}
+
+func (x *FaultRule) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_fault_injection_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultRule.ProtoReflect.Descriptor instead.
+func (*FaultRule) Descriptor() ([]byte, []int) {
+	return file_proto_v1_fault_injection_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FaultRule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FaultRule) GetTarget() FaultTarget {
+	if x != nil {
+		return x.Target
+	}
+	return FaultTarget_FAULT_TARGET_ANY
+}
+
+func (x *FaultRule) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *FaultRule) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *FaultRule) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *FaultRule) GetType() FaultType {
+	if x != nil {
+		return x.Type
+	}
+	return FaultType_FAULT_TYPE_LATENCY
+}
+
+func (x *FaultRule) GetLatency() *durationpb.Duration {
+	if x != nil {
+		return x.Latency
+	}
+	return nil
+}
+
+func (x *FaultRule) GetCode() int32 {
+	if x != nil {
+		return x.Code
+	}
+	return 0
+}
+
+func (x *FaultRule) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type FaultInjectionConfig struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	Rules         []*FaultRule           `protobuf:"bytes,2,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FaultInjectionConfig) Reset() {
+	*x = FaultInjectionConfig{}
+	mi := &file_proto_v1_fault_injection_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FaultInjectionConfig) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FaultInjectionConfig) ProtoMessage() {}
+
+func (x *FaultInjectionConfig) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_v1_fault_injection_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FaultInjectionConfig.ProtoReflect.Descriptor instead.
+func (*FaultInjectionConfig) Descriptor() ([]byte, []int) {
+	return file_proto_v1_fault_injection_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *FaultInjectionConfig) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *FaultInjectionConfig) GetRules() []*FaultRule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+var File_proto_v1_fault_injection_proto protoreflect.FileDescriptor
+
+var file_proto_v1_fault_injection_proto_rawDesc = string([]byte{
+	0x0a, 0x1e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x76, 0x31, 0x2f, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x5f, 0x69, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x12, 0x09, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x65, 0x6d, 0x70,
+	0x74, 0x79, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x93, 0x03, 0x0a, 0x09, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x74, 0x61,
+	0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x63, 0x72, 0x65,
+	0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x6f, 0x70,
+	0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f,
+	0x70, 0x65, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x63, 0x72, 0x65, 0x64,
+	0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x52, 0x75, 0x6c, 0x65, 0x2e,
+	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x61, 0x67, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x28, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x14, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12,
+	0x33, 0x0a, 0x07, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x07, 0x6c, 0x61, 0x74,
+	0x65, 0x6e, 0x63, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x1a, 0x3a, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5c,
+	0x0a, 0x14, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x12, 0x2a, 0x0a, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x75, 0x6c,
+	0x74, 0x52, 0x75, 0x6c, 0x65, 0x52, 0x05, 0x72, 0x75, 0x6c, 0x65, 0x73, 0x2a, 0x6f, 0x0a, 0x0b,
+	0x46, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x10, 0x46,
+	0x41, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x41, 0x4e, 0x59, 0x10,
+	0x00, 0x12, 0x15, 0x0a, 0x11, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45,
+	0x54, 0x5f, 0x47, 0x52, 0x50, 0x43, 0x10, 0x01, 0x12, 0x16, 0x0a, 0x12, 0x46, 0x41, 0x55, 0x4c,
+	0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54, 0x5f, 0x4b, 0x41, 0x46, 0x4b, 0x41, 0x10, 0x02,
+	0x12, 0x1b, 0x0a, 0x17, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x41, 0x52, 0x47, 0x45, 0x54,
+	0x5f, 0x52, 0x45, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x4f, 0x52, 0x59, 0x10, 0x03, 0x2a, 0x4f, 0x0a,
+	0x09, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x12, 0x46, 0x41,
+	0x55, 0x4c, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x4c, 0x41, 0x54, 0x45, 0x4e, 0x43, 0x59,
+	0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x41, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x01, 0x12, 0x14, 0x0a, 0x10, 0x46, 0x41, 0x55, 0x4c,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x42, 0x4f, 0x52, 0x54, 0x10, 0x02, 0x32, 0xc1,
+	0x01, 0x0a, 0x1a, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x41, 0x64, 0x6d, 0x69, 0x6e, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4c, 0x0a,
+	0x11, 0x47, 0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x1a, 0x1f, 0x2e, 0x63, 0x72, 0x65,
+	0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x55, 0x0a, 0x11, 0x53,
+	0x65, 0x74, 0x46, 0x61, 0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1f, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61, 0x75,
+	0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x67, 0x1a, 0x1f, 0x2e, 0x63, 0x72, 0x65, 0x64, 0x69, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x61,
+	0x75, 0x6c, 0x74, 0x49, 0x6e, 0x6a, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x42, 0x11, 0x5a, 0x0f, 0x70, 0x6b, 0x67, 0x2f, 0x67, 0x72, 0x70, 0x63, 0x2f, 0x63,
+	0x72, 0x65, 0x64, 0x69, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+})
+
+var (
+	file_proto_v1_fault_injection_proto_rawDescOnce sync.Once
+	file_proto_v1_fault_injection_proto_rawDescData []byte
+)
+
+func file_proto_v1_fault_injection_proto_rawDescGZIP() []byte {
+	file_proto_v1_fault_injection_proto_rawDescOnce.Do(func() {
+		file_proto_v1_fault_injection_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_v1_fault_injection_proto_rawDesc), len(file_proto_v1_fault_injection_proto_rawDesc)))
+	})
+	return file_proto_v1_fault_injection_proto_rawDescData
+}
+
+var file_proto_v1_fault_injection_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_v1_fault_injection_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_v1_fault_injection_proto_goTypes = []any{
+	(FaultTarget)(0),             // 0: credit.v1.FaultTarget
+	(FaultType)(0),               // 1: credit.v1.FaultType
+	(*FaultRule)(nil),            // 2: credit.v1.FaultRule
+	(*FaultInjectionConfig)(nil), // 3: credit.v1.FaultInjectionConfig
+	nil,                          // 4: credit.v1.FaultRule.HeadersEntry
+	(*durationpb.Duration)(nil),  // 5: google.protobuf.Duration
+	(*emptypb.Empty)(nil),        // 6: google.protobuf.Empty
+}
+var file_proto_v1_fault_injection_proto_depIdxs = []int32{
+	0, // 0: credit.v1.FaultRule.target:type_name -> credit.v1.FaultTarget
+	4, // 1: credit.v1.FaultRule.headers:type_name -> credit.v1.FaultRule.HeadersEntry
+	1, // 2: credit.v1.FaultRule.type:type_name -> credit.v1.FaultType
+	5, // 3: credit.v1.FaultRule.latency:type_name -> google.protobuf.Duration
+	2, // 4: credit.v1.FaultInjectionConfig.rules:type_name -> credit.v1.FaultRule
+	6, // 5: credit.v1.FaultInjectionAdminService.GetFaultInjection:input_type -> google.protobuf.Empty
+	3, // 6: credit.v1.FaultInjectionAdminService.SetFaultInjection:input_type -> credit.v1.FaultInjectionConfig
+	3, // 7: credit.v1.FaultInjectionAdminService.GetFaultInjection:output_type -> credit.v1.FaultInjectionConfig
+	3, // 8: credit.v1.FaultInjectionAdminService.SetFaultInjection:output_type -> credit.v1.FaultInjectionConfig
+	7, // [7:9] is the sub-list for method output_type
+	5, // [5:7] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_proto_v1_fault_injection_proto_init() }
+func file_proto_v1_fault_injection_proto_init() {
+	if File_proto_v1_fault_injection_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_v1_fault_injection_proto_rawDesc), len(file_proto_v1_fault_injection_proto_rawDesc)),
+			NumEnums:      2,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_v1_fault_injection_proto_goTypes,
+		DependencyIndexes: file_proto_v1_fault_injection_proto_depIdxs,
+		EnumInfos:         file_proto_v1_fault_injection_proto_enumTypes,
+		MessageInfos:      file_proto_v1_fault_injection_proto_msgTypes,
+	}.Build()
+	File_proto_v1_fault_injection_proto = out.File
+	file_proto_v1_fault_injection_proto_goTypes = nil
+	file_proto_v1_fault_injection_proto_depIdxs = nil
+}
@@ -0,0 +1,161 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: proto/v1/fault_injection.proto
+
+package credit
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	FaultInjectionAdminService_GetFaultInjection_FullMethodName = "/credit.v1.FaultInjectionAdminService/GetFaultInjection"
+	FaultInjectionAdminService_SetFaultInjection_FullMethodName = "/credit.v1.FaultInjectionAdminService/SetFaultInjection"
+)
+
+// FaultInjectionAdminServiceClient is the client API for FaultInjectionAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FaultInjectionAdminServiceClient interface {
+	GetFaultInjection(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FaultInjectionConfig, error)
+	SetFaultInjection(ctx context.Context, in *FaultInjectionConfig, opts ...grpc.CallOption) (*FaultInjectionConfig, error)
+}
+
+type faultInjectionAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFaultInjectionAdminServiceClient(cc grpc.ClientConnInterface) FaultInjectionAdminServiceClient {
+	return &faultInjectionAdminServiceClient{cc}
+}
+
+func (c *faultInjectionAdminServiceClient) GetFaultInjection(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*FaultInjectionConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FaultInjectionConfig)
+	err := c.cc.Invoke(ctx, FaultInjectionAdminService_GetFaultInjection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *faultInjectionAdminServiceClient) SetFaultInjection(ctx context.Context, in *FaultInjectionConfig, opts ...grpc.CallOption) (*FaultInjectionConfig, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FaultInjectionConfig)
+	err := c.cc.Invoke(ctx, FaultInjectionAdminService_SetFaultInjection_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FaultInjectionAdminServiceServer is the server API for FaultInjectionAdminService service.
+// All implementations must embed UnimplementedFaultInjectionAdminServiceServer
+// for forward compatibility.
+type FaultInjectionAdminServiceServer interface {
+	GetFaultInjection(context.Context, *emptypb.Empty) (*FaultInjectionConfig, error)
+	SetFaultInjection(context.Context, *FaultInjectionConfig) (*FaultInjectionConfig, error)
+	mustEmbedUnimplementedFaultInjectionAdminServiceServer()
+}
+
+// UnimplementedFaultInjectionAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFaultInjectionAdminServiceServer struct{}
+
+func (UnimplementedFaultInjectionAdminServiceServer) GetFaultInjection(context.Context, *emptypb.Empty) (*FaultInjectionConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFaultInjection not implemented")
+}
+func (UnimplementedFaultInjectionAdminServiceServer) SetFaultInjection(context.Context, *FaultInjectionConfig) (*FaultInjectionConfig, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetFaultInjection not implemented")
+}
+func (UnimplementedFaultInjectionAdminServiceServer) mustEmbedUnimplementedFaultInjectionAdminServiceServer() {
+}
+func (UnimplementedFaultInjectionAdminServiceServer) testEmbeddedByValue() {}
+
+// UnsafeFaultInjectionAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FaultInjectionAdminServiceServer will
+// result in compilation errors.
+type UnsafeFaultInjectionAdminServiceServer interface {
+	mustEmbedUnimplementedFaultInjectionAdminServiceServer()
+}
+
+func RegisterFaultInjectionAdminServiceServer(s grpc.ServiceRegistrar, srv FaultInjectionAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedFaultInjectionAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&FaultInjectionAdminService_ServiceDesc, srv)
+}
+
+func _FaultInjectionAdminService_GetFaultInjection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultInjectionAdminServiceServer).GetFaultInjection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FaultInjectionAdminService_GetFaultInjection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultInjectionAdminServiceServer).GetFaultInjection(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FaultInjectionAdminService_SetFaultInjection_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FaultInjectionConfig)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FaultInjectionAdminServiceServer).SetFaultInjection(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FaultInjectionAdminService_SetFaultInjection_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FaultInjectionAdminServiceServer).SetFaultInjection(ctx, req.(*FaultInjectionConfig))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FaultInjectionAdminService_ServiceDesc is the grpc.ServiceDesc for FaultInjectionAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FaultInjectionAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "credit.v1.FaultInjectionAdminService",
+	HandlerType: (*FaultInjectionAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetFaultInjection",
+			Handler:    _FaultInjectionAdminService_GetFaultInjection_Handler,
+		},
+		{
+			MethodName: "SetFaultInjection",
+			Handler:    _FaultInjectionAdminService_SetFaultInjection_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/v1/fault_injection.proto",
+}
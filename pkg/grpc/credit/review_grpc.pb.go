@@ -0,0 +1,397 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: proto/v1/review.proto
+
+package credit
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ManualReviewService_ListReviewQueue_FullMethodName     = "/credit.v1.ManualReviewService/ListReviewQueue"
+	ManualReviewService_GetReview_FullMethodName           = "/credit.v1.ManualReviewService/GetReview"
+	ManualReviewService_ClaimReview_FullMethodName         = "/credit.v1.ManualReviewService/ClaimReview"
+	ManualReviewService_ReleaseReview_FullMethodName       = "/credit.v1.ManualReviewService/ReleaseReview"
+	ManualReviewService_ApproveReview_FullMethodName       = "/credit.v1.ManualReviewService/ApproveReview"
+	ManualReviewService_RejectReview_FullMethodName        = "/credit.v1.ManualReviewService/RejectReview"
+	ManualReviewService_AddReviewNote_FullMethodName       = "/credit.v1.ManualReviewService/AddReviewNote"
+	ManualReviewService_AddReviewAttachment_FullMethodName = "/credit.v1.ManualReviewService/AddReviewAttachment"
+)
+
+// ManualReviewServiceClient is the client API for ManualReviewService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Очередь ручного андеррайтинга для заявок в статусе MANUAL_REVIEW.
+type ManualReviewServiceClient interface {
+	// Нерешённые заявки, самые старые первыми.
+	ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListReviewQueueResponse, error)
+	GetReview(ctx context.Context, in *GetReviewRequest, opts ...grpc.CallOption) (*Review, error)
+	ClaimReview(ctx context.Context, in *ReviewActionRequest, opts ...grpc.CallOption) (*Review, error)
+	ReleaseReview(ctx context.Context, in *ReviewActionRequest, opts ...grpc.CallOption) (*Review, error)
+	// Для сумм выше порога нужны одобрения двух разных андеррайтеров: после
+	// первого заявка возвращается в очередь.
+	ApproveReview(ctx context.Context, in *ReviewActionRequest, opts ...grpc.CallOption) (*Review, error)
+	RejectReview(ctx context.Context, in *RejectReviewRequest, opts ...grpc.CallOption) (*Review, error)
+	AddReviewNote(ctx context.Context, in *AddReviewNoteRequest, opts ...grpc.CallOption) (*Review, error)
+	AddReviewAttachment(ctx context.Context, in *AddReviewAttachmentRequest, opts ...grpc.CallOption) (*Review, error)
+}
+
+type manualReviewServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewManualReviewServiceClient(cc grpc.ClientConnInterface) ManualReviewServiceClient {
+	return &manualReviewServiceClient{cc}
+}
+
+func (c *manualReviewServiceClient) ListReviewQueue(ctx context.Context, in *ListReviewQueueRequest, opts ...grpc.CallOption) (*ListReviewQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewQueueResponse)
+	err := c.cc.Invoke(ctx, ManualReviewService_ListReviewQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manualReviewServiceClient) GetReview(ctx context.Context, in *GetReviewRequest, opts ...grpc.CallOption) (*Review, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Review)
+	err := c.cc.Invoke(ctx, ManualReviewService_GetReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manualReviewServiceClient) ClaimReview(ctx context.Context, in *ReviewActionRequest, opts ...grpc.CallOption) (*Review, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Review)
+	err := c.cc.Invoke(ctx, ManualReviewService_ClaimReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manualReviewServiceClient) ReleaseReview(ctx context.Context, in *ReviewActionRequest, opts ...grpc.CallOption) (*Review, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Review)
+	err := c.cc.Invoke(ctx, ManualReviewService_ReleaseReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manualReviewServiceClient) ApproveReview(ctx context.Context, in *ReviewActionRequest, opts ...grpc.CallOption) (*Review, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Review)
+	err := c.cc.Invoke(ctx, ManualReviewService_ApproveReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manualReviewServiceClient) RejectReview(ctx context.Context, in *RejectReviewRequest, opts ...grpc.CallOption) (*Review, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Review)
+	err := c.cc.Invoke(ctx, ManualReviewService_RejectReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manualReviewServiceClient) AddReviewNote(ctx context.Context, in *AddReviewNoteRequest, opts ...grpc.CallOption) (*Review, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Review)
+	err := c.cc.Invoke(ctx, ManualReviewService_AddReviewNote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *manualReviewServiceClient) AddReviewAttachment(ctx context.Context, in *AddReviewAttachmentRequest, opts ...grpc.CallOption) (*Review, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Review)
+	err := c.cc.Invoke(ctx, ManualReviewService_AddReviewAttachment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ManualReviewServiceServer is the server API for ManualReviewService service.
+// All implementations must embed UnimplementedManualReviewServiceServer
+// for forward compatibility.
+//
+// Очередь ручного андеррайтинга для заявок в статусе MANUAL_REVIEW.
+type ManualReviewServiceServer interface {
+	// Нерешённые заявки, самые старые первыми.
+	ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListReviewQueueResponse, error)
+	GetReview(context.Context, *GetReviewRequest) (*Review, error)
+	ClaimReview(context.Context, *ReviewActionRequest) (*Review, error)
+	ReleaseReview(context.Context, *ReviewActionRequest) (*Review, error)
+	// Для сумм выше порога нужны одобрения двух разных андеррайтеров: после
+	// первого заявка возвращается в очередь.
+	ApproveReview(context.Context, *ReviewActionRequest) (*Review, error)
+	RejectReview(context.Context, *RejectReviewRequest) (*Review, error)
+	AddReviewNote(context.Context, *AddReviewNoteRequest) (*Review, error)
+	AddReviewAttachment(context.Context, *AddReviewAttachmentRequest) (*Review, error)
+	mustEmbedUnimplementedManualReviewServiceServer()
+}
+
+// UnimplementedManualReviewServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedManualReviewServiceServer struct{}
+
+func (UnimplementedManualReviewServiceServer) ListReviewQueue(context.Context, *ListReviewQueueRequest) (*ListReviewQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReviewQueue not implemented")
+}
+func (UnimplementedManualReviewServiceServer) GetReview(context.Context, *GetReviewRequest) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReview not implemented")
+}
+func (UnimplementedManualReviewServiceServer) ClaimReview(context.Context, *ReviewActionRequest) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimReview not implemented")
+}
+func (UnimplementedManualReviewServiceServer) ReleaseReview(context.Context, *ReviewActionRequest) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReview not implemented")
+}
+func (UnimplementedManualReviewServiceServer) ApproveReview(context.Context, *ReviewActionRequest) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveReview not implemented")
+}
+func (UnimplementedManualReviewServiceServer) RejectReview(context.Context, *RejectReviewRequest) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectReview not implemented")
+}
+func (UnimplementedManualReviewServiceServer) AddReviewNote(context.Context, *AddReviewNoteRequest) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReviewNote not implemented")
+}
+func (UnimplementedManualReviewServiceServer) AddReviewAttachment(context.Context, *AddReviewAttachmentRequest) (*Review, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddReviewAttachment not implemented")
+}
+func (UnimplementedManualReviewServiceServer) mustEmbedUnimplementedManualReviewServiceServer() {}
+func (UnimplementedManualReviewServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeManualReviewServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ManualReviewServiceServer will
+// result in compilation errors.
+type UnsafeManualReviewServiceServer interface {
+	mustEmbedUnimplementedManualReviewServiceServer()
+}
+
+func RegisterManualReviewServiceServer(s grpc.ServiceRegistrar, srv ManualReviewServiceServer) {
+	// If the following call pancis, it indicates UnimplementedManualReviewServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ManualReviewService_ServiceDesc, srv)
+}
+
+func _ManualReviewService_ListReviewQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManualReviewServiceServer).ListReviewQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManualReviewService_ListReviewQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManualReviewServiceServer).ListReviewQueue(ctx, req.(*ListReviewQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManualReviewService_GetReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManualReviewServiceServer).GetReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManualReviewService_GetReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManualReviewServiceServer).GetReview(ctx, req.(*GetReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManualReviewService_ClaimReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManualReviewServiceServer).ClaimReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManualReviewService_ClaimReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManualReviewServiceServer).ClaimReview(ctx, req.(*ReviewActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManualReviewService_ReleaseReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManualReviewServiceServer).ReleaseReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManualReviewService_ReleaseReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManualReviewServiceServer).ReleaseReview(ctx, req.(*ReviewActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManualReviewService_ApproveReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManualReviewServiceServer).ApproveReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManualReviewService_ApproveReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManualReviewServiceServer).ApproveReview(ctx, req.(*ReviewActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManualReviewService_RejectReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManualReviewServiceServer).RejectReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManualReviewService_RejectReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManualReviewServiceServer).RejectReview(ctx, req.(*RejectReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManualReviewService_AddReviewNote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReviewNoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManualReviewServiceServer).AddReviewNote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManualReviewService_AddReviewNote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManualReviewServiceServer).AddReviewNote(ctx, req.(*AddReviewNoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ManualReviewService_AddReviewAttachment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReviewAttachmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ManualReviewServiceServer).AddReviewAttachment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ManualReviewService_AddReviewAttachment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ManualReviewServiceServer).AddReviewAttachment(ctx, req.(*AddReviewAttachmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ManualReviewService_ServiceDesc is the grpc.ServiceDesc for ManualReviewService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ManualReviewService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "credit.v1.ManualReviewService",
+	HandlerType: (*ManualReviewServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListReviewQueue",
+			Handler:    _ManualReviewService_ListReviewQueue_Handler,
+		},
+		{
+			MethodName: "GetReview",
+			Handler:    _ManualReviewService_GetReview_Handler,
+		},
+		{
+			MethodName: "ClaimReview",
+			Handler:    _ManualReviewService_ClaimReview_Handler,
+		},
+		{
+			MethodName: "ReleaseReview",
+			Handler:    _ManualReviewService_ReleaseReview_Handler,
+		},
+		{
+			MethodName: "ApproveReview",
+			Handler:    _ManualReviewService_ApproveReview_Handler,
+		},
+		{
+			MethodName: "RejectReview",
+			Handler:    _ManualReviewService_RejectReview_Handler,
+		},
+		{
+			MethodName: "AddReviewNote",
+			Handler:    _ManualReviewService_AddReviewNote_Handler,
+		},
+		{
+			MethodName: "AddReviewAttachment",
+			Handler:    _ManualReviewService_AddReviewAttachment_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/v1/review.proto",
+}
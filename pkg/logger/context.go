@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+type (
+	loggerKey        struct{}
+	applicationIDKey struct{}
+	principalKey     struct{}
+)
+
+// WithLogger attaches log to ctx; entry points (commands, interceptors, the
+// Kafka consumer) do it once and code below uses FromContext.
+func WithLogger(ctx context.Context, log *zap.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+// WithApplicationID makes FromContext tag logs with the application.
+func WithApplicationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, applicationIDKey{}, id)
+}
+
+// WithPrincipal makes FromContext tag logs with the caller.
+func WithPrincipal(ctx context.Context, principal string) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// FromContext returns the logger of ctx with the trace ID, span ID,
+// application ID and principal known in ctx. Without a logger in ctx it
+// returns a no-op logger.
+func FromContext(ctx context.Context) *zap.Logger {
+	log, ok := ctx.Value(loggerKey{}).(*zap.Logger)
+	if !ok {
+		return zap.NewNop()
+	}
+
+	var fields []zap.Field
+	if spanCtx := trace.SpanContextFromContext(ctx); spanCtx.IsValid() {
+		fields = append(fields,
+			zap.String("trace_id", spanCtx.TraceID().String()),
+			zap.String("span_id", spanCtx.SpanID().String()),
+		)
+	}
+	if id, ok := ctx.Value(applicationIDKey{}).(string); ok && id != "" {
+		fields = append(fields, zap.String("app_id", id))
+	}
+	if principal, ok := ctx.Value(principalKey{}).(string); ok && principal != "" {
+		fields = append(fields, zap.String("principal", principal))
+	}
+	if len(fields) == 0 {
+		return log
+	}
+	return log.With(fields...)
+}
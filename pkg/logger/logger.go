@@ -1,29 +1,119 @@
 package logger
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"gopkg.in/natefinch/lumberjack.v2"
 )
 
-var Logger *zap.Logger
+const (
+	ProfileDev  = "dev"
+	ProfileProd = "prod"
+
+	OutputStdout = "stdout"
+	OutputFile   = "file"
+	OutputBoth   = "both"
+)
+
+type Options struct {
+	// Profile dev writes colored console logs with stack traces from Warn;
+	// prod writes sampled JSON with stack traces from Error.
+	Profile string
+	// Level defaults to debug for dev and info for prod.
+	Level string
+	// Output is stdout, file or both. Console replaces os.Stdout, e.g. with
+	// os.Stderr for CLI commands that print their result.
+	Output  string
+	Console io.Writer
+	// File is rotated by size; MaxSizeMB, MaxBackups and MaxAgeDays follow
+	// lumberjack.
+	File       string
+	MaxSizeMB  int
+	MaxBackups int
+	MaxAgeDays int
+	Compress   bool
+}
+
+// New returns the logger and its level, which can be changed at runtime,
+// e.g. through the level's HTTP handler.
+func New(opts Options) (*zap.Logger, zap.AtomicLevel, error) {
+	level, err := parseLevel(opts)
+	if err != nil {
+		return nil, level, err
+	}
+
+	var encoder zapcore.Encoder
+	var zapOpts []zap.Option
+	switch opts.Profile {
+	case ProfileDev:
+		encoderConfig := zap.NewDevelopmentEncoderConfig()
+		encoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
+		encoder = zapcore.NewConsoleEncoder(encoderConfig)
+		zapOpts = []zap.Option{zap.AddCaller(), zap.AddStacktrace(zap.WarnLevel), zap.Development()}
+	case ProfileProd:
+		encoderConfig := zap.NewProductionEncoderConfig()
+		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoder = zapcore.NewJSONEncoder(encoderConfig)
+		zapOpts = []zap.Option{zap.AddCaller(), zap.AddStacktrace(zap.ErrorLevel)}
+	default:
+		return nil, level, fmt.Errorf("unknown log profile %q", opts.Profile)
+	}
 
-func InitLogger() {
-	logWriter := &lumberjack.Logger{
-		Filename:   "/var/log/myapp.log",
-		MaxSize:    10,
-		MaxBackups: 5,
-		MaxAge:     30,
-		Compress:   true,
+	writer, err := newWriter(opts)
+	if err != nil {
+		return nil, level, err
 	}
 
-	encoderConfig := zap.NewProductionEncoderConfig()
-	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
-	encoder := zapcore.NewJSONEncoder(encoderConfig)
-	writeSyncer := zapcore.AddSync(logWriter)
+	core := zapcore.NewCore(encoder, writer, level)
+	if opts.Profile == ProfileProd {
+		// Одинаковые сообщения сверх 100 в секунду пишем через одно из 100.
+		core = zapcore.NewSamplerWithOptions(core, 1e9, 100, 100)
+	}
+	return zap.New(core, zapOpts...), level, nil
+}
 
-	// TODO: DebugLevel применим только в dev режиме. Подумать над разграничением prod, dev
-	core := zapcore.NewCore(encoder, writeSyncer, zap.DebugLevel)
+func parseLevel(opts Options) (zap.AtomicLevel, error) {
+	if opts.Level == "" {
+		if opts.Profile == ProfileDev {
+			return zap.NewAtomicLevelAt(zap.DebugLevel), nil
+		}
+		return zap.NewAtomicLevelAt(zap.InfoLevel), nil
+	}
+	level, err := zap.ParseAtomicLevel(opts.Level)
+	if err != nil {
+		return level, fmt.Errorf("invalid log level: %w", err)
+	}
+	return level, nil
+}
 
-	Logger = zap.New(core, zap.AddCaller())
+func newWriter(opts Options) (zapcore.WriteSyncer, error) {
+	console := opts.Console
+	if console == nil {
+		console = os.Stdout
+	}
+	var writers []zapcore.WriteSyncer
+	if opts.Output == OutputStdout || opts.Output == OutputBoth {
+		writers = append(writers, zapcore.Lock(zapcore.AddSync(console)))
+	}
+	if opts.Output == OutputFile || opts.Output == OutputBoth {
+		if opts.File == "" {
+			return nil, errors.New("log file is required for file output")
+		}
+		writers = append(writers, zapcore.AddSync(&lumberjack.Logger{
+			Filename:   opts.File,
+			MaxSize:    opts.MaxSizeMB,
+			MaxBackups: opts.MaxBackups,
+			MaxAge:     opts.MaxAgeDays,
+			Compress:   opts.Compress,
+		}))
+	}
+	if len(writers) == 0 {
+		return nil, fmt.Errorf("unknown log output %q", opts.Output)
+	}
+	return zapcore.NewMultiWriteSyncer(writers...), nil
 }
@@ -0,0 +1,74 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+var (
+	ErrUnknownCurrency   = errors.New("unknown currency")
+	ErrCurrencyMismatch  = errors.New("currency mismatch")
+	ErrTooManyMinorUnits = errors.New("amount has more fractional digits than the currency allows")
+)
+
+// Currency is an ISO 4217 currency with its number of minor units.
+type Currency struct {
+	Code       string
+	MinorUnits int32
+}
+
+// Валюты с тремя знаками (KWD, BHD, ...) не поддерживаются: колонки сумм decimal(15,2).
+var currencies = map[string]Currency{
+	"RUB": {Code: "RUB", MinorUnits: 2},
+	"USD": {Code: "USD", MinorUnits: 2},
+	"EUR": {Code: "EUR", MinorUnits: 2},
+	"GBP": {Code: "GBP", MinorUnits: 2},
+	"CNY": {Code: "CNY", MinorUnits: 2},
+	"KZT": {Code: "KZT", MinorUnits: 2},
+	"BYN": {Code: "BYN", MinorUnits: 2},
+	"AMD": {Code: "AMD", MinorUnits: 2},
+	"JPY": {Code: "JPY", MinorUnits: 0},
+	"KRW": {Code: "KRW", MinorUnits: 0},
+}
+
+// ParseCurrency looks up a supported currency by its alphabetic code.
+func ParseCurrency(code string) (Currency, error) {
+	c, ok := currencies[strings.ToUpper(strings.TrimSpace(code))]
+	if !ok {
+		return Currency{}, fmt.Errorf("%w: %q", ErrUnknownCurrency, code)
+	}
+	return c, nil
+}
+
+func (c Currency) String() string {
+	return c.Code
+}
+
+// ValidateAmount checks that d fits the storage limits and is expressible in
+// the minor units of c, e.g. 100.5 JPY is rejected.
+func (c Currency) ValidateAmount(d decimal.Decimal) error {
+	if err := Validate(d); err != nil {
+		return err
+	}
+	if !d.Equal(d.Truncate(c.MinorUnits)) {
+		return fmt.Errorf("%w: %s %s", ErrTooManyMinorUnits, d, c.Code)
+	}
+	return nil
+}
+
+// SameCurrency checks that every non-empty code names c. Empty codes mean
+// "the application currency" and are accepted.
+func (c Currency) SameCurrency(codes ...string) error {
+	for _, code := range codes {
+		if code == "" {
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSpace(code), c.Code) {
+			return fmt.Errorf("%w: %s, expected %s", ErrCurrencyMismatch, code, c.Code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/shopspring/decimal"
+)
+
+// Ограничения совпадают с колонками decimal(15,2) в credit_applications.
+const (
+	MaxPrecision = 15
+	MaxScale     = 2
+	// maxWireScale bounds |scale| of incoming values so that a hostile
+	// request cannot make us build a decimal with a huge exponent.
+	maxWireScale = 18
+)
+
+var (
+	ErrNilDecimal        = errors.New("decimal is required")
+	ErrScaleOutOfRange   = errors.New("decimal scale is out of range")
+	ErrTooManyFractional = fmt.Errorf("decimal has more than %d fractional digits", MaxScale)
+	ErrPrecisionExceeded = fmt.Errorf("decimal exceeds %d significant digits", MaxPrecision)
+)
+
+var limit = decimal.New(1, MaxPrecision-MaxScale)
+
+// FromProto converts a wire decimal (value = unscaled * 10^-scale) into a
+// domain decimal. Trailing zeros beyond MaxScale are accepted (1.500 is 1.50),
+// any other loss of precision is an error.
+func FromProto(d *credit.Decimal) (decimal.Decimal, error) {
+	if d == nil {
+		return decimal.Zero, ErrNilDecimal
+	}
+	if d.Scale > maxWireScale || d.Scale < -maxWireScale {
+		return decimal.Zero, fmt.Errorf("%w: %d", ErrScaleOutOfRange, d.Scale)
+	}
+
+	value := decimal.New(d.Unscaled, -d.Scale)
+	if err := Validate(value); err != nil {
+		return decimal.Zero, err
+	}
+	return normalize(value), nil
+}
+
+// ToProto converts a domain decimal into its canonical wire form: scale is
+// always MaxScale, so 15.5 is sent as {unscaled: 1550, scale: 2}.
+func ToProto(d decimal.Decimal) (*credit.Decimal, error) {
+	if err := Validate(d); err != nil {
+		return nil, err
+	}
+	return &credit.Decimal{
+		Unscaled: d.Shift(MaxScale).IntPart(),
+		Scale:    MaxScale,
+	}, nil
+}
+
+// Validate checks that d fits decimal(MaxPrecision, MaxScale) without rounding.
+func Validate(d decimal.Decimal) error {
+	if !d.Equal(d.Truncate(MaxScale)) {
+		return fmt.Errorf("%w: %s", ErrTooManyFractional, d)
+	}
+	if d.Abs().GreaterThanOrEqual(limit) {
+		return fmt.Errorf("%w: %s", ErrPrecisionExceeded, d)
+	}
+	return nil
+}
+
+// normalize rescales an already validated d to exactly MaxScale fractional digits.
+func normalize(d decimal.Decimal) decimal.Decimal {
+	return decimal.New(d.Shift(MaxScale).IntPart(), -MaxScale)
+}
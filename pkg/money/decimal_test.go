@@ -0,0 +1,132 @@
+package money
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/shopspring/decimal"
+)
+
+// maxUnscaled is the largest unscaled value that fits decimal(15,2).
+const maxUnscaled = 999_999_999_999_999
+
+// validUnscaled generates unscaled values of decimal(15,2), biased towards the
+// precision limit.
+type validUnscaled int64
+
+func (validUnscaled) Generate(r *rand.Rand, _ int) reflect.Value {
+	var v int64
+	switch r.Intn(4) {
+	case 0:
+		v = maxUnscaled - r.Int63n(1000)
+	case 1:
+		v = r.Int63n(1000)
+	default:
+		v = r.Int63n(maxUnscaled + 1)
+	}
+	if r.Intn(2) == 0 {
+		v = -v
+	}
+	return reflect.ValueOf(validUnscaled(v))
+}
+
+func TestRoundTripProperty(t *testing.T) {
+	wireToDomainToWire := func(u validUnscaled) bool {
+		in := &credit.Decimal{Unscaled: int64(u), Scale: MaxScale}
+		d, err := FromProto(in)
+		if err != nil {
+			return false
+		}
+		out, err := ToProto(d)
+		return err == nil && out.Unscaled == in.Unscaled && out.Scale == in.Scale
+	}
+	if err := quick.Check(wireToDomainToWire, nil); err != nil {
+		t.Error(err)
+	}
+
+	domainToWireToDomain := func(u validUnscaled) bool {
+		d := decimal.New(int64(u), -MaxScale)
+		out, err := ToProto(d)
+		if err != nil {
+			return false
+		}
+		back, err := FromProto(out)
+		return err == nil && back.Equal(d)
+	}
+	if err := quick.Check(domainToWireToDomain, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFromProtoAcceptsEquivalentScales(t *testing.T) {
+	// 12.30 может прийти как {123, 1}, {1230, 2} или {12300, 3}: результат один.
+	property := func(u validUnscaled, extra uint8) bool {
+		shift := int32(extra % 4)
+		unscaled := int64(u)
+		for i := int32(0); i < shift; i++ {
+			unscaled *= 10
+		}
+		d, err := FromProto(&credit.Decimal{Unscaled: unscaled, Scale: MaxScale + shift})
+		return err == nil && d.Equal(decimal.New(int64(u), -MaxScale))
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFromProtoBoundaries(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *credit.Decimal
+		want error
+	}{
+		{"nil", nil, ErrNilDecimal},
+		{"scale 2", &credit.Decimal{Unscaled: 1, Scale: 2}, nil},
+		{"scale 3 with trailing zero", &credit.Decimal{Unscaled: 10, Scale: 3}, nil},
+		{"scale 3", &credit.Decimal{Unscaled: 1, Scale: 3}, ErrTooManyFractional},
+		{"negative scale", &credit.Decimal{Unscaled: 5, Scale: -3}, nil},
+		{"max precision", &credit.Decimal{Unscaled: maxUnscaled, Scale: 2}, nil},
+		{"min precision", &credit.Decimal{Unscaled: -maxUnscaled, Scale: 2}, nil},
+		{"precision 16", &credit.Decimal{Unscaled: maxUnscaled + 1, Scale: 2}, ErrPrecisionExceeded},
+		{"precision 16 negative", &credit.Decimal{Unscaled: -maxUnscaled - 1, Scale: 2}, ErrPrecisionExceeded},
+		{"huge scale", &credit.Decimal{Unscaled: 1, Scale: maxWireScale + 1}, ErrScaleOutOfRange},
+		{"huge negative scale", &credit.Decimal{Unscaled: 1, Scale: -maxWireScale - 1}, ErrScaleOutOfRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := FromProto(tt.in)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("FromProto(%v) error = %v, want %v", tt.in, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestToProtoBoundaries(t *testing.T) {
+	tests := []struct {
+		in   string
+		want error
+	}{
+		{"0.01", nil},
+		{"0.001", ErrTooManyFractional},
+		{"9999999999999.99", nil},
+		{"-9999999999999.99", nil},
+		{"10000000000000", ErrPrecisionExceeded},
+		{"-10000000000000", ErrPrecisionExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			out, err := ToProto(decimal.RequireFromString(tt.in))
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ToProto(%s) error = %v, want %v", tt.in, err, tt.want)
+			}
+			if err == nil && out.Scale != MaxScale {
+				t.Fatalf("ToProto(%s) scale = %d, want %d", tt.in, out.Scale, MaxScale)
+			}
+		})
+	}
+}
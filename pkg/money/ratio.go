@@ -0,0 +1,33 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Andronzi/credit-origination/pkg/grpc/credit"
+	"github.com/shopspring/decimal"
+)
+
+// RatioScale — число знаков у долей (DTI, лимит DTI), совпадает с decimal(5,4)
+// колонки max_dti и с округлением DTI при расчёте.
+const RatioScale = 4
+
+var ErrRatioTooPrecise = fmt.Errorf("ratio has more than %d fractional digits", RatioScale)
+
+var ErrRatioOutOfRange = errors.New("ratio does not fit the wire decimal")
+
+// RatioToProto converts a ratio into the wire decimal with scale RatioScale,
+// so 0.2404 is sent as {unscaled: 2404, scale: 4}. Amount limits do not apply.
+func RatioToProto(d decimal.Decimal) (*credit.Decimal, error) {
+	if !d.Equal(d.Truncate(RatioScale)) {
+		return nil, fmt.Errorf("%w: %s", ErrRatioTooPrecise, d)
+	}
+	unscaled := d.Shift(RatioScale)
+	if !unscaled.BigInt().IsInt64() {
+		return nil, fmt.Errorf("%w: %s", ErrRatioOutOfRange, d)
+	}
+	return &credit.Decimal{
+		Unscaled: unscaled.IntPart(),
+		Scale:    RatioScale,
+	}, nil
+}
@@ -1,15 +1,25 @@
-SCHEMA_FILE="schemas/avro/application/v1/ApplicationEvent.avsc"
+# v2 меняет тип сумм (long -> decimal), поэтому регистрируется в отдельном subject,
+# иначе registry отклонит схему проверкой совместимости. Консьюмеры находят схему по ID.
+register_schema() {
+  SUBJECT="$1"
+  SCHEMA_FILE="$2"
 
-# if ! avro-tools compile schema "$SCHEMA_FILE" /tmp > /dev/null 2>&1; then
-#   echo "Ошибка: Схема $SCHEMA_FILE невалидна"
-#   avro-tools compile schema "$SCHEMA_FILE" /tmp
-#   exit 1
-# fi
+  # if ! avro-tools compile schema "$SCHEMA_FILE" /tmp > /dev/null 2>&1; then
+  #   echo "Ошибка: Схема $SCHEMA_FILE невалидна"
+  #   avro-tools compile schema "$SCHEMA_FILE" /tmp
+  #   exit 1
+  # fi
 
+  SCHEMA=$(cat "$SCHEMA_FILE")
+  JSON_DATA=$(echo '{}' | jq --arg schema "$SCHEMA" '.schema = $schema')
 
-SCHEMA=$(cat "$SCHEMA_FILE")
-JSON_DATA=$(echo '{}' | jq --arg schema "$SCHEMA" '.schema = $schema')
+  curl -X POST -H "Content-Type: application/vnd.schemaregistry.v1+json" \
+    --data "$JSON_DATA" \
+    "http://localhost:8081/subjects/$SUBJECT/versions"
+}
 
-curl -X POST -H "Content-Type: application/vnd.schemaregistry.v1+json" \
-  --data "$JSON_DATA" \
-  http://localhost:8081/subjects/application/versions
\ No newline at end of file
+register_schema application "schemas/avro/application/v1/ApplicationEvent.avsc"
+register_schema application-v2 "schemas/avro/application/v2/ApplicationEvent.avsc"
+# v3 — следующая версия того же subject: v2 больше не меняется, новые типы
+# событий и поля с default добавляются в v3, registry проверяет совместимость.
+register_schema application-v2 "schemas/avro/application/v3/ApplicationEvent.avsc"